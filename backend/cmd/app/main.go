@@ -7,10 +7,13 @@ import (
 	"backend/internal/config"
 	"backend/internal/db"
 	"backend/internal/handlers"
+	"backend/internal/mail"
 	"backend/internal/oauth"
 	"backend/internal/repository"
+	"backend/internal/security"
 	"backend/internal/server"
 	"backend/internal/services"
+	"context"
 	"log"
 
 	_ "backend/docs"
@@ -49,12 +52,23 @@ func main() {
 
 	repos := repository.InitRepositories(dbConn)
 	jwtManager := auth.InitJWTManager(envs)
-	clients := clients.InitClients(envs)
+	clients, err := clients.InitClients(envs)
+	if err != nil {
+		log.Fatalf("Failed to init clients: %v", err)
+	}
 	oauth := oauth.InitOauth(envs)
-	service := services.InitServices(repos, redisClient, jwtManager, clients, oauth)
+	mailer, err := mail.InitMailer(envs)
+	if err != nil {
+		log.Fatalf("Failed to init mailer: %v", err)
+	}
+	loginThrottler := security.InitLoginThrottler(redisClient, envs)
+	service := services.InitServices(repos, redisClient, jwtManager, clients, oauth, mailer, loginThrottler)
 	handler := handlers.InitHandlers(service, envs)
 	appmiddleware := appmiddlewares.InitAppMiddlewares(jwtManager, service, envs)
 
+	go service.AccountService.RunPurgeWorker(context.Background())
+	go service.DataExportService.RunCleanupWorker(context.Background())
+
 	router := server.SetupRoutes(handler, appmiddleware)
 
 	server.StartServer(router, envs.Port)
@@ -1,8 +1,19 @@
 package apperrors
 
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
 type AppError struct {
 	Code    int
 	Message string
+	// RetryAfter is sent as the Retry-After header with 429 responses.
+	RetryAfter time.Duration
 }
 
 func (e *AppError) Error() string {
@@ -13,3 +24,49 @@ const (
 	PgErrUniqueViolation     = "23505"
 	PgErrForeignKeyViolation = "23503"
 )
+
+// Wrap turns a repository or client error into an AppError. AppErrors are
+// returned as is, cancelled and timed out requests get 400 and 504, anything
+// else is logged and reported as 500 with the message.
+func Wrap(err error, message string) error {
+	var appErr *AppError
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+
+	case errors.Is(err, context.Canceled):
+		log.Println("Request cancelled:", err)
+		return &AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Request cancelled",
+		}
+
+	case errors.Is(err, context.DeadlineExceeded):
+		log.Println("Deadline exceeded:", err)
+		return &AppError{
+			Code:    http.StatusGatewayTimeout,
+			Message: "Request timeout",
+		}
+
+	default:
+		log.Println("Unhandled error:", err)
+		return &AppError{
+			Code:    http.StatusInternalServerError,
+			Message: message,
+		}
+	}
+}
+
+// WrapNotFound works like Wrap and reports sql.ErrNoRows as 404 with
+// notFoundMessage.
+func WrapNotFound(err error, notFoundMessage, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("Not found:", err)
+		return &AppError{
+			Code:    http.StatusNotFound,
+			Message: notFoundMessage,
+		}
+	}
+
+	return Wrap(err, message)
+}
@@ -0,0 +1,48 @@
+package apperrors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	conflict := &AppError{Code: http.StatusConflict, Message: "Conflict"}
+
+	tests := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{"app error is kept", fmt.Errorf("wrapped: %w", conflict), http.StatusConflict, "Conflict"},
+		{"cancelled", context.Canceled, http.StatusBadRequest, "Request cancelled"},
+		{"timeout", fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "Request timeout"},
+		{"no rows is internal", sql.ErrNoRows, http.StatusInternalServerError, "Failed to get user"},
+		{"other error", errors.New("connection refused"), http.StatusInternalServerError, "Failed to get user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var appErr *AppError
+			assert.ErrorAs(t, Wrap(tt.err, "Failed to get user"), &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, tt.message, appErr.Message)
+		})
+	}
+}
+
+func TestWrapNotFound(t *testing.T) {
+	var appErr *AppError
+	assert.ErrorAs(t, WrapNotFound(sql.ErrNoRows, "User not found", "Failed to get user"), &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.Equal(t, "User not found", appErr.Message)
+
+	assert.ErrorAs(t, WrapNotFound(context.DeadlineExceeded, "User not found", "Failed to get user"), &appErr)
+	assert.Equal(t, http.StatusGatewayTimeout, appErr.Code)
+}
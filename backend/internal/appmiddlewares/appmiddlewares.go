@@ -7,18 +7,18 @@ import (
 )
 
 type AppMiddlewares struct {
-	AppAuthMiddlreware *AppAuthMiddlreware
-	AppRoleMiddleware  *AppRoleMiddlreware
-	AppCorsMiddleware  *AppCorsMiddleware
+	AppAuthMiddlreware      *AppAuthMiddlreware
+	AppPermissionMiddleware *AppPermissionMiddleware
+	AppCorsMiddleware       *AppCorsMiddleware
 }
 
 func InitAppMiddlewares(jwtManager *auth.JWTManager, services *services.Services, envs *config.Envs) *AppMiddlewares {
 	return &AppMiddlewares{
-		AppAuthMiddlreware: NewAppAuthMiddleware(jwtManager),
-		AppRoleMiddleware:  NewAppRoleMiddleware(services.UserService),
+		AppAuthMiddlreware:      NewAppAuthMiddleware(jwtManager, services.AuthService, services.APITokenService),
+		AppPermissionMiddleware: NewAppPermissionMiddleware(services.RoleService),
 		AppCorsMiddleware: NewAppCorsMiddleware(
 			[]string{envs.FrontendUrl},
-			[]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			[]string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 			[]string{
 				"Authorization",
 				"Content-Type",
@@ -27,6 +27,7 @@ func InitAppMiddlewares(jwtManager *auth.JWTManager, services *services.Services
 				"X-CSRF-Token",
 				"Content-Disposition",
 			},
+			[]string{"Retry-After"},
 			true,
 			300,
 			false,
@@ -1,38 +1,128 @@
 package appmiddlewares
 
 import (
+	"backend/internal/apperrors"
 	"backend/internal/auth"
+	"backend/internal/services"
 	"backend/internal/utils"
 	"context"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/websocket"
 )
 
 type AppAuthMiddlreware struct {
-	jwtManager *auth.JWTManager
+	jwtManager      *auth.JWTManager
+	authService     *services.AuthService
+	apiTokenService *services.APITokenService
 }
 
-func NewAppAuthMiddleware(jwtManager *auth.JWTManager) *AppAuthMiddlreware {
-	return &AppAuthMiddlreware{jwtManager: jwtManager}
+func NewAppAuthMiddleware(jwtManager *auth.JWTManager, authService *services.AuthService, apiTokenService *services.APITokenService) *AppAuthMiddlreware {
+	return &AppAuthMiddlreware{
+		jwtManager:      jwtManager,
+		authService:     authService,
+		apiTokenService: apiTokenService,
+	}
 }
 
+// AuthMiddleware accepts only the access_token cookie of a logged in user.
 func (m *AppAuthMiddlreware) AuthMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie("access_token")
-			if err != nil {
-				utils.JSONError(w, "Unauthorized", http.StatusUnauthorized)
+			m.serveSession(w, r, next)
+		})
+	}
+}
+
+// APIAuthMiddleware additionally accepts API tokens in the Authorization
+// header. The token needs the "<resource>:read" scope for GET requests and
+// "<resource>:write" for the others and for WebSocket upgrades.
+func (m *AppAuthMiddlreware) APIAuthMiddleware(resource string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				m.serveSession(w, r, next)
 				return
 			}
 
-			claims, err := m.jwtManager.Verify(cookie.Value)
-			if err != nil {
-				utils.JSONError(w, "Invalid token", http.StatusForbidden)
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				utils.JSONError(w, "Invalid authorization header", http.StatusUnauthorized)
 				return
 			}
 
 			ctx := r.Context()
-			ctx = context.WithValue(ctx, "user_id", claims.UserID)
+			apiToken, err := m.apiTokenService.Authenticate(ctx, token)
+			if err != nil {
+				var appErr *apperrors.AppError
+				if errors.As(err, &appErr) {
+					utils.JSONError(w, appErr.Message, appErr.Code)
+					return
+				}
+				utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			scope := requiredScope(resource, r)
+			if !slices.Contains(apiToken.Scopes, scope) {
+				utils.JSONError(w, "Token has no scope "+scope, http.StatusForbidden)
+				return
+			}
+
+			ctx = context.WithValue(ctx, "user_id", apiToken.UserID)
+			ctx = context.WithValue(ctx, "api_token_id", apiToken.ID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// requiredScope treats a WebSocket upgrade as a write, the messages of a live
+// connection can change data although the upgrade is a GET request.
+func requiredScope(resource string, r *http.Request) string {
+	if websocket.IsWebSocketUpgrade(r) {
+		return resource + ":write"
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return resource + ":read"
+	}
+
+	return resource + ":write"
+}
+
+func (m *AppAuthMiddlreware) serveSession(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	cookie, err := r.Cookie("access_token")
+	if err != nil {
+		utils.JSONError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := m.jwtManager.Verify(cookie.Value)
+	if err != nil {
+		utils.JSONError(w, "Invalid token", http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	if err := m.authService.VerifyClaims(ctx, claims); err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = context.WithValue(ctx, "user_id", claims.UserID)
+	ctx = context.WithValue(ctx, "session_id", claims.SessionID)
+	ctx = context.WithValue(ctx, "token_id", claims.ID)
+	if claims.ExpiresAt != nil {
+		ctx = context.WithValue(ctx, "token_expires_at", claims.ExpiresAt.Time)
+	}
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,33 @@
+package appmiddlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredScope(t *testing.T) {
+	upgrade := httptest.NewRequest(http.MethodGet, "/workouts/1/live", nil)
+	upgrade.Header.Set("Connection", "Upgrade")
+	upgrade.Header.Set("Upgrade", "websocket")
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		want    string
+	}{
+		{"get", httptest.NewRequest(http.MethodGet, "/workouts/1", nil), "workouts:read"},
+		{"head", httptest.NewRequest(http.MethodHead, "/workouts/1", nil), "workouts:read"},
+		{"post", httptest.NewRequest(http.MethodPost, "/workouts", nil), "workouts:write"},
+		{"delete", httptest.NewRequest(http.MethodDelete, "/workouts/1", nil), "workouts:write"},
+		{"websocket upgrade", upgrade, "workouts:write"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiredScope("workouts", tt.request))
+		})
+	}
+}
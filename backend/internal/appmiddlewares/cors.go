@@ -10,6 +10,7 @@ type AppCorsMiddleware struct {
 	allowedOrigins   []string
 	allowedMethods   []string
 	allowedHeaders   []string
+	exposedHeaders   []string
 	allowCredentials bool
 	maxAge           int
 	debug            bool
@@ -19,6 +20,7 @@ func NewAppCorsMiddleware(
 	allowedOrigins []string,
 	allowedMethods []string,
 	allowedHeaders []string,
+	exposedHeaders []string,
 	allowCrecentials bool,
 	maxAge int,
 	debug bool,
@@ -27,6 +29,7 @@ func NewAppCorsMiddleware(
 		allowedOrigins:   allowedOrigins,
 		allowedMethods:   allowedMethods,
 		allowedHeaders:   allowedHeaders,
+		exposedHeaders:   exposedHeaders,
 		allowCredentials: allowCrecentials,
 		maxAge:           maxAge,
 		debug:            debug,
@@ -38,6 +41,7 @@ func (m *AppCorsMiddleware) Handler() func(http.Handler) http.Handler {
 		AllowedOrigins:   m.allowedOrigins,
 		AllowedMethods:   m.allowedMethods,
 		AllowedHeaders:   m.allowedHeaders,
+		ExposedHeaders:   m.exposedHeaders,
 		AllowCredentials: m.allowCredentials,
 		MaxAge:           m.maxAge,
 		Debug:            m.debug,
@@ -0,0 +1,51 @@
+package appmiddlewares
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"errors"
+	"net/http"
+)
+
+type AppPermissionMiddleware struct {
+	roleService *services.RoleService
+}
+
+func NewAppPermissionMiddleware(roleService *services.RoleService) *AppPermissionMiddleware {
+	return &AppPermissionMiddleware{roleService: roleService}
+}
+
+// RequirePermission lets the request through only if one of the user's roles
+// grants the permission. It must run after the auth middleware.
+func (m *AppPermissionMiddleware) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			userID, ok := ctx.Value("user_id").(int)
+			if !ok || userID == 0 {
+				utils.JSONError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			allowed, err := m.roleService.HasPermission(ctx, userID, permission)
+			if err != nil {
+				var appErr *apperrors.AppError
+				if errors.As(err, &appErr) {
+					utils.JSONError(w, appErr.Message, appErr.Code)
+					return
+				}
+				utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			if !allowed {
+				utils.JSONError(w, "Access denied: insufficient rights", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
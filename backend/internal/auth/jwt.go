@@ -10,6 +10,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type JWTManager struct {
 	secretKey string
 }
@@ -21,11 +26,21 @@ func InitJWTManager(envs *config.Envs) *JWTManager {
 	return &JWTManager{secretKey: envs.JWTSecureKey}
 }
 
-func (m *JWTManager) Generate(user *models.User) (string, error) {
+func (m *JWTManager) Generate(user *models.User, sessionID int) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := &models.Claims{
-		UserID: user.ID,
+		UserID:       user.ID,
+		SessionID:    sessionID,
+		TokenVersion: user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
 		},
 	}
 
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 key from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238 test vectors truncated to 6 digits.
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, v := range vectors {
+		step, ok := ValidateTOTP(rfcSecret, v.code, time.Unix(v.unix, 0))
+		assert.True(t, ok, v.code)
+		assert.Equal(t, v.unix/30, step)
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	_, ok := ValidateTOTP(rfcSecret, "287082", now.Add(TOTPPeriod))
+	assert.True(t, ok)
+
+	_, ok = ValidateTOTP(rfcSecret, "287082", now.Add(3*TOTPPeriod))
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(rfcSecret, "000000", now)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP("not base32!", "287082", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("SECRET", "user@example.com")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Online%20Workout%20Tracker:user@example.com?"))
+	assert.Contains(t, uri, "secret=SECRET")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Len(t, hashes, 10)
+
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, hashes[i], HashRecoveryCode(code))
+		assert.Equal(t, hashes[i], HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+}
@@ -1,13 +1,41 @@
 package clients
 
-import "backend/internal/config"
+import (
+	"backend/internal/config"
+	"fmt"
+)
+
+const (
+	NutritionProviderNutritionix = "nutritionix"
+	NutritionProviderFatSecret   = "fatsecret"
+	NutritionProviderFake        = "fake"
+)
 
 type Clients struct {
-	NutritionixClient *NutritionixClient
+	NutritionProvider NutritionProvider
 }
 
-func InitClients(envs *config.Envs) *Clients {
-	return &Clients{
-		NutritionixClient: NewNutritionixClient(envs),
+// InitClients selects the nutrition provider by NUTRITION_PROVIDER,
+// Nutritionix is used when it is not set.
+func InitClients(envs *config.Envs) (*Clients, error) {
+	var provider NutritionProvider
+
+	switch envs.NutritionProvider {
+	case "", NutritionProviderNutritionix:
+		provider = NewNutritionixClient(envs)
+	case NutritionProviderFatSecret:
+		provider = NewFatSecretClient(envs)
+	case NutritionProviderFake:
+		fake, err := NewFakeNutritionProvider(envs.NutritionFixtureFile)
+		if err != nil {
+			return nil, err
+		}
+		provider = fake
+	default:
+		return nil, fmt.Errorf("unknown nutrition provider: %s", envs.NutritionProvider)
 	}
+
+	return &Clients{
+		NutritionProvider: provider,
+	}, nil
 }
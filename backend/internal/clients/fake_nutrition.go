@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"backend/internal/models"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed fixtures/nutrition.json
+var defaultNutritionFixture []byte
+
+// fakeQueryItem matches one food of a query built by the food service, e.g.
+// "200g rice" or "2 egg".
+var fakeQueryItem = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([^\d\s]*)\s+(.+)$`)
+
+type fakeFood struct {
+	models.NutritionFood
+	Aliases []string `json:"aliases"`
+}
+
+// FakeNutritionProvider answers from a fixture file without network calls,
+// the same query always gives the same result.
+type FakeNutritionProvider struct {
+	foods []fakeFood
+}
+
+// NewFakeNutritionProvider loads the fixture from path, the embedded
+// fixtures/nutrition.json is used when path is empty.
+func NewFakeNutritionProvider(path string) (*FakeNutritionProvider, error) {
+	data := defaultNutritionFixture
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nutrition fixture: %w", err)
+		}
+	}
+
+	var fixture struct {
+		Foods []fakeFood `json:"foods"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode nutrition fixture: %w", err)
+	}
+
+	return &FakeNutritionProvider{foods: fixture.Foods}, nil
+}
+
+// Parse splits the query by "and" and commas. The amount is in grams for
+// "g", "kg" and "ml" and in servings of the fixture food otherwise.
+func (p *FakeNutritionProvider) Parse(ctx context.Context, query string) ([]models.NutritionFood, error) {
+	parts := strings.Split(strings.ReplaceAll(strings.ToLower(query), " and ", ","), ",")
+
+	foods := []models.NutritionFood{}
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		qty, unit, name := 1.0, "", part
+		if m := fakeQueryItem.FindStringSubmatch(part); m != nil {
+			qty, _ = strconv.ParseFloat(m[1], 64)
+			unit, name = m[2], m[3]
+		}
+
+		food := p.find(name)
+		if food == nil {
+			return nil, ErrFoodNotFound
+		}
+
+		foods = append(foods, eaten(food.NutritionFood, qty, unit))
+	}
+
+	if len(foods) == 0 {
+		return nil, ErrFoodNotFound
+	}
+
+	return foods, nil
+}
+
+func (p *FakeNutritionProvider) Search(ctx context.Context, query string) ([]models.NutritionFood, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	foods := []models.NutritionFood{}
+	for _, food := range p.foods {
+		if strings.Contains(food.Name, query) || containsAlias(food.Aliases, query) {
+			foods = append(foods, food.NutritionFood)
+		}
+	}
+
+	return foods, nil
+}
+
+func (p *FakeNutritionProvider) GetFood(ctx context.Context, id string) (*models.NutritionFood, error) {
+	for _, food := range p.foods {
+		if food.ID == id {
+			result := food.NutritionFood
+			return &result, nil
+		}
+	}
+
+	return nil, ErrFoodNotFound
+}
+
+func (p *FakeNutritionProvider) GetFoodByBarcode(ctx context.Context, barcode string) (*models.NutritionFood, error) {
+	for _, food := range p.foods {
+		if food.Barcode != "" && food.Barcode == barcode {
+			result := food.NutritionFood
+			return &result, nil
+		}
+	}
+
+	return nil, ErrFoodNotFound
+}
+
+func (p *FakeNutritionProvider) find(name string) *fakeFood {
+	for i, food := range p.foods {
+		if food.Name == name || containsExact(food.Aliases, name) {
+			return &p.foods[i]
+		}
+	}
+
+	return nil
+}
+
+// eaten scales the nutrients of the fixture serving to the eaten amount.
+func eaten(food models.NutritionFood, qty float64, unit string) models.NutritionFood {
+	var grams float64
+	switch unit {
+	case "g", "ml":
+		grams = qty
+	case "kg":
+		grams = qty * 1000
+	default:
+		grams = qty / food.ServingQty * food.ServingWeightGrams
+		if unit == "" {
+			unit = food.ServingUnit
+		}
+	}
+
+	ratio := grams / food.ServingWeightGrams
+	return models.NutritionFood{
+		ID:                 food.ID,
+		Name:               food.Name,
+		Brand:              food.Brand,
+		ServingQty:         qty,
+		ServingUnit:        unit,
+		ServingWeightGrams: round2(grams),
+		Calories:           round2(food.Calories * ratio),
+		Protein:            round2(food.Protein * ratio),
+		Carbs:              round2(food.Carbs * ratio),
+		Fat:                round2(food.Fat * ratio),
+	}
+}
+
+func containsAlias(aliases []string, query string) bool {
+	for _, alias := range aliases {
+		if strings.Contains(alias, query) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsExact(aliases []string, name string) bool {
+	for _, alias := range aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,380 @@
+package clients
+
+import (
+	"backend/internal/config"
+	"backend/internal/models"
+	"backend/internal/oauth"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const fatSecretBaseURL = "https://platform.fatsecret.com/rest"
+
+// fatSecretDescription matches the food_description of the search results,
+// e.g. "Per 100g - Calories: 52kcal | Fat: 0.17g | Carbs: 13.81g | Protein: 0.26g".
+var (
+	fatSecretDescription = regexp.MustCompile(`^Per (.+?) - Calories: ([\d.]+)kcal \| Fat: ([\d.]+)g \| Carbs: ([\d.]+)g \| Protein: ([\d.]+)g`)
+	fatSecretServing     = regexp.MustCompile(`^([\d.]+)\s*(.*)$`)
+)
+
+// FatSecretClient uses the FatSecret Platform API on behalf of the
+// application, unlike oauth.FatSecretAuthClient that reads the diary of a
+// connected user.
+type FatSecretClient struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	BaseURL        string
+	HTTPClient     *http.Client
+}
+
+func NewFatSecretClient(envs *config.Envs) *FatSecretClient {
+	return &FatSecretClient{
+		ConsumerKey:    envs.FatsecretConsumerKey,
+		ConsumerSecret: envs.FatsecretConsumerSecret,
+		BaseURL:        fatSecretBaseURL,
+		HTTPClient:     &http.Client{},
+	}
+}
+
+func (c *FatSecretClient) Parse(ctx context.Context, query string) ([]models.NutritionFood, error) {
+	jsonBody, err := json.Marshal(map[string]any{
+		"user_input":        query,
+		"include_food_data": false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	var response struct {
+		FoodResponse []struct {
+			FoodID        flexString `json:"food_id"`
+			FoodEntryName string     `json:"food_entry_name"`
+			Eaten         struct {
+				Units                   flexFloat `json:"units"`
+				MetricDescription       string    `json:"metric_description"`
+				TotalMetricAmount       flexFloat `json:"total_metric_amount"`
+				TotalNutritionalContent struct {
+					Calories flexFloat `json:"calories"`
+					Protein  flexFloat `json:"protein"`
+					Carbs    flexFloat `json:"carbohydrate"`
+					Fat      flexFloat `json:"fat"`
+				} `json:"total_nutritional_content"`
+			} `json:"eaten"`
+		} `json:"food_response"`
+	}
+
+	if err := c.do(ctx, "POST", "/natural-language-processing/v1", nil, jsonBody, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.FoodResponse) == 0 {
+		return nil, ErrFoodNotFound
+	}
+
+	foods := make([]models.NutritionFood, 0, len(response.FoodResponse))
+	for _, f := range response.FoodResponse {
+		nutrients := f.Eaten.TotalNutritionalContent
+		food := models.NutritionFood{
+			ID:          string(f.FoodID),
+			Name:        f.FoodEntryName,
+			ServingQty:  float64(f.Eaten.TotalMetricAmount),
+			ServingUnit: f.Eaten.MetricDescription,
+			Calories:    float64(nutrients.Calories),
+			Protein:     float64(nutrients.Protein),
+			Carbs:       float64(nutrients.Carbs),
+			Fat:         float64(nutrients.Fat),
+		}
+		if food.ServingUnit == "g" {
+			food.ServingWeightGrams = food.ServingQty
+		}
+		foods = append(foods, food)
+	}
+
+	return foods, nil
+}
+
+func (c *FatSecretClient) Search(ctx context.Context, query string) ([]models.NutritionFood, error) {
+	params := map[string]string{
+		"method":            "foods.search",
+		"search_expression": query,
+		"max_results":       "20",
+	}
+
+	var response struct {
+		Foods struct {
+			Food oneOrMany[struct {
+				FoodID          string `json:"food_id"`
+				FoodName        string `json:"food_name"`
+				BrandName       string `json:"brand_name"`
+				FoodDescription string `json:"food_description"`
+			}] `json:"food"`
+		} `json:"foods"`
+	}
+
+	if err := c.do(ctx, "GET", "/server.api", params, nil, &response); err != nil {
+		return nil, err
+	}
+
+	foods := make([]models.NutritionFood, 0, len(response.Foods.Food))
+	for _, f := range response.Foods.Food {
+		food := models.NutritionFood{
+			ID:    f.FoodID,
+			Name:  f.FoodName,
+			Brand: f.BrandName,
+		}
+
+		// Foods with an unexpected description are still found by GetFood.
+		if m := fatSecretDescription.FindStringSubmatch(f.FoodDescription); m != nil {
+			food.ServingQty, food.ServingUnit, food.ServingWeightGrams = parseFatSecretServing(m[1])
+			food.Calories = parseFloat(m[2])
+			food.Fat = parseFloat(m[3])
+			food.Carbs = parseFloat(m[4])
+			food.Protein = parseFloat(m[5])
+		}
+
+		foods = append(foods, food)
+	}
+
+	return foods, nil
+}
+
+// GetFood returns the nutrients of the default serving of the food.
+func (c *FatSecretClient) GetFood(ctx context.Context, id string) (*models.NutritionFood, error) {
+	params := map[string]string{
+		"method":  "food.get.v2",
+		"food_id": id,
+	}
+
+	var response struct {
+		Food *struct {
+			FoodID    string `json:"food_id"`
+			FoodName  string `json:"food_name"`
+			BrandName string `json:"brand_name"`
+			Servings  struct {
+				Serving oneOrMany[struct {
+					MeasurementDescription string    `json:"measurement_description"`
+					NumberOfUnits          flexFloat `json:"number_of_units"`
+					MetricServingAmount    flexFloat `json:"metric_serving_amount"`
+					MetricServingUnit      string    `json:"metric_serving_unit"`
+					IsDefault              string    `json:"is_default"`
+					Calories               flexFloat `json:"calories"`
+					Protein                flexFloat `json:"protein"`
+					Carbs                  flexFloat `json:"carbohydrate"`
+					Fat                    flexFloat `json:"fat"`
+				}] `json:"serving"`
+			} `json:"servings"`
+		} `json:"food"`
+	}
+
+	if err := c.do(ctx, "GET", "/server.api", params, nil, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Food == nil || len(response.Food.Servings.Serving) == 0 {
+		return nil, ErrFoodNotFound
+	}
+
+	serving := response.Food.Servings.Serving[0]
+	for _, s := range response.Food.Servings.Serving {
+		if s.IsDefault == "1" {
+			serving = s
+			break
+		}
+	}
+
+	food := &models.NutritionFood{
+		ID:          response.Food.FoodID,
+		Name:        response.Food.FoodName,
+		Brand:       response.Food.BrandName,
+		ServingQty:  float64(serving.NumberOfUnits),
+		ServingUnit: serving.MeasurementDescription,
+		Calories:    float64(serving.Calories),
+		Protein:     float64(serving.Protein),
+		Carbs:       float64(serving.Carbs),
+		Fat:         float64(serving.Fat),
+	}
+	if serving.MetricServingUnit == "g" {
+		food.ServingWeightGrams = float64(serving.MetricServingAmount)
+	}
+
+	return food, nil
+}
+
+func (c *FatSecretClient) GetFoodByBarcode(ctx context.Context, barcode string) (*models.NutritionFood, error) {
+	params := map[string]string{
+		"method":  "food.find_id_for_barcode",
+		"barcode": barcode,
+	}
+
+	var response struct {
+		FoodID struct {
+			Value string `json:"value"`
+		} `json:"food_id"`
+	}
+
+	if err := c.do(ctx, "GET", "/server.api", params, nil, &response); err != nil {
+		return nil, err
+	}
+
+	// FatSecret answers with id 0 when the barcode is unknown.
+	if response.FoodID.Value == "" || response.FoodID.Value == "0" {
+		return nil, ErrFoodNotFound
+	}
+
+	food, err := c.GetFood(ctx, response.FoodID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	food.Barcode = barcode
+	return food, nil
+}
+
+// do signs the request with the query parameters and decodes the response
+// into result. FatSecret reports errors with status 200 and an error object.
+func (c *FatSecretClient) do(ctx context.Context, method, path string, params map[string]string, body []byte, result any) error {
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["format"] = "json"
+
+	endpoint := c.BaseURL + path
+	signed := oauth.SignedParams(method, endpoint, params, c.ConsumerKey, c.ConsumerSecret)
+
+	query := url.Values{}
+	for k, v := range signed {
+		query.Set(k, v)
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+"?"+query.Encode(), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: %s, response %s", resp.Status, string(data))
+	}
+
+	var apiErr struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &apiErr); err == nil && apiErr.Error != nil {
+		// 106 is an invalid id and 211 no food found by the NLP endpoint.
+		if apiErr.Error.Code == 106 || apiErr.Error.Code == 211 {
+			return ErrFoodNotFound
+		}
+		return fmt.Errorf("API error %d: %s", apiErr.Error.Code, apiErr.Error.Message)
+	}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// parseFatSecretServing splits a serving like "100g" or "1 medium" into the
+// quantity and the unit, the weight is known for grams only.
+func parseFatSecretServing(serving string) (float64, string, float64) {
+	m := fatSecretServing.FindStringSubmatch(strings.TrimSpace(serving))
+	if m == nil {
+		return 1, serving, 0
+	}
+
+	qty := parseFloat(m[1])
+	unit := strings.TrimSpace(m[2])
+	if unit == "g" {
+		return qty, unit, qty
+	}
+
+	return qty, unit, 0
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// FatSecret encodes most numbers as strings, a single result as an object
+// instead of an array, and empty results as an empty string.
+
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	*f = flexFloat(v)
+	return nil
+}
+
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	*s = flexString(strings.Trim(string(data), `"`))
+	return nil
+}
+
+type oneOrMany[T any] []T
+
+func (o *oneOrMany[T]) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '"' || string(trimmed) == "null" {
+		*o = nil
+		return nil
+	}
+
+	if trimmed[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return err
+		}
+		*o = items
+		return nil
+	}
+
+	var item T
+	if err := json.Unmarshal(trimmed, &item); err != nil {
+		return err
+	}
+	*o = []T{item}
+	return nil
+}
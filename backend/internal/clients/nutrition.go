@@ -0,0 +1,21 @@
+package clients
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+)
+
+var ErrFoodNotFound = errors.New("food not found")
+
+// NutritionProvider looks up the nutrients of foods. NutritionixClient and
+// FatSecretClient call the external APIs, FakeNutritionProvider answers from
+// a fixture file for local development and tests.
+type NutritionProvider interface {
+	// Parse recognizes foods in a natural language query like
+	// "200g rice and 2 eggs", the nutrients are for the eaten amount.
+	Parse(ctx context.Context, query string) ([]models.NutritionFood, error)
+	Search(ctx context.Context, query string) ([]models.NutritionFood, error)
+	GetFood(ctx context.Context, id string) (*models.NutritionFood, error)
+	GetFoodByBarcode(ctx context.Context, barcode string) (*models.NutritionFood, error)
+}
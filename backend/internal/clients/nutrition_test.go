@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"backend/internal/config"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeNutritionProviderParse(t *testing.T) {
+	provider, err := NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	foods, err := provider.Parse(context.Background(), "200g rice and 2 eggs")
+	assert.NoError(t, err)
+	assert.Len(t, foods, 2)
+
+	assert.Equal(t, "rice", foods[0].Name)
+	assert.Equal(t, 200.0, foods[0].ServingWeightGrams)
+	assert.Equal(t, 260.0, foods[0].Calories)
+	assert.Equal(t, 5.4, foods[0].Protein)
+
+	assert.Equal(t, "egg", foods[1].Name)
+	assert.Equal(t, 2.0, foods[1].ServingQty)
+	assert.Equal(t, "large", foods[1].ServingUnit)
+	assert.Equal(t, 100.0, foods[1].ServingWeightGrams)
+	assert.Equal(t, 143.0, foods[1].Calories)
+}
+
+func TestFakeNutritionProviderLookup(t *testing.T) {
+	provider, err := NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	foods, err := provider.Search(context.Background(), "Breast")
+	assert.NoError(t, err)
+	assert.Equal(t, "chicken breast", foods[0].Name)
+
+	food, err := provider.GetFood(context.Background(), "fake-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "rice", food.Name)
+
+	food, err = provider.GetFoodByBarcode(context.Background(), "4600000000017")
+	assert.NoError(t, err)
+	assert.Equal(t, "greek yogurt", food.Name)
+}
+
+func TestFakeNutritionProviderFixtureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foods.json")
+	err := os.WriteFile(path, []byte(`{"foods": [{"id": "1", "name": "apple", "serving_qty": 1,
+		"serving_unit": "medium", "serving_weight_grams": 180, "calories": 95}]}`), 0o644)
+	assert.NoError(t, err)
+
+	provider, err := NewFakeNutritionProvider(path)
+	assert.NoError(t, err)
+
+	foods, err := provider.Parse(context.Background(), "90g apple")
+	assert.NoError(t, err)
+	assert.Equal(t, 47.5, foods[0].Calories)
+
+	_, err = provider.Parse(context.Background(), "200g rice")
+	assert.ErrorIs(t, err, ErrFoodNotFound)
+}
+
+func TestNutritionixClientParse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/natural/nutrients", r.URL.Path)
+		assert.Equal(t, "app-id", r.Header.Get("x-app-id"))
+		assert.Equal(t, "app-key", r.Header.Get("x-app-key"))
+
+		var body struct {
+			Query string `json:"query"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "200g rice", body.Query)
+
+		w.Write([]byte(`{"foods": [{"food_name": "rice", "serving_qty": 200, "serving_unit": "g",
+			"serving_weight_grams": 200, "nf_calories": 260, "nf_protein": 5.4,
+			"nf_total_carbohydrate": 56.4, "nf_total_fat": 0.6}]}`))
+	}))
+	defer server.Close()
+
+	client := NewNutritionixClient(&config.Envs{
+		NutritionixAppID:   "app-id",
+		NutritionixAppKey:  "app-key",
+		NutritionixBaseURL: server.URL,
+	})
+
+	foods, err := client.Parse(context.Background(), "200g rice")
+	assert.NoError(t, err)
+	assert.Len(t, foods, 1)
+	assert.Equal(t, "rice", foods[0].Name)
+	assert.Equal(t, 260.0, foods[0].Calories)
+	assert.Equal(t, 56.4, foods[0].Carbs)
+}
+
+func TestNutritionixClientSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search/instant", r.URL.Path)
+		assert.Equal(t, "yogurt", r.URL.Query().Get("query"))
+
+		w.Write([]byte(`{"common": [{"food_name": "yogurt", "serving_qty": 1, "serving_unit": "cup",
+			"full_nutrients": [{"attr_id": 208, "value": 149}, {"attr_id": 203, "value": 8.5}]}],
+			"branded": [{"nix_item_id": "abc", "food_name": "Greek Yogurt", "brand_name": "Brand",
+			"nf_calories": 100}]}`))
+	}))
+	defer server.Close()
+
+	client := NewNutritionixClient(&config.Envs{NutritionixBaseURL: server.URL})
+
+	foods, err := client.Search(context.Background(), "yogurt")
+	assert.NoError(t, err)
+	assert.Len(t, foods, 2)
+	assert.Equal(t, 149.0, foods[0].Calories)
+	assert.Equal(t, 8.5, foods[0].Protein)
+	assert.Equal(t, "abc", foods[1].ID)
+	assert.Equal(t, "Brand", foods[1].Brand)
+}
+
+func TestNutritionixClientNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "resource not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewNutritionixClient(&config.Envs{NutritionixBaseURL: server.URL})
+
+	_, err := client.GetFoodByBarcode(context.Background(), "123")
+	assert.ErrorIs(t, err, ErrFoodNotFound)
+}
+
+func TestFatSecretClientSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/server.api", r.URL.Path)
+		assert.Equal(t, "foods.search", r.URL.Query().Get("method"))
+		assert.Equal(t, "key", r.URL.Query().Get("oauth_consumer_key"))
+		assert.NotEmpty(t, r.URL.Query().Get("oauth_signature"))
+
+		w.Write([]byte(`{"foods": {"food": {"food_id": "33691", "food_name": "Banana",
+			"food_description": "Per 100g - Calories: 89kcal | Fat: 0.33g | Carbs: 22.84g | Protein: 1.09g"}}}`))
+	}))
+	defer server.Close()
+
+	client := &FatSecretClient{
+		ConsumerKey:    "key",
+		ConsumerSecret: "secret",
+		BaseURL:        server.URL,
+		HTTPClient:     server.Client(),
+	}
+
+	foods, err := client.Search(context.Background(), "banana")
+	assert.NoError(t, err)
+	assert.Len(t, foods, 1)
+	assert.Equal(t, "33691", foods[0].ID)
+	assert.Equal(t, 100.0, foods[0].ServingWeightGrams)
+	assert.Equal(t, 89.0, foods[0].Calories)
+	assert.Equal(t, 22.84, foods[0].Carbs)
+}
+
+func TestFatSecretClientBarcodeNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"food_id": {"value": "0"}}`))
+	}))
+	defer server.Close()
+
+	client := &FatSecretClient{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	_, err := client.GetFoodByBarcode(context.Background(), "123")
+	assert.ErrorIs(t, err, ErrFoodNotFound)
+}
+
+func TestInitClientsUnknownProvider(t *testing.T) {
+	_, err := InitClients(&config.Envs{NutritionProvider: "unknown"})
+	assert.Error(t, err)
+}
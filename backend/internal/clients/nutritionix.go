@@ -4,10 +4,22 @@ import (
 	"backend/internal/config"
 	"backend/internal/models"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+)
+
+const nutritionixBaseURL = "https://trackapi.nutritionix.com/v2"
+
+// Nutritionix nutrient ids in full_nutrients.
+const (
+	nutritionixProteinID  = 203
+	nutritionixFatID      = 204
+	nutritionixCarbsID    = 205
+	nutritionixCaloriesID = 208
 )
 
 type NutritionixClient struct {
@@ -18,17 +30,20 @@ type NutritionixClient struct {
 }
 
 func NewNutritionixClient(envs *config.Envs) *NutritionixClient {
+	baseURL := envs.NutritionixBaseURL
+	if baseURL == "" {
+		baseURL = nutritionixBaseURL
+	}
+
 	return &NutritionixClient{
 		AppID:      envs.NutritionixAppID,
 		AppKey:     envs.NutritionixAppKey,
-		BaseURL:    "https://trackapi.nutritionix.com/v2",
+		BaseURL:    baseURL,
 		HTTPClient: &http.Client{},
 	}
 }
 
-func (c *NutritionixClient) GetNutritionData(query string) (*models.NutritionixResponse, error) {
-	endpoint := c.BaseURL + "/natural/nutrients"
-
+func (c *NutritionixClient) Parse(ctx context.Context, query string) ([]models.NutritionFood, error) {
 	requestBody := struct {
 		Query string `json:"query"`
 	}{
@@ -40,36 +55,143 @@ func (c *NutritionixClient) GetNutritionData(query string) (*models.NutritionixR
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonBody))
+	var response models.NutritionixResponse
+	if err := c.do(ctx, "POST", "/natural/nutrients", bytes.NewBuffer(jsonBody), &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Foods) == 0 {
+		return nil, ErrFoodNotFound
+	}
+
+	foods := make([]models.NutritionFood, 0, len(response.Foods))
+	for _, f := range response.Foods {
+		foods = append(foods, newNutritionixFood(f))
+	}
+
+	return foods, nil
+}
+
+// Search returns common foods first and branded foods after them, only the
+// branded ones have an id for GetFood.
+func (c *NutritionixClient) Search(ctx context.Context, query string) ([]models.NutritionFood, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("detailed", "true")
+
+	var response models.NutritionixSearchResponse
+	if err := c.do(ctx, "GET", "/search/instant?"+params.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+
+	foods := make([]models.NutritionFood, 0, len(response.Common)+len(response.Branded))
+	for _, f := range response.Common {
+		foods = append(foods, newNutritionixFood(f))
+	}
+	for _, f := range response.Branded {
+		foods = append(foods, newNutritionixFood(f))
+	}
+
+	return foods, nil
+}
+
+func (c *NutritionixClient) GetFood(ctx context.Context, id string) (*models.NutritionFood, error) {
+	return c.getItem(ctx, "nix_item_id", id)
+}
+
+func (c *NutritionixClient) GetFoodByBarcode(ctx context.Context, barcode string) (*models.NutritionFood, error) {
+	food, err := c.getItem(ctx, "upc", barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	food.Barcode = barcode
+	return food, nil
+}
+
+func (c *NutritionixClient) getItem(ctx context.Context, param, value string) (*models.NutritionFood, error) {
+	params := url.Values{}
+	params.Set(param, value)
+
+	var response models.NutritionixResponse
+	if err := c.do(ctx, "GET", "/search/item?"+params.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Foods) == 0 {
+		return nil, ErrFoodNotFound
+	}
+
+	food := newNutritionixFood(response.Foods[0])
+	return &food, nil
+}
+
+// do sends the request and decodes the response into result. Nutritionix
+// answers 404 when nothing matches the query.
+func (c *NutritionixClient) do(ctx context.Context, method, path string, body io.Reader, result any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("x-app-id", c.AppID)
 	req.Header.Set("x-app-key", c.AppKey)
-	req.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
+		return fmt.Errorf("API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrFoodNotFound
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: %s, response %s", resp.Status, string(body))
+		return fmt.Errorf("API error: %s, response %s", resp.Status, string(body))
 	}
 
-	var response models.NutritionixResponse
-
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if len(response.Foods) == 0 {
-		return nil, fmt.Errorf("no food data found for query: %s", query)
+	return nil
+}
+
+// newNutritionixFood takes the nutrients from full_nutrients when the nf_
+// fields are missing, as in the search results.
+func newNutritionixFood(f models.NutritionixFood) models.NutritionFood {
+	food := models.NutritionFood{
+		ID:                 f.NixItemID,
+		Name:               f.FoodName,
+		Brand:              f.BrandName,
+		ServingQty:         f.ServingQty,
+		ServingUnit:        f.ServingUint,
+		ServingWeightGrams: f.ServingWeightGrams,
+		Calories:           f.Calories,
+		Protein:            f.Protein,
+		Carbs:              f.Carbs,
+		Fat:                f.Fat,
 	}
 
-	return &response, nil
+	if food.Calories == 0 && food.Protein == 0 && food.Carbs == 0 && food.Fat == 0 {
+		for _, n := range f.FullNutrients {
+			switch n.AttrID {
+			case nutritionixCaloriesID:
+				food.Calories = n.Value
+			case nutritionixProteinID:
+				food.Protein = n.Value
+			case nutritionixCarbsID:
+				food.Carbs = n.Value
+			case nutritionixFatID:
+				food.Fat = n.Value
+			}
+		}
+	}
 
+	return food
 }
@@ -21,9 +21,20 @@ type Envs struct {
 	FrontendUrl             string
 	NutritionixAppID        string
 	NutritionixAppKey       string
+	NutritionixBaseURL      string
+	NutritionProvider       string
+	NutritionFixtureFile    string
 	FatsecretConsumerKey    string
 	FatsecretConsumerSecret string
 	FatsecretCallbackURL    string
+	MailDriver              string
+	SMTPHost                string
+	SMTPPort                string
+	SMTPUsername            string
+	SMTPPassword            string
+	MailFrom                string
+	MailDir                 string
+	AuthLogFile             string
 }
 
 func LoadEnvs(path string) (*Envs, error) {
@@ -50,8 +61,19 @@ func LoadEnvs(path string) (*Envs, error) {
 		FrontendUrl:             os.Getenv("FRONTEND_URL"),
 		NutritionixAppID:        os.Getenv("NUTRITIONIX_APP_ID"),
 		NutritionixAppKey:       os.Getenv("NUTRITIONIX_APP_KEY"),
+		NutritionixBaseURL:      os.Getenv("NUTRITIONIX_BASE_URL"),
+		NutritionProvider:       os.Getenv("NUTRITION_PROVIDER"),
+		NutritionFixtureFile:    os.Getenv("NUTRITION_FIXTURE_FILE"),
 		FatsecretConsumerKey:    os.Getenv("FATSECRET_CONSUMER_KEY"),
 		FatsecretConsumerSecret: os.Getenv("FATSECRET_CONSUMER_SECRET"),
 		FatsecretCallbackURL:    os.Getenv("FATSECRET_CALLBACK_URL"),
+		MailDriver:              os.Getenv("MAIL_DRIVER"),
+		SMTPHost:                os.Getenv("SMTP_HOST"),
+		SMTPPort:                os.Getenv("SMTP_PORT"),
+		SMTPUsername:            os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:            os.Getenv("SMTP_PASSWORD"),
+		MailFrom:                os.Getenv("MAIL_FROM"),
+		MailDir:                 os.Getenv("MAIL_DIR"),
+		AuthLogFile:             os.Getenv("AUTH_LOG_FILE"),
 	}, nil
 }
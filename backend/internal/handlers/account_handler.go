@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+type AccountHandler struct {
+	accountService *services.AccountService
+}
+
+func NewAccountHandler(accountService *services.AccountService) *AccountHandler {
+	return &AccountHandler{accountService: accountService}
+}
+
+// UpdateProfile godoc
+// @Summary Update current user profile
+// @Description Change the username and/or the email of current user. Changing the email requires current_password, a new email has to be verified again, the link is sent to it
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body models.UpdateProfileRequest true "New username and/or email"
+// @Success 200 {object} models.UserResponse "Profile successfully updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Nothing to update"
+// @Failure 400 {object} models.ErrorResponse "Username is required and must be at most 100 characters"
+// @Failure 400 {object} models.ErrorResponse "Invalid email"
+// @Failure 400 {object} models.ErrorResponse "Current password is required to change the email"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 403 {object} models.ErrorResponse "Invalid password"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "Username already exists"
+// @Failure 409 {object} models.ErrorResponse "Email already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to update profile"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me [patch]
+func (h *AccountHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.accountService.UpdateProfile(ctx, &req)
+	if err != nil {
+		log.Println("Failed to update profile:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+// ChangePassword godoc
+// @Summary Change current user password
+// @Description Change the password of current user. All sessions are revoked, the user has to log in again
+// @Tags user
+// @Accept json
+// @Param data body models.ChangePasswordRequest true "Current and new passwords"
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Current and new passwords are required"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Invalid password"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to change password"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/password [post]
+func (h *AccountHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountService.ChangePassword(ctx, &req); err != nil {
+		log.Println("Failed to change password:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	clearAuthCookies(w)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteAccount godoc
+// @Summary Delete current user account
+// @Description Deactivate the account of current user and log them out everywhere. Workouts, foods and FatSecret credentials are purged after a grace period of 30 days, until then an administrator can restore the account
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body models.DeleteAccountRequest true "Current password"
+// @Success 200 {object} models.DeleteAccountResponse "Account scheduled for deletion"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Password is required"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Invalid password"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete account"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me [delete]
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.accountService.DeleteAccount(ctx, &req)
+	if err != nil {
+		log.Println("Failed to delete account:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	clearAuthCookies(w)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
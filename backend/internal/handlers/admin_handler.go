@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AdminHandler struct {
+	adminService *services.AdminService
+}
+
+func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// SearchUsers godoc
+// @Summary Search users
+// @Description Get a page of users, newest first, with roles and last login. Requires the users:manage permission
+// @Tags admin
+// @Produce json
+// @Param search query string false "Search by username or email"
+// @Param is_active query bool false "Only active or only deactivated users"
+// @Param page query int false "Page number (default is 1)"
+// @Param limit query int false "Number of users per page (default is 20)"
+// @Success 200 {object} models.AdminUserListResponse "Users successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 500 {object} models.ErrorResponse "Failed to get users"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/users [get]
+func (h *AdminHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter, err := utils.ParseUserFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.adminService.SearchUsers(ctx, filter)
+	if err != nil {
+		log.Println("Failed to get users:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetUser godoc
+// @Summary Get user
+// @Description Get user with roles, status and last login (time, IP address and user agent of the latest session). Requires the users:manage permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} models.AdminUserResponse "User data"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get user"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := h.adminService.GetUser(ctx, id)
+	if err != nil {
+		log.Println("Failed to get user:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate user
+// @Description Block the login of the user, revoke all sessions and API tokens. Requires the users:manage permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} models.AdminUserResponse "User data"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 403 {object} models.ErrorResponse "You can't deactivate yourself"
+// @Failure 409 {object} models.ErrorResponse "User is already deactivated"
+// @Failure 500 {object} models.ErrorResponse "Failed to update user"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/users/{id}/deactivate [post]
+func (h *AdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := h.adminService.DeactivateUser(ctx, id)
+	if err != nil {
+		log.Println("Failed to update user:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate user
+// @Description Allow the deactivated user to log in again. Requires the users:manage permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} models.AdminUserResponse "User data"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "User is already active"
+// @Failure 500 {object} models.ErrorResponse "Failed to update user"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := h.adminService.ReactivateUser(ctx, id)
+	if err != nil {
+		log.Println("Failed to update user:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+// ForcePasswordReset godoc
+// @Summary Force password reset
+// @Description Revoke all sessions of the user and block the login until the password is reset with the link sent by email. Requires the users:manage permission
+// @Tags admin
+// @Produce json
+// @Param id path int true "User id"
+// @Success 200 {object} models.AdminUserResponse "User data"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to send email"
+// @Failure 500 {object} models.ErrorResponse "Failed to require password reset"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/users/{id}/password-reset [post]
+func (h *AdminHandler) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := h.adminService.ForcePasswordReset(ctx, id)
+	if err != nil {
+		log.Println("Failed to require password reset:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
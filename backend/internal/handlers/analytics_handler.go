@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+// GetExerciseProgression godoc
+// @Summary Get exercise progression
+// @Description Get estimated 1RM, top set and total volume of exercise per day, week or month
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Exercise id"
+// @Param from query string false "Start date in YYYY-MM-DD format"
+// @Param to query string false "End date in YYYY-MM-DD format"
+// @Param formula query string false "1RM formula: epley, brzycki or lombardi (default is epley)"
+// @Param bucket query string false "Bucket: day, week or month (default is day)"
+// @Success 200 {object} models.ProgressionResponse "Progression successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Exercise not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get progression"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /analytics/exercises/{id}/progression [get]
+func (h *AnalyticsHandler) GetExerciseProgression(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := utils.ParseProgressionFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	points, err := h.analyticsService.GetExerciseProgression(ctx, id, filter)
+	if err != nil {
+		log.Println("Failed to get progression:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.ProgressionResponse{
+		ExerciseID: id,
+		Formula:    filter.Formula,
+		Bucket:     filter.Bucket,
+		Points:     points,
+	}
+
+	if response.Points == nil {
+		response.Points = []models.ProgressionPoint{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetMuscleVolume godoc
+// @Summary Get weekly volume per muscle group
+// @Description Get weekly sets and tonnage per muscle group, secondary muscles are counted separately. Defaults to the last four weeks
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date in YYYY-MM-DD format"
+// @Param to query string false "End date in YYYY-MM-DD format"
+// @Success 200 {object} models.MuscleVolumeResponse "Muscle volume successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get muscle volume"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /analytics/muscle-volume [get]
+func (h *AnalyticsHandler) GetMuscleVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter, err := utils.ParseMuscleVolumeFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	weeks, err := h.analyticsService.GetMuscleVolume(ctx, filter)
+	if err != nil {
+		log.Println("Failed to get muscle volume:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.MuscleVolumeResponse{
+		From:  filter.From,
+		To:    filter.To,
+		Weeks: weeks,
+	}
+
+	if response.Weeks == nil {
+		response.Weeks = []models.MuscleVolumeWeek{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTrainingTime godoc
+// @Summary Get weekly training time
+// @Description Get total and average duration of finished workouts per week. Defaults to the last four weeks
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date in YYYY-MM-DD format"
+// @Param to query string false "End date in YYYY-MM-DD format"
+// @Success 200 {object} models.TrainingTimeResponse "Training time successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get training time"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /analytics/training-time [get]
+func (h *AnalyticsHandler) GetTrainingTime(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter, err := utils.ParseMuscleVolumeFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, weeks, err := h.analyticsService.GetTrainingTime(ctx, filter)
+	if err != nil {
+		log.Println("Failed to get training time:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newTrainingTimeResponse(userID, filter, weeks))
+}
+
+// GetClientTrainingTime godoc
+// @Summary Get weekly training time of client
+// @Description Get total and average duration of finished workouts per week of client of current trainer. Defaults to the last four weeks
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client id"
+// @Param from query string false "Start date in YYYY-MM-DD format"
+// @Param to query string false "End date in YYYY-MM-DD format"
+// @Success 200 {object} models.TrainingTimeResponse "Training time successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "User is not your client"
+// @Failure 500 {object} models.ErrorResponse "Failed to get training time"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/clients/{clientID}/training-time [get]
+func (h *AnalyticsHandler) GetClientTrainingTime(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	clientID, err := strconv.Atoi(chi.URLParam(r, "clientID"))
+	if err != nil || clientID < 1 {
+		log.Println("Incorrect client id:", err)
+		utils.JSONError(w, "Incorrect client id", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := utils.ParseMuscleVolumeFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	weeks, err := h.analyticsService.GetClientTrainingTime(ctx, clientID, filter)
+	if err != nil {
+		log.Println("Failed to get client training time:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newTrainingTimeResponse(clientID, filter, weeks))
+}
+
+func newTrainingTimeResponse(userID int, filter *models.DateRangeFilter, weeks []models.TrainingTimeWeek) models.TrainingTimeResponse {
+	response := models.TrainingTimeResponse{
+		UserID: userID,
+		From:   filter.From,
+		To:     filter.To,
+		Weeks:  weeks,
+	}
+
+	for _, week := range weeks {
+		response.TotalSeconds += week.TotalSeconds
+	}
+
+	if response.Weeks == nil {
+		response.Weeks = []models.TrainingTimeWeek{}
+	}
+
+	return response
+}
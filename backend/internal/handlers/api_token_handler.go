@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type APITokenHandler struct {
+	apiTokenService *services.APITokenService
+}
+
+func NewAPITokenHandler(apiTokenService *services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{apiTokenService: apiTokenService}
+}
+
+// CreateToken godoc
+// @Summary Create API token
+// @Description Create a personal access token for scripts, sent as "Authorization: Bearer <token>". The token is returned only once. Scopes: workouts, templates, exercises, foods with :read or :write, programs:read, analytics:read, records:read. Expiration defaults to 30 days
+// @Tags api-tokens
+// @Accept json
+// @Produce json
+// @Param data body models.APITokenRequest true "Token name, scopes and expiration in days"
+// @Success 201 {object} models.CreatedAPITokenResponse "API token successfully created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Name is required and must be at most 100 characters"
+// @Failure 400 {object} models.ErrorResponse "At least one scope is required"
+// @Failure 400 {object} models.ErrorResponse "Unknown scope"
+// @Failure 400 {object} models.ErrorResponse "Expiration must be between 1 and 365 days"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to create API token"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/api-tokens [post]
+func (h *APITokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.APITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.apiTokenService.CreateToken(ctx, &req)
+	if err != nil {
+		log.Println("Failed to create API token:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTokens godoc
+// @Summary Get API tokens
+// @Description Get active API tokens of current user, without the tokens themselves
+// @Tags api-tokens
+// @Produce json
+// @Success 200 {array} models.APITokenResponse "API tokens successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get API tokens"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/api-tokens [get]
+func (h *APITokenHandler) GetTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	response, err := h.apiTokenService.GetTokens(ctx)
+	if err != nil {
+		log.Println("Failed to get API tokens:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeToken godoc
+// @Summary Revoke API token
+// @Description Revoke API token of current user, it stops working immediately
+// @Tags api-tokens
+// @Param id path int true "API token id"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "API token not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to revoke API token"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/api-tokens/{id} [delete]
+func (h *APITokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.apiTokenService.RevokeToken(ctx, id); err != nil {
+		log.Println("Failed to revoke API token:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
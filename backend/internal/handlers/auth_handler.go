@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"backend/internal/apperrors"
+	"backend/internal/auth"
 	"backend/internal/models"
 	"backend/internal/services"
 	"backend/internal/utils"
@@ -9,8 +10,12 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AuthHandler struct {
@@ -23,7 +28,7 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 
 // Register godoc
 // @Summary User registration
-// @Description Endpoint for new user registration
+// @Description Endpoint for new user registration, sends an email verification link
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -74,16 +79,19 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 // Login godoc
 // @Summary User login
-// @Description Endpoint for login
+// @Description Endpoint for login. When two-factor authentication is enabled no cookies are set, a challenge is returned instead and the login is completed with /login/2fa
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param data body models.UserAuthRequest true "User data (email, password)"
 // @Success 200 {object} models.UserResponse "User login successfully"
+// @Success 200 {object} models.LoginChallenge "Two-factor code required"
 // @Failure 400 {object} models.ErrorResponse "Incorrect user data"
 // @Failure 400 {object} models.ErrorResponse "Invalid password"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 403 {object} models.ErrorResponse "Email is not verified"
 // @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 429 {object} models.ErrorResponse "Too many login attempts"
 // @Failure 500 {object} models.ErrorResponse "Failed to login user"
 // @Failure 504 {object} models.ErrorResponse "Request timeout"
 // @Router /login [post]
@@ -100,11 +108,17 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, user, err := h.authService.Login(ctx, &req)
+	client := &models.SessionClient{
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r),
+	}
+
+	tokens, user, challenge, err := h.authService.Login(ctx, &req, client)
 	if err != nil {
 		log.Println("Login failed:", err)
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
+			setRetryAfter(w, appErr)
 			utils.JSONError(w, appErr.Message, appErr.Code)
 			return
 		}
@@ -112,15 +126,14 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "access_token",
-		Value:    token,
-		HttpOnly: true,
-		Secure:   false, // true = only HTTPS (possible false on localhost)
-		SameSite: http.SameSiteLaxMode,
-		Path:     "/api",
-		MaxAge:   900,
-	})
+	if challenge != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(challenge)
+		return
+	}
+
+	setAuthCookies(w, tokens)
 
 	response := models.UserResponse{
 		ID:        user.ID,
@@ -134,15 +147,432 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// LoginTwoFactor godoc
+// @Summary Two-factor login
+// @Description Complete the login with the challenge from /login and a code from the authenticator app or a recovery code. The challenge is valid for 5 minutes and 5 attempts
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body models.TwoFactorLoginRequest true "Challenge and code"
+// @Success 200 {object} models.UserResponse "User login successfully"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Invalid or expired challenge"
+// @Failure 401 {object} models.ErrorResponse "Invalid code"
+// @Failure 429 {object} models.ErrorResponse "Too many attempts"
+// @Failure 429 {object} models.ErrorResponse "Too many login attempts"
+// @Failure 500 {object} models.ErrorResponse "Failed to login user"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	client := &models.SessionClient{
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r),
+	}
+
+	tokens, user, err := h.authService.LoginTwoFactor(ctx, &req, client)
+	if err != nil {
+		log.Println("Two-factor login failed:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			setRetryAfter(w, appErr)
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	setAuthCookies(w, tokens)
+
+	response := models.UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Issue new access and refresh tokens using refresh_token cookie. The refresh token is rotated, reusing an old one revokes the session
+// @Tags auth
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Invalid refresh token"
+// @Failure 401 {object} models.ErrorResponse "Session expired"
+// @Failure 401 {object} models.ErrorResponse "Refresh token reuse detected"
+// @Failure 500 {object} models.ErrorResponse "Failed to refresh token"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cookie, err := r.Cookie("refresh_token")
+	if err != nil || cookie.Value == "" {
+		utils.JSONError(w, "Invalid refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := h.authService.Refresh(ctx, cookie.Value)
+	if err != nil {
+		log.Println("Refresh failed:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			if appErr.Code == http.StatusUnauthorized {
+				clearAuthCookies(w)
+			}
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	setAuthCookies(w, tokens)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Logout godoc
 // @Summary User logout
-// @Description Endpoint for logout
+// @Description Endpoint for logout, revokes current access token and session
 // @Tags auth
 // @Success 200
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 401 {object} models.ErrorResponse "Token revoked"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to logout"
 // @Router /logout [post]
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := h.authService.Logout(ctx); err != nil {
+		log.Println("Logout failed:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	clearAuthCookies(w)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// LogoutAll godoc
+// @Summary Logout everywhere
+// @Description Revoke all access tokens and sessions of current user on every device
+// @Tags auth
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 401 {object} models.ErrorResponse "Token revoked"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to logout"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /logout/all [post]
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := h.authService.LogoutAll(ctx); err != nil {
+		log.Println("Logout from all devices failed:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	clearAuthCookies(w)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetSessions godoc
+// @Summary Get sessions
+// @Description Get active sessions of current user, one per logged in device
+// @Tags user
+// @Produce json
+// @Success 200 {array} models.SessionResponse "Sessions successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get sessions"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/sessions [get]
+func (h *AuthHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	response, err := h.authService.GetSessions(ctx)
+	if err != nil {
+		log.Println("Failed to get sessions:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeSession godoc
+// @Summary Revoke session
+// @Description Revoke session of current user, the device has to login again once its access token expires
+// @Tags user
+// @Param id path int true "Session id"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to revoke session"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.RevokeSession(ctx, id); err != nil {
+		log.Println("Failed to revoke session:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestEmailVerification godoc
+// @Summary Request email verification
+// @Description Send a new email verification link. The response is the same for unknown emails
+// @Tags auth
+// @Accept json
+// @Param data body models.EmailRequest true "Email"
+// @Success 202
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 500 {object} models.ErrorResponse "Failed to send email"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /auth/verify-email/request [post]
+func (h *AuthHandler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var req models.EmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.RequestEmailVerification(ctx, req.Email); err != nil {
+		log.Println("Failed to request email verification:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Confirm email with token from verification link
+// @Tags auth
+// @Accept json
+// @Param data body models.TokenRequest true "Token from email"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Invalid or expired token"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 500 {object} models.ErrorResponse "Failed to verify email"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /auth/verify-email/confirm [post]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.TokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.VerifyEmail(ctx, req.Token); err != nil {
+		log.Println("Failed to verify email:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestPasswordReset godoc
+// @Summary Request password reset
+// @Description Send a password reset link. The response is the same for unknown emails
+// @Tags auth
+// @Accept json
+// @Param data body models.EmailRequest true "Email"
+// @Success 202
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 500 {object} models.ErrorResponse "Failed to send email"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /auth/password-reset/request [post]
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var req models.EmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(ctx, req.Email); err != nil {
+		log.Println("Failed to request password reset:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set new password with token from reset link, all sessions of the user are revoked
+// @Tags auth
+// @Accept json
+// @Param data body models.PasswordResetRequest true "Token from email and new password"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Token and password are required"
+// @Failure 400 {object} models.ErrorResponse "Invalid or expired token"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 500 {object} models.ErrorResponse "Failed to reset password"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /auth/password-reset/confirm [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.ResetPassword(ctx, &req); err != nil {
+		log.Println("Failed to reset password:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setAuthCookies(w http.ResponseWriter, tokens *models.AuthTokens) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "access_token",
+		Value:    tokens.AccessToken,
+		HttpOnly: true,
+		Secure:   false, // true = only HTTPS (possible false on localhost)
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/api",
+		MaxAge:   int(auth.AccessTokenTTL.Seconds()),
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    tokens.RefreshToken,
+		HttpOnly: true,
+		Secure:   false, // true = only HTTPS (possible false on localhost)
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/api/v1/auth",
+		MaxAge:   int(auth.RefreshTokenTTL.Seconds()),
+	})
+}
+
+func clearAuthCookies(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "access_token",
 		Value:    "",
@@ -153,5 +583,19 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		MaxAge:   -1,
 	})
 
-	w.WriteHeader(http.StatusOK)
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   false, // true = only HTTPS (possible false on localhost)
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/api/v1/auth",
+		MaxAge:   -1,
+	})
+}
+
+func setRetryAfter(w http.ResponseWriter, appErr *apperrors.AppError) {
+	if appErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
 }
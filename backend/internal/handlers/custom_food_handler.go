@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CustomFoodHandler struct {
+	customFoodService *services.CustomFoodService
+}
+
+func NewCustomFoodHandler(customFoodService *services.CustomFoodService) *CustomFoodHandler {
+	return &CustomFoodHandler{customFoodService: customFoodService}
+}
+
+// CreateCustomFood godoc
+// @Summary Create custom food
+// @Description Add food with label values to the personal library. Nutrition is set per 100g, per serving or both, the missing one is computed when the serving weight is known
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param food body models.CustomFoodRequest true "Custom food data"
+// @Success 201 {object} models.CustomFood "Custom food created"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to create custom food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/custom [post]
+func (h *CustomFoodHandler) CreateCustomFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.CustomFoodRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	food, err := h.customFoodService.CreateCustomFood(ctx, &request)
+	if err != nil {
+		log.Println("Failed to create custom food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(food)
+}
+
+// GetCustomFoods godoc
+// @Summary Get custom foods
+// @Description Get the personal food library of current user
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.CustomFood "Custom foods successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Custom foods not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get custom foods"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/custom [get]
+func (h *CustomFoodHandler) GetCustomFoods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	foods, err := h.customFoodService.GetCustomFoods(ctx)
+	if err != nil {
+		log.Println("Failed to get custom foods:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(foods)
+}
+
+// GetCustomFood godoc
+// @Summary Get custom food
+// @Description Get custom food of current user by id
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom food id"
+// @Success 200 {object} models.CustomFood "Custom food successfully got"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Custom food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get custom food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/custom/{id} [get]
+func (h *CustomFoodHandler) GetCustomFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	food, err := h.customFoodService.GetCustomFood(ctx, id)
+	if err != nil {
+		log.Println("Failed to get custom food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(food)
+}
+
+// UpdateCustomFood godoc
+// @Summary Update custom food
+// @Description Replace custom food of current user by id. Food diary entries logged before keep their values
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom food id"
+// @Param food body models.CustomFoodRequest true "Custom food data"
+// @Success 200 {object} models.CustomFood "Custom food updated"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Custom food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to update custom food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/custom/{id} [put]
+func (h *CustomFoodHandler) UpdateCustomFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.CustomFoodRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	food, err := h.customFoodService.UpdateCustomFood(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to update custom food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(food)
+}
+
+// DeleteCustomFood godoc
+// @Summary Delete custom food
+// @Description Remove custom food of current user from the library. Food diary entries logged before are kept
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom food id"
+// @Success 204 "Custom food successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Custom food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete custom food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/custom/{id} [delete]
+func (h *CustomFoodHandler) DeleteCustomFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.customFoodService.DeleteCustomFood(ctx, id); err != nil {
+		log.Println("Failed to delete custom food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type DataExportHandler struct {
+	exportService *services.DataExportService
+}
+
+func NewDataExportHandler(exportService *services.DataExportService) *DataExportHandler {
+	return &DataExportHandler{exportService: exportService}
+}
+
+// RequestExport godoc
+// @Summary Request personal data export
+// @Description Start generating a ZIP archive with the profile, roles, workouts, workout exercises and sets, foods and connected integrations of current user as JSON and CSV files. The archive is generated in the background, poll GET /users/me/export for the download link. The previous export is replaced
+// @Tags user
+// @Produce json
+// @Success 202 {object} models.DataExportResponse "Export started"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Export is already in progress"
+// @Failure 500 {object} models.ErrorResponse "Failed to create export"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/export [post]
+func (h *DataExportHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	export, err := h.exportService.RequestExport(ctx)
+	if err != nil {
+		log.Println("Failed to request export:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(export)
+}
+
+// GetExport godoc
+// @Summary Get personal data export status
+// @Description Get the status of the latest export of current user: pending, ready or failed. A ready export has a download link until it expires in 24 hours
+// @Tags user
+// @Produce json
+// @Success 200 {object} models.DataExportResponse "Export successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Export not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get export"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/export [get]
+func (h *DataExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	export, err := h.exportService.GetExport(ctx)
+	if err != nil {
+		log.Println("Failed to get export:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(export)
+}
+
+// DownloadExport godoc
+// @Summary Download personal data export
+// @Description Download the ZIP archive of a ready export of current user
+// @Tags user
+// @Produce application/zip
+// @Param id path int true "Export id"
+// @Success 200 {file} file "ZIP archive"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Export not found or expired"
+// @Failure 500 {object} models.ErrorResponse "Failed to get export"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/export/{id}/download [get]
+func (h *DataExportHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	archive, err := h.exportService.GetArchive(ctx, id)
+	if err != nil {
+		log.Println("Failed to download export:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%d.zip"`, id))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
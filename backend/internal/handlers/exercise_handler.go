@@ -35,6 +35,8 @@ func NewExerciseHandler(exerciseService *services.ExerciseService) *ExerciseHand
 // @Success 201 {object} models.ExerciseResponse "Exercise created"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
 // @Failure 400 {object} models.ErrorResponse "Invalid category id"
+// @Failure 400 {object} models.ErrorResponse "Invalid muscle group id"
+// @Failure 400 {object} models.ErrorResponse "Invalid tracking type"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
@@ -76,12 +78,15 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 	}
 
 	response := models.ExerciseResponse{
-		ID:          exercise.ID,
-		Name:        exercise.Name,
-		Description: exercise.Description,
-		CategoryID:  exercise.CategoryID,
-		CreatedAt:   exercise.CreatedAt,
-		UpdatedAt:   exercise.UpdatedAt,
+		ID:               exercise.ID,
+		Name:             exercise.Name,
+		Description:      exercise.Description,
+		CategoryID:       exercise.CategoryID,
+		TrackingType:     exercise.TrackingType,
+		CreatedAt:        exercise.CreatedAt,
+		UpdatedAt:        exercise.UpdatedAt,
+		PrimaryMuscles:   exercise.PrimaryMuscles,
+		SecondaryMuscles: exercise.SecondaryMuscles,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -133,12 +138,15 @@ func (h *ExerciseHandler) GetExercises(w http.ResponseWriter, r *http.Request) {
 
 	for _, exercise := range *exercises {
 		exerciseResponse = append(exerciseResponse, models.ExerciseResponse{
-			ID:          exercise.ID,
-			Name:        exercise.Name,
-			Description: exercise.Description,
-			CategoryID:  exercise.CategoryID,
-			CreatedAt:   exercise.CreatedAt,
-			UpdatedAt:   exercise.UpdatedAt,
+			ID:               exercise.ID,
+			Name:             exercise.Name,
+			Description:      exercise.Description,
+			CategoryID:       exercise.CategoryID,
+			TrackingType:     exercise.TrackingType,
+			CreatedAt:        exercise.CreatedAt,
+			UpdatedAt:        exercise.UpdatedAt,
+			PrimaryMuscles:   exercise.PrimaryMuscles,
+			SecondaryMuscles: exercise.SecondaryMuscles,
 		})
 	}
 
@@ -199,12 +207,15 @@ func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := models.ExerciseResponse{
-		ID:          exercise.ID,
-		Name:        exercise.Name,
-		Description: exercise.Description,
-		CategoryID:  exercise.CategoryID,
-		CreatedAt:   exercise.CreatedAt,
-		UpdatedAt:   exercise.UpdatedAt,
+		ID:               exercise.ID,
+		Name:             exercise.Name,
+		Description:      exercise.Description,
+		CategoryID:       exercise.CategoryID,
+		TrackingType:     exercise.TrackingType,
+		CreatedAt:        exercise.CreatedAt,
+		UpdatedAt:        exercise.UpdatedAt,
+		PrimaryMuscles:   exercise.PrimaryMuscles,
+		SecondaryMuscles: exercise.SecondaryMuscles,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -224,6 +235,8 @@ func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} models.ErrorResponse "Invalid id"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
 // @Failure 400 {object} models.ErrorResponse "Invalid category id"
+// @Failure 400 {object} models.ErrorResponse "Invalid muscle group id"
+// @Failure 400 {object} models.ErrorResponse "Invalid tracking type"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
@@ -275,12 +288,15 @@ func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request)
 	}
 
 	response := models.ExerciseResponse{
-		ID:          exercise.ID,
-		Name:        exercise.Name,
-		Description: exercise.Description,
-		CategoryID:  exercise.CategoryID,
-		CreatedAt:   exercise.CreatedAt,
-		UpdatedAt:   exercise.UpdatedAt,
+		ID:               exercise.ID,
+		Name:             exercise.Name,
+		Description:      exercise.Description,
+		CategoryID:       exercise.CategoryID,
+		TrackingType:     exercise.TrackingType,
+		CreatedAt:        exercise.CreatedAt,
+		UpdatedAt:        exercise.UpdatedAt,
+		PrimaryMuscles:   exercise.PrimaryMuscles,
+		SecondaryMuscles: exercise.SecondaryMuscles,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -334,3 +350,40 @@ func (h *ExerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetMuscleGroups godoc
+// @Summary Get muscle groups
+// @Description Get all muscle groups that can be linked to exercises
+// @Tags exercises
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.MuscleGroup "List of muscle groups"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Muscle groups not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get muscle groups"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /muscle-groups [get]
+func (h *ExerciseHandler) GetMuscleGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	muscleGroups, err := h.exerciseService.GetMuscleGroups(ctx)
+	if err != nil {
+		log.Println("Failed to get muscle groups:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(muscleGroups)
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -27,15 +28,16 @@ func NewFoodHandler(foodService *services.FoodService) *FoodHandler {
 
 // AddFood godoc
 // @Summary Add food
-// @Description Add user daily food
+// @Description Add user daily food into a meal: breakfast, lunch, dinner, snack (default) or custom with meal_name, time is HH:MM. Items with custom_food_id are taken from the personal library with unit g, kg, ml (counted as grams) or the serving unit of the food, items with recipe_id are servings of a recipe, the others are recognized by the nutrition provider. Entries keep the order of the items
 // @Tags foods
 // @Accept json
 // @Produce json
 // @Param description body models.FoodRequest true "Food description"
-// @Success 201 {array} models.FoodResponse "List of foods"
+// @Success 201 {object} models.FoodResponse "List of foods"
 // @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Food not found"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Failure 504 {object} models.ErrorResponse "Request timeout"
 // @Router /foods [post]
@@ -72,50 +74,23 @@ func (h *FoodHandler) AddFood(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var (
-		response          models.FoodResponse
-		foodResponseItems []models.FoodResponseItem
-	)
-
-	for _, food := range *foods {
-		response.UserID = food.UserID
-		response.Date = food.Date
-		foodResponseItem := models.FoodResponseItem{
-			ID:          food.ID,
-			Name:        food.Name,
-			Quantity:    food.Quantity,
-			Uint:        food.Uint,
-			WeightGrams: food.WeightGrams,
-			Calories:    food.Calories,
-			Protein:     food.Protein,
-			Carbs:       food.Carbs,
-			Fat:         food.Fat,
-		}
-
-		foodResponseItems = append(foodResponseItems, foodResponseItem)
-
-	}
-
-	response.Items = foodResponseItems
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(newFoodResponse(*foods))
 
 }
 
 // GetFood godoc
 // @Summary Get food
-// @Description Get user daily food
+// @Description Get user daily food grouped by meal with meal and daily totals, a day without food has no meals. Entries logged before meals were introduced have meal type unspecified
 // @Tags foods
 // @Accept json
 // @Produce json
 // @Param date path string true "Date"
-// @Success 200 {array} models.FoodResponse "List of foods"
+// @Success 200 {object} models.FoodDayResponse "Foods grouped by meal"
 // @Failure 400 {object} models.ErrorResponse "Bad request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
-// @Failure 404 {object} models.ErrorResponse "Not found"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Failure 504 {object} models.ErrorResponse "Request timeout"
 // @Router /foods/{date} [get]
@@ -133,7 +108,7 @@ func (h *FoodHandler) GetFood(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foods, err := h.foodService.GetFoodByDate(ctx, date)
+	day, err := h.foodService.GetFoodByDate(ctx, date)
 	if err != nil {
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
@@ -144,34 +119,242 @@ func (h *FoodHandler) GetFood(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var (
-		response          models.FoodResponse
-		foodResponseItems []models.FoodResponseItem
-	)
-
-	for _, food := range *foods {
-		response.UserID = food.UserID
-		response.Date = food.Date
-		foodResponseItem := models.FoodResponseItem{
-			ID:          food.ID,
-			Name:        food.Name,
-			Quantity:    food.Quantity,
-			Uint:        food.Uint,
-			WeightGrams: food.WeightGrams,
-			Calories:    food.Calories,
-			Protein:     food.Protein,
-			Carbs:       food.Carbs,
-			Fat:         food.Fat,
+	response := models.FoodDayResponse{
+		UserID: day.UserID,
+		Date:   day.Date,
+		Meals:  []models.MealResponse{},
+		Total:  day.Total,
+	}
+
+	for _, meal := range day.Meals {
+		mealResponse := models.MealResponse{
+			MealType: meal.MealType,
+			MealName: meal.MealName,
+			Total:    meal.Total,
 		}
 
-		foodResponseItems = append(foodResponseItems, foodResponseItem)
+		for i := range meal.Foods {
+			mealResponse.Items = append(mealResponse.Items, newFoodResponseItem(&meal.Foods[i]))
+		}
 
+		response.Meals = append(response.Meals, mealResponse)
 	}
 
-	response.Items = foodResponseItems
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 
 }
+
+// SearchFoods godoc
+// @Summary Search foods
+// @Description Search foods by name, brand or barcode. Foods of the personal library come first, foods of the nutrition provider after them
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param query query string true "Search query"
+// @Success 200 {array} models.FoodSearchItem "Foods successfully found"
+// @Failure 400 {object} models.ErrorResponse "Query is required"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Foods not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to search foods"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/search [get]
+func (h *FoodHandler) SearchFoods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	foods, err := h.foodService.SearchFoods(ctx, r.URL.Query().Get("query"))
+	if err != nil {
+		log.Println("Failed to search foods:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(foods)
+}
+
+// UpdateFood godoc
+// @Summary Update food
+// @Description Change quantity of user food diary entry, weight and nutrients are rescaled in proportion
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Food id"
+// @Param food body models.UpdateFoodRequest true "New quantity"
+// @Success 200 {object} models.FoodResponseItem "Food updated"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Food not found"
+// @Failure 409 {object} models.ErrorResponse "Food has no quantity to rescale"
+// @Failure 500 {object} models.ErrorResponse "Failed to update food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/{id} [put]
+func (h *FoodHandler) UpdateFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.UpdateFoodRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	food, err := h.foodService.UpdateFood(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to update food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newFoodResponseItem(food))
+}
+
+// DeleteFood godoc
+// @Summary Delete food
+// @Description Delete user food diary entry by id
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Food id"
+// @Success 204 "Food successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete food"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/{id} [delete]
+func (h *FoodHandler) DeleteFood(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.foodService.DeleteFood(ctx, id); err != nil {
+		log.Println("Failed to delete food:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CopyMeal godoc
+// @Summary Copy meal
+// @Description Copy all foods of a meal to another date with the same nutrients and times, meal_name is required for custom meals. The dates must differ
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param meal body models.CopyMealRequest true "Meal to copy"
+// @Success 201 {object} models.FoodResponse "Copied foods"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Meal not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to copy meal"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/meals/copy [post]
+func (h *FoodHandler) CopyMeal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.CopyMealRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	foods, err := h.foodService.CopyMeal(ctx, &request)
+	if err != nil {
+		log.Println("Failed to copy meal:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newFoodResponse(foods))
+}
+
+func newFoodResponse(foods []models.Food) models.FoodResponse {
+	var response models.FoodResponse
+
+	for i := range foods {
+		response.UserID = foods[i].UserID
+		response.Date = foods[i].Date
+		response.Items = append(response.Items, newFoodResponseItem(&foods[i]))
+	}
+
+	return response
+}
+
+func newFoodResponseItem(food *models.Food) models.FoodResponseItem {
+	return models.FoodResponseItem{
+		ID:          food.ID,
+		Name:        food.Name,
+		Quantity:    food.Quantity,
+		Uint:        food.Uint,
+		WeightGrams: food.WeightGrams,
+		Calories:    food.Calories,
+		Protein:     food.Protein,
+		Carbs:       food.Carbs,
+		Fat:         food.Fat,
+		MealType:    food.MealType,
+		MealName:    food.MealName,
+		Time:        food.EatenAt,
+	}
+}
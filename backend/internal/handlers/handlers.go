@@ -9,11 +9,25 @@ type Handlers struct {
 	ExerciseHandler        *ExerciseHandler
 	CategoryHandler        *CategoryHandler
 	UserHandler            *UserHandler
+	AccountHandler         *AccountHandler
+	DataExportHandler      *DataExportHandler
+	RoleHandler            *RoleHandler
+	AdminHandler           *AdminHandler
 	AuthHandler            *AuthHandler
+	TwoFactorHandler       *TwoFactorHandler
+	APITokenHandler        *APITokenHandler
 	HealthHandler          *HealthHandler
 	WorkoutHandler         *WorkoutHandler
 	WorkoutExerciseHandler *WorkoutExerciseHandler
+	WorkoutSetHandler      *WorkoutSetHandler
+	WorkoutSessionHandler  *WorkoutSessionHandler
+	WorkoutTemplateHandler *WorkoutTemplateHandler
+	ProgramHandler         *ProgramHandler
+	PersonalRecordHandler  *PersonalRecordHandler
+	AnalyticsHandler       *AnalyticsHandler
 	FoodHandler            *FoodHandler
+	CustomFoodHandler      *CustomFoodHandler
+	RecipeHandler          *RecipeHandler
 	NutritionHandler       *NutritionHandler
 	FatSecretAuthHandler   *FatSecretAuthHandler
 }
@@ -23,11 +37,25 @@ func InitHandlers(services *services.Services, envs *config.Envs) *Handlers {
 		ExerciseHandler:        NewExerciseHandler(services.ExerciseService),
 		CategoryHandler:        NewCategoryHandler(services.CategoryService),
 		UserHandler:            NewUserHandler(services.UserService),
+		AccountHandler:         NewAccountHandler(services.AccountService),
+		DataExportHandler:      NewDataExportHandler(services.DataExportService),
+		RoleHandler:            NewRoleHandler(services.RoleService),
+		AdminHandler:           NewAdminHandler(services.AdminService),
 		AuthHandler:            NewAuthHandler(services.AuthService),
+		TwoFactorHandler:       NewTwoFactorHandler(services.TwoFactorService),
+		APITokenHandler:        NewAPITokenHandler(services.APITokenService),
 		HealthHandler:          NewHealthHandler(services.HealthService),
 		WorkoutHandler:         NewWorkoutHandler(services.WorkoutSerivce),
 		WorkoutExerciseHandler: NewWorkoutExerciseHandler(services.WorkoutExerciseSerivce),
+		WorkoutSetHandler:      NewWorkoutSetHandler(services.WorkoutSetService),
+		WorkoutSessionHandler:  NewWorkoutSessionHandler(services.WorkoutSessionService, envs.FrontendUrl),
+		WorkoutTemplateHandler: NewWorkoutTemplateHandler(services.WorkoutTemplateService),
+		ProgramHandler:         NewProgramHandler(services.ProgramService),
+		PersonalRecordHandler:  NewPersonalRecordHandler(services.PersonalRecordService),
+		AnalyticsHandler:       NewAnalyticsHandler(services.AnalyticsService),
 		FoodHandler:            NewFoodHandler(services.FoodService),
+		CustomFoodHandler:      NewCustomFoodHandler(services.CustomFoodService),
+		RecipeHandler:          NewRecipeHandler(services.RecipeService),
 		NutritionHandler:       NewNutritionHandler(services.NutritionService),
 		FatSecretAuthHandler:   NewFatSecretAuthHandler(services.NutritionService, envs.FrontendUrl),
 	}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PersonalRecordHandler struct {
+	personalRecordService *services.PersonalRecordService
+}
+
+func NewPersonalRecordHandler(personalRecordService *services.PersonalRecordService) *PersonalRecordHandler {
+	return &PersonalRecordHandler{personalRecordService: personalRecordService}
+}
+
+// GetMyRecords godoc
+// @Summary Get my personal records
+// @Description Get current personal records of user for all exercises
+// @Tags records
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.PersonalRecordResponse "Records successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Records not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get records"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/records [get]
+func (h *PersonalRecordHandler) GetMyRecords(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	records, err := h.personalRecordService.GetMyRecords(ctx)
+	if err != nil {
+		log.Println("Failed to get records:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newPersonalRecordResponses(*records))
+}
+
+// GetExerciseRecords godoc
+// @Summary Get exercise personal records
+// @Description Get current personal records and their history for exercise
+// @Tags records
+// @Accept json
+// @Produce json
+// @Param id path int true "Exercise id"
+// @Success 200 {object} models.ExerciseRecordsResponse "Records successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Records not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get records"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /exercises/{id}/records [get]
+func (h *PersonalRecordHandler) GetExerciseRecords(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.personalRecordService.GetExerciseRecords(ctx, id)
+	if err != nil {
+		log.Println("Failed to get exercise records:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.ExerciseRecordsResponse{
+		ExerciseID: id,
+		History:    newPersonalRecordResponses(*records),
+	}
+
+	for _, record := range response.History {
+		if record.IsCurrent {
+			response.Current = append(response.Current, record)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func newPersonalRecordResponses(records []models.PersonalRecord) []models.PersonalRecordResponse {
+	var response []models.PersonalRecordResponse
+	for _, record := range records {
+		response = append(response, models.PersonalRecordResponse{
+			ID:                record.ID,
+			ExerciseID:        record.ExerciseID,
+			ExerciseName:      record.ExerciseName,
+			RecordType:        record.RecordType,
+			Value:             record.Value,
+			Weight:            record.Weight,
+			Reps:              record.Reps,
+			WorkoutID:         record.WorkoutID,
+			WorkoutExerciseID: record.WorkoutExerciseID,
+			AchievedAt:        record.AchievedAt,
+			IsCurrent:         record.IsCurrent,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,895 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ProgramHandler struct {
+	programService *services.ProgramService
+}
+
+func NewProgramHandler(programService *services.ProgramService) *ProgramHandler {
+	return &ProgramHandler{programService: programService}
+}
+
+// AddClient godoc
+// @Summary Add client
+// @Description Invite user with given email to become client of current trainer. Programs can be assigned and data read only after the client accepts the invitation
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param client body models.TrainerClientRequest true "Client email"
+// @Success 201 {object} models.TrainerClientResponse "Client invited"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "Record already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to add client"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/clients [post]
+func (h *ProgramHandler) AddClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.TrainerClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	client, err := h.programService.AddClient(ctx, &request)
+	if err != nil {
+		log.Println("Failed to add client:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newTrainerClientResponse(client))
+}
+
+// GetClients godoc
+// @Summary Get clients
+// @Description Get clients of current trainer, status is pending until the client accepts the invitation
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.TrainerClientResponse "Clients successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Clients not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get clients"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/clients [get]
+func (h *ProgramHandler) GetClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	clients, err := h.programService.GetClients(ctx)
+	if err != nil {
+		log.Println("Failed to get clients:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var response []models.TrainerClientResponse
+	for i := range *clients {
+		response = append(response, newTrainerClientResponse(&(*clients)[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetClientAssignments godoc
+// @Summary Get client programs
+// @Description Get programs assigned by current trainer to client
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client id"
+// @Success 200 {array} models.ProgramAssignmentResponse "Assignments successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Assignments not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get assignments"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/clients/{clientID}/assignments [get]
+func (h *ProgramHandler) GetClientAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	clientID, err := strconv.Atoi(chi.URLParam(r, "clientID"))
+	if err != nil || clientID < 1 {
+		log.Println("Incorrect client id:", err)
+		utils.JSONError(w, "Incorrect client id", http.StatusBadRequest)
+		return
+	}
+
+	assignments, err := h.programService.GetClientAssignments(ctx, clientID)
+	if err != nil {
+		log.Println("Failed to get client assignments:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var response []models.ProgramAssignmentResponse
+	for i := range *assignments {
+		response = append(response, newProgramAssignmentResponse(&(*assignments)[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateProgram godoc
+// @Summary Create program
+// @Description Create multi-week program from templates of current trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param program body models.ProgramRequest true "Program data"
+// @Success 201 {object} models.ProgramResponse "Program created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Record already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to create program"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs [post]
+func (h *ProgramHandler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	program, err := h.programService.CreateProgram(ctx, &request)
+	if err != nil {
+		log.Println("Failed to create program:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newProgramResponse(program))
+}
+
+// GetPrograms godoc
+// @Summary Get programs
+// @Description Get programs of current trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ProgramResponse "Programs successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Programs not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get programs"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs [get]
+func (h *ProgramHandler) GetPrograms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	programs, err := h.programService.GetPrograms(ctx)
+	if err != nil {
+		log.Println("Failed to get programs:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var response []models.ProgramResponse
+	for i := range *programs {
+		response = append(response, newProgramResponse(&(*programs)[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetProgram godoc
+// @Summary Get program
+// @Description Get program of current trainer with its workouts
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Program id"
+// @Success 200 {object} models.ProgramResponse "Program successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Program not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get program"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs/{id} [get]
+func (h *ProgramHandler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	program, err := h.programService.GetProgram(ctx, id)
+	if err != nil {
+		log.Println("Failed to get program:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProgramResponse(program))
+}
+
+// UpdateProgram godoc
+// @Summary Update program
+// @Description Update program and replace its workouts
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Program id"
+// @Param program body models.ProgramRequest true "Program data"
+// @Success 200 {object} models.ProgramResponse "Program updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Program not found"
+// @Failure 409 {object} models.ErrorResponse "Record already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to update program"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs/{id} [put]
+func (h *ProgramHandler) UpdateProgram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	program, err := h.programService.UpdateProgram(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to update program:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProgramResponse(program))
+}
+
+// AssignProgram godoc
+// @Summary Assign program
+// @Description Assign program to client and generate scheduled workouts starting from start date
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Program id"
+// @Param assignment body models.ProgramAssignmentRequest true "Assignment data"
+// @Success 201 {object} models.ProgramAssignmentResponse "Program assigned"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Program not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to assign program"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs/{id}/assign [post]
+func (h *ProgramHandler) AssignProgram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.ProgramAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.programService.AssignProgram(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to assign program:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newProgramAssignmentResponse(assignment))
+}
+
+// GetClientSchedule godoc
+// @Summary Get client schedule
+// @Description Get scheduled workouts of program assigned by current trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment id"
+// @Success 200 {object} models.ProgramAssignmentResponse "Schedule successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Assignment not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get schedule"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/assignments/{id}/schedule [get]
+func (h *ProgramHandler) GetClientSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.programService.GetClientSchedule(ctx, id)
+	if err != nil {
+		log.Println("Failed to get client schedule:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProgramAssignmentResponse(assignment))
+}
+
+// GetMyPrograms godoc
+// @Summary Get my programs
+// @Description Get programs assigned to current user
+// @Tags programs
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ProgramAssignmentResponse "Programs successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Programs not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get programs"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /programs [get]
+func (h *ProgramHandler) GetMyPrograms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	assignments, err := h.programService.GetMyAssignments(ctx)
+	if err != nil {
+		log.Println("Failed to get programs:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var response []models.ProgramAssignmentResponse
+	for i := range *assignments {
+		response = append(response, newProgramAssignmentResponse(&(*assignments)[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetMySchedule godoc
+// @Summary Get my schedule
+// @Description Get scheduled workouts of program assigned to current user
+// @Tags programs
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment id"
+// @Success 200 {object} models.ProgramAssignmentResponse "Schedule successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Program not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get schedule"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /programs/{id}/schedule [get]
+func (h *ProgramHandler) GetMySchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.programService.GetMySchedule(ctx, id)
+	if err != nil {
+		log.Println("Failed to get schedule:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newProgramAssignmentResponse(assignment))
+}
+
+// RemoveClient godoc
+// @Summary Remove client
+// @Description Remove client from current trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client id"
+// @Success 204 "Client successfully removed"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Client not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to remove client"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/clients/{clientID} [delete]
+func (h *ProgramHandler) RemoveClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	clientID, err := strconv.Atoi(chi.URLParam(r, "clientID"))
+	if err != nil || clientID < 1 {
+		log.Println("Incorrect client id:", err)
+		utils.JSONError(w, "Incorrect client id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.RemoveClient(ctx, clientID); err != nil {
+		log.Println("Failed to remove client:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteProgram godoc
+// @Summary Delete program
+// @Description Delete program of current trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Program id"
+// @Success 204 "Program successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Program not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete program"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/programs/{id} [delete]
+func (h *ProgramHandler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.DeleteProgram(ctx, id); err != nil {
+		log.Println("Failed to delete program:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CancelAssignment godoc
+// @Summary Cancel assignment
+// @Description Cancel program assignment and remove upcoming scheduled workouts
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment id"
+// @Success 204 "Assignment successfully cancelled"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Assignment not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to cancel assignment"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainer/assignments/{id} [delete]
+func (h *ProgramHandler) CancelAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.CancelAssignment(ctx, id); err != nil {
+		log.Println("Failed to cancel assignment:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetInvitations godoc
+// @Summary Get trainer invitations
+// @Description Get pending invitations of trainers to current user
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.TrainerInvitation "Invitations successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Failed to get invitations"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /invitations [get]
+func (h *ProgramHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	invitations, err := h.programService.GetInvitations(ctx)
+	if err != nil {
+		log.Println("Failed to get invitations:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept trainer invitation
+// @Description Accept invitation of a trainer, the trainer can then assign programs and read training data of current user
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Invitation id"
+// @Success 204 "Invitation accepted"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Invitation not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to accept invitation"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /invitations/{id}/accept [post]
+func (h *ProgramHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.AcceptInvitation(ctx, id); err != nil {
+		log.Println("Failed to accept invitation:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline trainer invitation
+// @Description Decline pending invitation of a trainer
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param id path int true "Invitation id"
+// @Success 204 "Invitation declined"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Invitation not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to decline invitation"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /invitations/{id} [delete]
+func (h *ProgramHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.DeclineInvitation(ctx, id); err != nil {
+		log.Println("Failed to decline invitation:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LeaveTrainer godoc
+// @Summary Leave trainer
+// @Description Leave the trainer whose invitation was accepted. Programs assigned by the trainer are cancelled
+// @Tags trainer
+// @Accept json
+// @Produce json
+// @Param trainerID path int true "Trainer id"
+// @Success 204 "Trainer left"
+// @Failure 400 {object} models.ErrorResponse "Incorrect trainer id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Trainer not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to leave trainer"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /trainers/{trainerID} [delete]
+func (h *ProgramHandler) LeaveTrainer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	trainerID, err := strconv.Atoi(chi.URLParam(r, "trainerID"))
+	if err != nil || trainerID < 1 {
+		log.Println("Incorrect trainer id:", err)
+		utils.JSONError(w, "Incorrect trainer id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.programService.LeaveTrainer(ctx, trainerID); err != nil {
+		log.Println("Failed to leave trainer:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newTrainerClientResponse(client *models.TrainerClient) models.TrainerClientResponse {
+	return models.TrainerClientResponse{
+		ID:         client.ID,
+		ClientID:   client.ClientID,
+		Username:   client.Username,
+		Email:      client.Email,
+		Status:     client.Status(),
+		CreatedAt:  client.CreatedAt,
+		AcceptedAt: client.AcceptedAt,
+	}
+}
+
+func newProgramResponse(program *models.Program) models.ProgramResponse {
+	response := models.ProgramResponse{
+		ID:          program.ID,
+		TrainerID:   program.TrainerID,
+		Name:        program.Name,
+		Description: program.Description,
+		Weeks:       program.Weeks,
+		CreatedAt:   program.CreatedAt,
+		UpdatedAt:   program.UpdatedAt,
+	}
+
+	for _, workout := range program.Workouts {
+		response.Workouts = append(response.Workouts, models.ProgramWorkoutResponse{
+			ID:           workout.ID,
+			TemplateID:   workout.TemplateID,
+			TemplateName: workout.TemplateName,
+			Week:         workout.Week,
+			Day:          workout.Day,
+			Notes:        workout.Notes,
+		})
+	}
+
+	return response
+}
+
+func newProgramAssignmentResponse(assignment *models.ProgramAssignment) models.ProgramAssignmentResponse {
+	response := models.ProgramAssignmentResponse{
+		ID:          assignment.ID,
+		ProgramID:   assignment.ProgramID,
+		ProgramName: assignment.ProgramName,
+		TrainerID:   assignment.TrainerID,
+		TrainerName: assignment.TrainerName,
+		ClientID:    assignment.ClientID,
+		StartDate:   assignment.StartDate,
+		CreatedAt:   assignment.CreatedAt,
+	}
+
+	for _, scheduled := range assignment.ScheduledWorkouts {
+		response.ScheduledWorkouts = append(response.ScheduledWorkouts, models.ScheduledWorkoutResponse{
+			ID:            scheduled.ID,
+			WorkoutID:     scheduled.WorkoutID,
+			Week:          scheduled.Week,
+			Day:           scheduled.Day,
+			ScheduledDate: scheduled.ScheduledDate,
+			Notes:         scheduled.Notes,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RecipeHandler struct {
+	recipeService *services.RecipeService
+}
+
+func NewRecipeHandler(recipeService *services.RecipeService) *RecipeHandler {
+	return &RecipeHandler{recipeService: recipeService}
+}
+
+// CreateRecipe godoc
+// @Summary Create recipe
+// @Description Create recipe from ingredients with gram weights and number of servings. An ingredient is a custom food, a food of the nutrition provider by id or a product name recognized by the provider. Nutrition of the ingredients is saved with the recipe, total and per serving nutrition is computed. Log servings of the recipe with recipe_id in POST /foods
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param recipe body models.RecipeRequest true "Recipe data"
+// @Success 201 {object} models.Recipe "Recipe created"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to create recipe"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/recipes [post]
+func (h *RecipeHandler) CreateRecipe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.RecipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := h.recipeService.CreateRecipe(ctx, &request)
+	if err != nil {
+		log.Println("Failed to create recipe:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(recipe)
+}
+
+// GetRecipes godoc
+// @Summary Get recipes
+// @Description Get recipes of current user with total and per serving nutrition, without ingredients
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Recipe "Recipes successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Recipes not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get recipes"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/recipes [get]
+func (h *RecipeHandler) GetRecipes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	recipes, err := h.recipeService.GetRecipes(ctx)
+	if err != nil {
+		log.Println("Failed to get recipes:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(recipes)
+}
+
+// GetRecipe godoc
+// @Summary Get recipe
+// @Description Get recipe of current user by id with ingredients
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe id"
+// @Success 200 {object} models.Recipe "Recipe successfully got"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Recipe not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get recipe"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/recipes/{id} [get]
+func (h *RecipeHandler) GetRecipe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := h.recipeService.GetRecipe(ctx, id)
+	if err != nil {
+		log.Println("Failed to get recipe:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(recipe)
+}
+
+// UpdateRecipe godoc
+// @Summary Update recipe
+// @Description Replace recipe of current user by id, nutrition of the ingredients is taken again. Food diary entries logged from the recipe before keep their values
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe id"
+// @Param recipe body models.RecipeRequest true "Recipe data"
+// @Success 200 {object} models.Recipe "Recipe updated"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Invalid input"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Recipe or food not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to update recipe"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/recipes/{id} [put]
+func (h *RecipeHandler) UpdateRecipe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.RecipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := h.recipeService.UpdateRecipe(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to update recipe:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(recipe)
+}
+
+// DeleteRecipe godoc
+// @Summary Delete recipe
+// @Description Delete recipe of current user by id. Food diary entries logged from the recipe are kept
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe id"
+// @Success 204 "Recipe successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Recipe not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete recipe"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /foods/recipes/{id} [delete]
+func (h *RecipeHandler) DeleteRecipe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.recipeService.DeleteRecipe(ctx, id); err != nil {
+		log.Println("Failed to delete recipe:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
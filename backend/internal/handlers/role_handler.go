@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RoleHandler struct {
+	roleService *services.RoleService
+}
+
+func NewRoleHandler(roleService *services.RoleService) *RoleHandler {
+	return &RoleHandler{roleService: roleService}
+}
+
+// GetRoles godoc
+// @Summary Get roles
+// @Description Get all roles with their permissions. Requires the roles:manage permission
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Role "Roles successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 500 {object} models.ErrorResponse "Failed to get roles"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/roles [get]
+func (h *RoleHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	roles, err := h.roleService.GetRoles(ctx)
+	if err != nil {
+		log.Println("Failed to get roles:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roles)
+}
+
+// CreateRole godoc
+// @Summary Create role
+// @Description Create a role without permissions. Requires the roles:manage permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body models.RoleRequest true "Role name and description"
+// @Success 201 {object} models.Role "Role successfully created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Role name must be 2-50 characters of latin letters, digits, '-' or '_'"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 409 {object} models.ErrorResponse "Role already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to create role"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/roles [post]
+func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := h.roleService.CreateRole(ctx, &req)
+	if err != nil {
+		log.Println("Failed to create role:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(role)
+}
+
+// SetRolePermissions godoc
+// @Summary Set role permissions
+// @Description Replace the permissions of a role. Users having the role get the new permissions on their next request. Requires the roles:manage permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Role id"
+// @Param data body models.RolePermissionsRequest true "Permission names"
+// @Success 200 {object} models.Role "Role permissions successfully updated"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Unknown permission"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 404 {object} models.ErrorResponse "Role not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to set role permissions"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/roles/{id}/permissions [put]
+func (h *RoleHandler) SetRolePermissions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.RolePermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Permissions == nil {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	role, err := h.roleService.SetRolePermissions(ctx, id, &req)
+	if err != nil {
+		log.Println("Failed to set role permissions:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(role)
+}
+
+// GetPermissions godoc
+// @Summary Get permissions
+// @Description Get all permissions that can be granted to roles. Requires the roles:manage permission
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Permission "Permissions successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Access denied: insufficient rights"
+// @Failure 500 {object} models.ErrorResponse "Failed to get permissions"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /admin/permissions [get]
+func (h *RoleHandler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	permissions, err := h.roleService.GetPermissions(ctx)
+	if err != nil {
+		log.Println("Failed to get permissions:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(permissions)
+}
+
+// GetMyPermissions godoc
+// @Summary Get current user permissions
+// @Description Get the permissions granted to current user by all of their roles
+// @Tags user
+// @Produce json
+// @Success 200 {array} string "Permissions successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get user permissions"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/permissions [get]
+func (h *RoleHandler) GetMyPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	permissions, err := h.roleService.GetCurrentUserPermissions(ctx)
+	if err != nil {
+		log.Println("Failed to get user permissions:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(permissions)
+}
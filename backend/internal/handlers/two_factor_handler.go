@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService *services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+// GetStatus godoc
+// @Summary Get two-factor status
+// @Description Get whether two-factor authentication is enabled for current user and how many recovery codes are left
+// @Tags two-factor
+// @Produce json
+// @Success 200 {object} models.TwoFactorStatusResponse "Two-factor status successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get two-factor status"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/2fa [get]
+func (h *TwoFactorHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	response, err := h.twoFactorService.GetStatus(ctx)
+	if err != nil {
+		log.Println("Failed to get two-factor status:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Setup godoc
+// @Summary Setup two-factor authentication
+// @Description Generate a TOTP secret and otpauth URI for the authenticator app. Two-factor authentication is enabled only after the first code is confirmed
+// @Tags two-factor
+// @Produce json
+// @Success 200 {object} models.TwoFactorSetupResponse "Secret successfully generated"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication is already enabled"
+// @Failure 500 {object} models.ErrorResponse "Failed to setup two-factor authentication"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/2fa/setup [post]
+func (h *TwoFactorHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	response, err := h.twoFactorService.Setup(ctx)
+	if err != nil {
+		log.Println("Failed to setup two-factor authentication:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Enable godoc
+// @Summary Enable two-factor authentication
+// @Description Confirm the secret from setup with a code from the authenticator app. Recovery codes are returned once
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param data body models.TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} models.RecoveryCodesResponse "Two-factor authentication successfully enabled"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Two-factor authentication setup is not started"
+// @Failure 400 {object} models.ErrorResponse "Invalid code"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication is already enabled"
+// @Failure 429 {object} models.ErrorResponse "Too many attempts"
+// @Failure 500 {object} models.ErrorResponse "Failed to enable two-factor authentication"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/2fa/enable [post]
+func (h *TwoFactorHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.twoFactorService.Enable(ctx, req.Code, utils.ClientIP(r))
+	if err != nil {
+		log.Println("Failed to enable two-factor authentication:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			setRetryAfter(w, appErr)
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication with a code from the authenticator app or a recovery code
+// @Tags two-factor
+// @Accept json
+// @Param data body models.TwoFactorCodeRequest true "Code from the authenticator app or recovery code"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Two-factor authentication is not enabled"
+// @Failure 400 {object} models.ErrorResponse "Invalid code"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 429 {object} models.ErrorResponse "Too many attempts"
+// @Failure 500 {object} models.ErrorResponse "Failed to disable two-factor authentication"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.twoFactorService.Disable(ctx, req.Code, utils.ClientIP(r)); err != nil {
+		log.Println("Failed to disable two-factor authentication:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			setRetryAfter(w, appErr)
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes of current user, old codes stop working. Requires a code from the authenticator app
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param data body models.TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} models.RecoveryCodesResponse "Recovery codes successfully regenerated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Two-factor authentication is not enabled"
+// @Failure 400 {object} models.ErrorResponse "Invalid code"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 429 {object} models.ErrorResponse "Too many attempts"
+// @Failure 500 {object} models.ErrorResponse "Failed to regenerate recovery codes"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var req models.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		utils.JSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.twoFactorService.RegenerateRecoveryCodes(ctx, req.Code, utils.ClientIP(r))
+	if err != nil {
+		log.Println("Failed to regenerate recovery codes:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			setRetryAfter(w, appErr)
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
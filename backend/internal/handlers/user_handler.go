@@ -67,6 +67,41 @@ func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// Get current user roles godoc
+// @Summary Get current user roles
+// @Description Endpoint for get roles of current user
+// @Tags user
+// @Produce json
+// @Success 200 {array} models.Role "User roles data"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Failed to get user roles"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/me/roles [get]
+func (h *UserHandler) GetMyRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	roles, err := h.userService.GetCurrentUserRoles(ctx)
+	if err != nil {
+		log.Println("Failed to get user roles:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roles)
+}
+
 // Add role to user godoc
 // @Summary Add role to user
 // @Description Endpoint for add role to user
@@ -134,6 +169,63 @@ func (h *UserHandler) AddRoleToUser(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// Remove role from user godoc
+// @Summary Remove role from user
+// @Description Endpoint for remove role from user. Requires the roles:manage permission
+// @Tags user
+// @Param id path int true "User id"
+// @Param roleID path int true "Role id"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Incorrect role id"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User has no such role"
+// @Failure 500 {object} models.ErrorResponse "Failed to remove role"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /users/{id}/roles/{roleID} [delete]
+func (h *UserHandler) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	roleID, err := strconv.Atoi(chi.URLParam(r, "roleID"))
+	if err != nil || roleID < 1 {
+		log.Println("Incorrect role id:", err)
+		utils.JSONError(w, "Incorrect role id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	userID := ctx.Value("user_id").(int)
+	if userID == id {
+		utils.JSONError(w, "You can't change your role", http.StatusForbidden)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := h.userService.RemoveRoleFromUser(ctx, id, roleID); err != nil {
+		log.Println("Failed to remove role:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Get user roles godoc
 // @Summary Get user roles
 // @Description Endpoint for get user roles
@@ -34,6 +34,8 @@ func NewWorkoutExerciseHandler(workoutExerciseService *services.WorkoutExerciseS
 // @Param workoutExercise body models.WorkoutExerciseRequest true "Exercise data"
 // @Success 201 {object} models.WorkoutExerciseResponse "Exercise added to workout"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Fields do not match exercise tracking type"
+// @Failure 400 {object} models.ErrorResponse "End time must not be before start time"
 // @Failure 400 {object} models.ErrorResponse "Invalid workout id"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
@@ -77,14 +79,22 @@ func (h *WorkoutExerciseHandler) AddExerciseToWorkout(w http.ResponseWriter, r *
 	}
 
 	response := models.WorkoutExerciseResponse{
-		ID:         workoutExercise.ID,
-		WorkoutID:  workoutExercise.WorkoutID,
-		ExerciseID: workoutExercise.ExerciseID,
-		Sets:       workoutExercise.Sets,
-		Reps:       workoutExercise.Reps,
-		Weight:     workoutExercise.Weight,
-		Notes:      workoutExercise.Notes,
-		CreatedAt:  workoutExercise.CreatedAt,
+		ID:               workoutExercise.ID,
+		WorkoutID:        workoutExercise.WorkoutID,
+		ExerciseID:       workoutExercise.ExerciseID,
+		Sets:             workoutExercise.Sets,
+		Reps:             workoutExercise.Reps,
+		Weight:           workoutExercise.Weight,
+		DurationSeconds:  workoutExercise.DurationSeconds,
+		DistanceMeters:   workoutExercise.DistanceMeters,
+		PaceSecondsPerKm: utils.PaceSecondsPerKm(workoutExercise.DurationSeconds, workoutExercise.DistanceMeters),
+		HeartRateAvg:     workoutExercise.HeartRateAvg,
+		StartedAt:        workoutExercise.StartedAt,
+		EndedAt:          workoutExercise.EndedAt,
+		Notes:            workoutExercise.Notes,
+		CreatedAt:        workoutExercise.CreatedAt,
+		WorkoutSets:      newWorkoutSetResponses(workoutExercise.WorkoutSets),
+		NewRecords:       newPersonalRecordResponses(workoutExercise.NewRecords),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -139,15 +149,23 @@ func (h *WorkoutExerciseHandler) GetExercisesByWorkoutID(w http.ResponseWriter,
 	var response []models.WorkoutExerciseResponse
 	for _, workoutExercise := range *workoutExercises {
 		workoutExerciseResponse := models.WorkoutExerciseResponse{
-			ID:         workoutExercise.ID,
-			WorkoutID:  workoutExercise.WorkoutID,
-			ExerciseID: workoutExercise.ExerciseID,
-			Sets:       workoutExercise.Sets,
-			Reps:       workoutExercise.Reps,
-			Weight:     workoutExercise.Weight,
-			Notes:      workoutExercise.Notes,
-			CreatedAt:  workoutExercise.CreatedAt,
-			Exercise:   workoutExercise.Exercise,
+			ID:               workoutExercise.ID,
+			WorkoutID:        workoutExercise.WorkoutID,
+			ExerciseID:       workoutExercise.ExerciseID,
+			Sets:             workoutExercise.Sets,
+			Reps:             workoutExercise.Reps,
+			Weight:           workoutExercise.Weight,
+			DurationSeconds:  workoutExercise.DurationSeconds,
+			DistanceMeters:   workoutExercise.DistanceMeters,
+			PaceSecondsPerKm: utils.PaceSecondsPerKm(workoutExercise.DurationSeconds, workoutExercise.DistanceMeters),
+			HeartRateAvg:     workoutExercise.HeartRateAvg,
+			StartedAt:        workoutExercise.StartedAt,
+			EndedAt:          workoutExercise.EndedAt,
+			Notes:            workoutExercise.Notes,
+			CreatedAt:        workoutExercise.CreatedAt,
+			Exercise:         workoutExercise.Exercise,
+			WorkoutSets:      newWorkoutSetResponses(workoutExercise.WorkoutSets),
+			NewRecords:       newPersonalRecordResponses(workoutExercise.NewRecords),
 		}
 
 		response = append(response, workoutExerciseResponse)
@@ -212,15 +230,23 @@ func (h *WorkoutExerciseHandler) GetExerciseByWorkoutID(w http.ResponseWriter, r
 	}
 
 	response := models.WorkoutExerciseResponse{
-		ID:         workoutExercise.ID,
-		WorkoutID:  workoutExercise.WorkoutID,
-		ExerciseID: workoutExercise.ExerciseID,
-		Sets:       workoutExercise.Sets,
-		Reps:       workoutExercise.Reps,
-		Weight:     workoutExercise.Weight,
-		Notes:      workoutExercise.Notes,
-		CreatedAt:  workoutExercise.CreatedAt,
-		Exercise:   workoutExercise.Exercise,
+		ID:               workoutExercise.ID,
+		WorkoutID:        workoutExercise.WorkoutID,
+		ExerciseID:       workoutExercise.ExerciseID,
+		Sets:             workoutExercise.Sets,
+		Reps:             workoutExercise.Reps,
+		Weight:           workoutExercise.Weight,
+		DurationSeconds:  workoutExercise.DurationSeconds,
+		DistanceMeters:   workoutExercise.DistanceMeters,
+		PaceSecondsPerKm: utils.PaceSecondsPerKm(workoutExercise.DurationSeconds, workoutExercise.DistanceMeters),
+		HeartRateAvg:     workoutExercise.HeartRateAvg,
+		StartedAt:        workoutExercise.StartedAt,
+		EndedAt:          workoutExercise.EndedAt,
+		Notes:            workoutExercise.Notes,
+		CreatedAt:        workoutExercise.CreatedAt,
+		Exercise:         workoutExercise.Exercise,
+		WorkoutSets:      newWorkoutSetResponses(workoutExercise.WorkoutSets),
+		NewRecords:       newPersonalRecordResponses(workoutExercise.NewRecords),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -230,7 +256,7 @@ func (h *WorkoutExerciseHandler) GetExerciseByWorkoutID(w http.ResponseWriter, r
 
 // UpdateExerciseInWorkout godoc
 // @Summary Update exercise in workout
-// @Description Update exercise in workout
+// @Description Update exercise in workout. The sets of the exercise are replaced with the sent ones
 // @Tags workouts
 // @Accept json
 // @Produce json
@@ -239,6 +265,8 @@ func (h *WorkoutExerciseHandler) GetExerciseByWorkoutID(w http.ResponseWriter, r
 // @Param workoutExercise body models.WorkoutExerciseRequest true "Exercise data"
 // @Success 200 {object} models.WorkoutExerciseResponse "Exercise updated successfully"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Fields do not match exercise tracking type"
+// @Failure 400 {object} models.ErrorResponse "End time must not be before start time"
 // @Failure 400 {object} models.ErrorResponse "Invalid workout id"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
@@ -292,14 +320,22 @@ func (h *WorkoutExerciseHandler) UpdateExerciseInWorkout(w http.ResponseWriter,
 	}
 
 	response := models.WorkoutExerciseResponse{
-		ID:         workoutExercise.ID,
-		WorkoutID:  workoutExercise.WorkoutID,
-		ExerciseID: workoutExercise.ExerciseID,
-		Sets:       workoutExercise.Sets,
-		Reps:       workoutExercise.Reps,
-		Weight:     workoutExercise.Weight,
-		Notes:      workoutExercise.Notes,
-		CreatedAt:  workoutExercise.CreatedAt,
+		ID:               workoutExercise.ID,
+		WorkoutID:        workoutExercise.WorkoutID,
+		ExerciseID:       workoutExercise.ExerciseID,
+		Sets:             workoutExercise.Sets,
+		Reps:             workoutExercise.Reps,
+		Weight:           workoutExercise.Weight,
+		DurationSeconds:  workoutExercise.DurationSeconds,
+		DistanceMeters:   workoutExercise.DistanceMeters,
+		PaceSecondsPerKm: utils.PaceSecondsPerKm(workoutExercise.DurationSeconds, workoutExercise.DistanceMeters),
+		HeartRateAvg:     workoutExercise.HeartRateAvg,
+		StartedAt:        workoutExercise.StartedAt,
+		EndedAt:          workoutExercise.EndedAt,
+		Notes:            workoutExercise.Notes,
+		CreatedAt:        workoutExercise.CreatedAt,
+		WorkoutSets:      newWorkoutSetResponses(workoutExercise.WorkoutSets),
+		NewRecords:       newPersonalRecordResponses(workoutExercise.NewRecords),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -33,6 +33,7 @@ func NewWorkoutHandler(workoutService *services.WorkoutSerivce) *WorkoutHandler
 // @Param workout body models.WorkoutRequest true "Workout data"
 // @Success 201 {object} models.WorkoutResponse "Workout created"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "End time must not be before start time"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
@@ -65,14 +66,7 @@ func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := models.WorkoutResponse{
-		ID:        workout.ID,
-		UserID:    workout.UserID,
-		Date:      workout.Date,
-		Notes:     workout.Notes,
-		CreatedAt: workout.CreatedAt,
-		UpdatedAt: workout.UpdatedAt,
-	}
+	response := newWorkoutResponse(workout)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -86,11 +80,18 @@ func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 // @Tags workouts
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.WorkoutResponse "Workouts got successfully"
+// @Param from query string false "Start of time range, RFC 3339 time or YYYY-MM-DD"
+// @Param to query string false "End of time range, RFC 3339 time or YYYY-MM-DD (whole day included)"
+// @Param exercise_id query int false "Only workouts containing exercise"
+// @Param search query string false "Search by notes"
+// @Param page query int false "Page number (default is 1)"
+// @Param limit query int false "Number of workouts per page (default is 20)"
+// @Param sort_order query string false "Sort order by date: asc or desc (default is desc)"
+// @Success 200 {object} models.WorkoutListResponse "Workouts got successfully"
+// @Failure 400 {object} models.ErrorResponse "Invalid query parameters"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
-// @Failure 404 {object} models.ErrorResponse "Workouts not found"
 // @Failure 500 {object} models.ErrorResponse "Failed to get workouts"
 // @Failure 504 {object} models.ErrorResponse "Request timeout"
 // @Router /workouts [get]
@@ -100,7 +101,14 @@ func (h *WorkoutHandler) GetWorkoutsByUserID(w http.ResponseWriter, r *http.Requ
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	workouts, err := h.workoutSerivce.GetWorkoutsByUserID(ctx)
+	filter, err := utils.ParseWorkoutFilter(r)
+	if err != nil {
+		log.Println("Invalid query parameters:", err)
+		utils.JSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	workouts, total, err := h.workoutSerivce.GetWorkoutsByUserID(ctx, filter)
 	if err != nil {
 		log.Println("Failed to get workout")
 		var appErr *apperrors.AppError
@@ -112,18 +120,16 @@ func (h *WorkoutHandler) GetWorkoutsByUserID(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var response []models.WorkoutResponse
+	workoutResponse := []models.WorkoutResponse{}
 	for _, workout := range *workouts {
-		workoutResponse := models.WorkoutResponse{
-			ID:        workout.ID,
-			UserID:    workout.UserID,
-			Date:      workout.Date,
-			Notes:     workout.Notes,
-			CreatedAt: workout.CreatedAt,
-			UpdatedAt: workout.UpdatedAt,
-		}
+		workoutResponse = append(workoutResponse, newWorkoutResponse(&workout))
+	}
 
-		response = append(response, workoutResponse)
+	response := models.WorkoutListResponse{
+		Workouts: workoutResponse,
+		Total:    total,
+		Page:     filter.Page,
+		Limit:    filter.Limit,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -173,14 +179,7 @@ func (h *WorkoutHandler) GetWorkoutByUserID(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	response := models.WorkoutResponse{
-		ID:        workout.ID,
-		UserID:    workout.UserID,
-		Date:      workout.Date,
-		Notes:     workout.Notes,
-		CreatedAt: workout.CreatedAt,
-		UpdatedAt: workout.UpdatedAt,
-	}
+	response := newWorkoutResponse(workout)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -189,7 +188,7 @@ func (h *WorkoutHandler) GetWorkoutByUserID(w http.ResponseWriter, r *http.Reque
 
 // UpdateWorkoutByUserID godoc
 // @Summary Update workout by user id
-// @Description Update workout by user id
+// @Description Update workout by user id. Omitted started_at or ended_at keep their current value, null clears them
 // @Tags workouts
 // @Accept json
 // @Produce json
@@ -199,6 +198,7 @@ func (h *WorkoutHandler) GetWorkoutByUserID(w http.ResponseWriter, r *http.Reque
 // @Failure 400 {object} models.ErrorResponse "Invalid id"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
 // @Failure 400 {object} models.ErrorResponse "Invalid user id"
+// @Failure 400 {object} models.ErrorResponse "End time must not be before start time"
 // @Failure 400 {object} models.ErrorResponse "Request cancelled"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 403 {object} models.ErrorResponse "Forbidden"
@@ -242,14 +242,7 @@ func (h *WorkoutHandler) UpdateWorkoutByUserID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	response := models.WorkoutResponse{
-		ID:        workout.ID,
-		UserID:    workout.UserID,
-		Date:      workout.Date,
-		Notes:     workout.Notes,
-		CreatedAt: workout.CreatedAt,
-		UpdatedAt: workout.UpdatedAt,
-	}
+	response := newWorkoutResponse(workout)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -302,3 +295,75 @@ func (h *WorkoutHandler) DeleteWorkoutByUserID(w http.ResponseWriter, r *http.Re
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// CreateWorkoutFromTemplate godoc
+// @Summary Create workout from template
+// @Description Create workout with exercises and planned sets copied from template
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param templateID path int true "Template id"
+// @Param workout body models.WorkoutFromTemplateRequest true "Workout data"
+// @Success 201 {object} models.WorkoutResponse "Workout created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Template not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to create workout from template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/from-template/{templateID} [post]
+func (h *WorkoutHandler) CreateWorkoutFromTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	templateID, err := strconv.Atoi(chi.URLParam(r, "templateID"))
+	if err != nil || templateID < 1 {
+		log.Println("Incorrect template id:", err)
+		utils.JSONError(w, "Incorrect template id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.WorkoutFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	workout, err := h.workoutSerivce.CreateWorkoutFromTemplate(ctx, templateID, &request)
+	if err != nil {
+		log.Println("Failed to create workout from template:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := newWorkoutResponse(workout)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func newWorkoutResponse(workout *models.Workout) models.WorkoutResponse {
+	return models.WorkoutResponse{
+		ID:              workout.ID,
+		UserID:          workout.UserID,
+		Date:            workout.Date,
+		Notes:           workout.Notes,
+		StartedAt:       workout.StartedAt,
+		EndedAt:         workout.EndedAt,
+		DurationSeconds: utils.DurationSeconds(workout.StartedAt, workout.EndedAt),
+		TotalSets:       workout.TotalSets,
+		TotalVolume:     workout.TotalVolume,
+		CreatedAt:       workout.CreatedAt,
+		UpdatedAt:       workout.UpdatedAt,
+	}
+}
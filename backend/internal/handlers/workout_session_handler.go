@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	sessionWriteWait      = 10 * time.Second
+	sessionPongWait       = 60 * time.Second
+	sessionPingPeriod     = 50 * time.Second
+	sessionMaxMessageSize = 4096
+)
+
+type WorkoutSessionHandler struct {
+	workoutSessionService *services.WorkoutSessionService
+	upgrader              websocket.Upgrader
+}
+
+func NewWorkoutSessionHandler(workoutSessionService *services.WorkoutSessionService, frontendUrl string) *WorkoutSessionHandler {
+	return &WorkoutSessionHandler{
+		workoutSessionService: workoutSessionService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origin == frontendUrl
+			},
+		},
+	}
+}
+
+// StartSession godoc
+// @Summary Start live workout session
+// @Description Mark workout as started and open a live session shared by all devices of the user
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Success 200 {object} models.WorkoutSessionResponse "Session started"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Workout not found"
+// @Failure 409 {object} models.ErrorResponse "Workout already finished"
+// @Failure 500 {object} models.ErrorResponse "Failed to start workout"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/start [post]
+func (h *WorkoutSessionHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || workoutID < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.workoutSessionService.StartSession(ctx, workoutID)
+	if err != nil {
+		log.Println("Failed to start session:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.WorkoutSessionResponse{
+		WorkoutID:  session.WorkoutID,
+		StartedAt:  session.StartedAt,
+		RestEndsAt: session.RestEndsAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// FinishSession godoc
+// @Summary Finish live workout session
+// @Description Close the live session and record workout duration, total sets and total volume
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Success 200 {object} models.WorkoutResponse "Workout finished"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Workout not found"
+// @Failure 409 {object} models.ErrorResponse "Workout is not started"
+// @Failure 409 {object} models.ErrorResponse "Workout already finished"
+// @Failure 500 {object} models.ErrorResponse "Failed to finish workout"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/finish [post]
+func (h *WorkoutSessionHandler) FinishSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || workoutID < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	workout, err := h.workoutSessionService.FinishSession(ctx, workoutID)
+	if err != nil {
+		log.Println("Failed to finish session:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutResponse(workout))
+}
+
+// LiveSession godoc
+// @Summary Connect to live workout session
+// @Description Upgrade to WebSocket. Clients send models.SessionMessage (complete_set, update_set, delete_set, start_rest_timer, stop_rest_timer) and receive models.SessionEventResponse for every change made by any device, plus rest_timer_tick every second while the rest timer runs. API tokens need the workouts:write scope
+// @Tags workouts
+// @Produce json
+// @Param id path int true "Workout id"
+// @Success 101 {object} models.SessionEventResponse "Switching protocols"
+// @Failure 400 {object} models.ErrorResponse "Incorrect id"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to subscribe to session"
+// @Router /workouts/{id}/live [get]
+func (h *WorkoutSessionHandler) LiveSession(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || workoutID < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	lookupCtx, lookupCancel := context.WithTimeout(ctx, 5*time.Second)
+	session, err := h.workoutSessionService.GetSession(lookupCtx, workoutID)
+	lookupCancel()
+	if err != nil {
+		log.Println("Failed to get session:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	events, closeEvents, err := h.workoutSessionService.Subscribe(ctx, workoutID)
+	if err != nil {
+		log.Println("Failed to subscribe to session:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer closeEvents()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("Failed to upgrade connection:", err)
+		return
+	}
+	defer conn.Close()
+
+	replies := make(chan models.SessionEventResponse)
+	go h.readSessionMessages(ctx, cancel, conn, workoutID, replies)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	pingTicker := time.NewTicker(sessionPingPeriod)
+	defer pingTicker.Stop()
+
+	restEndsAt := session.RestEndsAt
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case reply := <-replies:
+			if err := writeSessionEvent(conn, reply); err != nil {
+				return
+			}
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			switch event.Type {
+			case models.SessionEventRestTimerStarted:
+				restEndsAt = event.RestEndsAt
+			case models.SessionEventRestTimerStopped:
+				restEndsAt = nil
+			}
+
+			if err := writeSessionEvent(conn, newSessionEventResponse(&event)); err != nil {
+				return
+			}
+
+			if event.Type == models.SessionEventSessionFinished {
+				conn.SetWriteDeadline(time.Now().Add(sessionWriteWait))
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "session finished"))
+				return
+			}
+
+		case now := <-ticker.C:
+			if restEndsAt == nil {
+				continue
+			}
+
+			remaining := int(math.Ceil(restEndsAt.Sub(now).Seconds()))
+			if remaining <= 0 {
+				remaining = 0
+				restEndsAt = nil
+			}
+
+			tick := models.SessionEventResponse{
+				Type:             models.SessionEventRestTimerTick,
+				WorkoutID:        workoutID,
+				RemainingSeconds: &remaining,
+				SentAt:           now,
+			}
+			if err := writeSessionEvent(conn, tick); err != nil {
+				return
+			}
+
+		case <-pingTicker.C:
+			conn.SetWriteDeadline(time.Now().Add(sessionWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readSessionMessages is the only reader of the connection; replies meant for
+// this device alone are handed to the writer through the replies channel.
+func (h *WorkoutSessionHandler) readSessionMessages(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	conn *websocket.Conn,
+	workoutID int,
+	replies chan<- models.SessionEventResponse,
+) {
+	defer cancel()
+
+	conn.SetReadLimit(sessionMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(sessionPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(sessionPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Println("Live session connection closed:", err)
+			}
+			return
+		}
+
+		message := ""
+		var request models.SessionMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			log.Println("Invalid input:", err)
+			message = "Invalid input"
+		} else {
+			msgCtx, msgCancel := context.WithTimeout(ctx, 5*time.Second)
+			err = h.workoutSessionService.HandleMessage(msgCtx, workoutID, &request)
+			msgCancel()
+
+			if err != nil {
+				log.Println("Failed to handle session message:", err)
+				message = "Internal server error"
+				var appErr *apperrors.AppError
+				if errors.As(err, &appErr) {
+					message = appErr.Message
+				}
+			}
+		}
+
+		if message == "" {
+			continue
+		}
+
+		reply := models.SessionEventResponse{
+			Type:      models.SessionEventError,
+			WorkoutID: workoutID,
+			Message:   message,
+			SentAt:    time.Now(),
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func writeSessionEvent(conn *websocket.Conn, event models.SessionEventResponse) error {
+	conn.SetWriteDeadline(time.Now().Add(sessionWriteWait))
+	if err := conn.WriteJSON(event); err != nil {
+		log.Println("Failed to write session event:", err)
+		return err
+	}
+
+	return nil
+}
+
+func newSessionEventResponse(event *models.SessionEvent) models.SessionEventResponse {
+	response := models.SessionEventResponse{
+		Type:              event.Type,
+		WorkoutID:         event.WorkoutID,
+		WorkoutExerciseID: event.WorkoutExerciseID,
+		SetID:             event.SetID,
+		RestEndsAt:        event.RestEndsAt,
+		SentAt:            event.SentAt,
+	}
+
+	if event.Set != nil {
+		set := newWorkoutSetResponse(event.Set)
+		response.Set = &set
+	}
+
+	if event.Workout != nil {
+		workout := newWorkoutResponse(event.Workout)
+		response.Workout = &workout
+	}
+
+	return response
+}
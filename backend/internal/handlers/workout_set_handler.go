@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WorkoutSetHandler struct {
+	workoutSetService *services.WorkoutSetService
+}
+
+func NewWorkoutSetHandler(workoutSetService *services.WorkoutSetService) *WorkoutSetHandler {
+	return &WorkoutSetHandler{workoutSetService: workoutSetService}
+}
+
+// CreateSet godoc
+// @Summary Add set to workout exercise
+// @Description Log a single set (reps, weight, RPE, set type) for an exercise in workout
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Param workoutExerciseID path int true "Workout exercise id"
+// @Param set body models.WorkoutSetRequest true "Set data"
+// @Success 201 {object} models.WorkoutSetResponse "Set added"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Fields do not match exercise tracking type"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Exercise not found in workout"
+// @Failure 409 {object} models.ErrorResponse "Set with this number already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to add set"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/exercises/{workoutExerciseID}/sets [post]
+func (h *WorkoutSetHandler) CreateSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, workoutExerciseID, ok := parseWorkoutExerciseParams(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.WorkoutSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	set, err := h.workoutSetService.CreateSet(ctx, workoutID, workoutExerciseID, &request)
+	if err != nil {
+		log.Println("Failed to add set:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newWorkoutSetResponse(set))
+}
+
+// GetSets godoc
+// @Summary Get sets of workout exercise
+// @Description Get all sets of exercise in workout ordered by set number
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Param workoutExerciseID path int true "Workout exercise id"
+// @Success 200 {array} models.WorkoutSetResponse "Sets successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Sets not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get sets"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/exercises/{workoutExerciseID}/sets [get]
+func (h *WorkoutSetHandler) GetSets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, workoutExerciseID, ok := parseWorkoutExerciseParams(w, r)
+	if !ok {
+		return
+	}
+
+	sets, err := h.workoutSetService.GetSets(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to get sets:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutSetResponses(*sets))
+}
+
+// GetSet godoc
+// @Summary Get set
+// @Description Get set of exercise in workout by id
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Param workoutExerciseID path int true "Workout exercise id"
+// @Param setID path int true "Set id"
+// @Success 200 {object} models.WorkoutSetResponse "Set successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Set not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get set"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/exercises/{workoutExerciseID}/sets/{setID} [get]
+func (h *WorkoutSetHandler) GetSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, workoutExerciseID, ok := parseWorkoutExerciseParams(w, r)
+	if !ok {
+		return
+	}
+
+	setID, ok := parseSetID(w, r)
+	if !ok {
+		return
+	}
+
+	set, err := h.workoutSetService.GetSet(ctx, workoutID, workoutExerciseID, setID)
+	if err != nil {
+		log.Println("Failed to get set:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutSetResponse(set))
+}
+
+// UpdateSet godoc
+// @Summary Update set
+// @Description Update set of exercise in workout
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Param workoutExerciseID path int true "Workout exercise id"
+// @Param setID path int true "Set id"
+// @Param set body models.WorkoutSetRequest true "Set data"
+// @Success 200 {object} models.WorkoutSetResponse "Set updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Fields do not match exercise tracking type"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Set not found"
+// @Failure 409 {object} models.ErrorResponse "Set with this number already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to update set"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/exercises/{workoutExerciseID}/sets/{setID} [put]
+func (h *WorkoutSetHandler) UpdateSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, workoutExerciseID, ok := parseWorkoutExerciseParams(w, r)
+	if !ok {
+		return
+	}
+
+	setID, ok := parseSetID(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.WorkoutSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	set, err := h.workoutSetService.UpdateSet(ctx, workoutID, workoutExerciseID, setID, &request)
+	if err != nil {
+		log.Println("Failed to update set:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutSetResponse(set))
+}
+
+// DeleteSet godoc
+// @Summary Delete set
+// @Description Delete set of exercise in workout
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout id"
+// @Param workoutExerciseID path int true "Workout exercise id"
+// @Param setID path int true "Set id"
+// @Success 204 "Set successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Set not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete set"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /workouts/{id}/exercises/{workoutExerciseID}/sets/{setID} [delete]
+func (h *WorkoutSetHandler) DeleteSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, workoutExerciseID, ok := parseWorkoutExerciseParams(w, r)
+	if !ok {
+		return
+	}
+
+	setID, ok := parseSetID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.workoutSetService.DeleteSet(ctx, workoutID, workoutExerciseID, setID)
+	if err != nil {
+		log.Println("Failed to delete set:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseWorkoutExerciseParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	workoutExerciseID, err := strconv.Atoi(chi.URLParam(r, "workoutExerciseID"))
+	if err != nil || workoutExerciseID < 1 {
+		log.Println("Incorrect workout exercise id:", err)
+		utils.JSONError(w, "Incorrect workout exercise id", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	return id, workoutExerciseID, true
+}
+
+func parseSetID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	setID, err := strconv.Atoi(chi.URLParam(r, "setID"))
+	if err != nil || setID < 1 {
+		log.Println("Incorrect set id:", err)
+		utils.JSONError(w, "Incorrect set id", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return setID, true
+}
+
+func newWorkoutSetResponse(set *models.WorkoutSet) models.WorkoutSetResponse {
+	return models.WorkoutSetResponse{
+		ID:                set.ID,
+		WorkoutExerciseID: set.WorkoutExerciseID,
+		SetNumber:         set.SetNumber,
+		SetType:           set.SetType,
+		Reps:              set.Reps,
+		Weight:            set.Weight,
+		RPE:               set.RPE,
+		DurationSeconds:   set.DurationSeconds,
+		DistanceMeters:    set.DistanceMeters,
+		PaceSecondsPerKm:  utils.PaceSecondsPerKm(set.DurationSeconds, set.DistanceMeters),
+		HeartRateAvg:      set.HeartRateAvg,
+		CreatedAt:         set.CreatedAt,
+		NewRecords:        newPersonalRecordResponses(set.NewRecords),
+	}
+}
+
+func newWorkoutSetResponses(sets []models.WorkoutSet) []models.WorkoutSetResponse {
+	var response []models.WorkoutSetResponse
+	for i := range sets {
+		response = append(response, newWorkoutSetResponse(&sets[i]))
+	}
+
+	return response
+}
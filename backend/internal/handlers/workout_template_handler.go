@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WorkoutTemplateHandler struct {
+	workoutTemplateService *services.WorkoutTemplateService
+}
+
+func NewWorkoutTemplateHandler(workoutTemplateService *services.WorkoutTemplateService) *WorkoutTemplateHandler {
+	return &WorkoutTemplateHandler{workoutTemplateService: workoutTemplateService}
+}
+
+// CreateTemplate godoc
+// @Summary Create workout template
+// @Description Save ordered list of exercises with target sets, reps and weight as reusable template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body models.WorkoutTemplateRequest true "Template data"
+// @Success 201 {object} models.WorkoutTemplateResponse "Template created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 409 {object} models.ErrorResponse "Template with this name already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to create template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates [post]
+func (h *WorkoutTemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var request models.WorkoutTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.workoutTemplateService.CreateTemplate(ctx, &request)
+	if err != nil {
+		log.Println("Failed to create template:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newWorkoutTemplateResponse(template))
+}
+
+// CreateTemplateFromWorkout godoc
+// @Summary Save workout as template
+// @Description Create template from exercises of existing workout
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param workoutID path int true "Workout id"
+// @Param template body models.TemplateFromWorkoutRequest true "Template data"
+// @Success 201 {object} models.WorkoutTemplateResponse "Template created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Workout not found"
+// @Failure 409 {object} models.ErrorResponse "Template with this name already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to create template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates/from-workout/{workoutID} [post]
+func (h *WorkoutTemplateHandler) CreateTemplateFromWorkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "workoutID"))
+	if err != nil || workoutID < 1 {
+		log.Println("Incorrect workout id:", err)
+		utils.JSONError(w, "Incorrect workout id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.TemplateFromWorkoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.workoutTemplateService.CreateTemplateFromWorkout(ctx, workoutID, &request)
+	if err != nil {
+		log.Println("Failed to create template from workout:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newWorkoutTemplateResponse(template))
+}
+
+// GetTemplates godoc
+// @Summary Get workout templates
+// @Description Get workout templates of current user
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.WorkoutTemplateResponse "Templates successfully got"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Templates not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get templates"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates [get]
+func (h *WorkoutTemplateHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	templates, err := h.workoutTemplateService.GetTemplates(ctx)
+	if err != nil {
+		log.Println("Failed to get templates:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var response []models.WorkoutTemplateResponse
+	for i := range *templates {
+		response = append(response, newWorkoutTemplateResponse(&(*templates)[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTemplate godoc
+// @Summary Get workout template
+// @Description Get workout template with exercises by id
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template id"
+// @Success 200 {object} models.WorkoutTemplateResponse "Template successfully got"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Template not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to get template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates/{id} [get]
+func (h *WorkoutTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.workoutTemplateService.GetTemplate(ctx, id)
+	if err != nil {
+		log.Println("Failed to get template:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutTemplateResponse(template))
+}
+
+// UpdateTemplate godoc
+// @Summary Update workout template
+// @Description Update template name, notes and replace its exercises
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template id"
+// @Param template body models.WorkoutTemplateRequest true "Template data"
+// @Success 200 {object} models.WorkoutTemplateResponse "Template updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Template not found"
+// @Failure 409 {object} models.ErrorResponse "Template with this name already exists"
+// @Failure 500 {object} models.ErrorResponse "Failed to update template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates/{id} [put]
+func (h *WorkoutTemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	var request models.WorkoutTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Invalid input:", err)
+		utils.JSONError(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.workoutTemplateService.UpdateTemplate(ctx, id, &request)
+	if err != nil {
+		log.Println("Failed to update template:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newWorkoutTemplateResponse(template))
+}
+
+// DeleteTemplate godoc
+// @Summary Delete workout template
+// @Description Delete workout template by id
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template id"
+// @Success 204 "Template successfully deleted"
+// @Failure 400 {object} models.ErrorResponse "Invalid id"
+// @Failure 400 {object} models.ErrorResponse "Request cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Template not found"
+// @Failure 500 {object} models.ErrorResponse "Failed to delete template"
+// @Failure 504 {object} models.ErrorResponse "Request timeout"
+// @Router /templates/{id} [delete]
+func (h *WorkoutTemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		log.Println("Incorrect id:", err)
+		utils.JSONError(w, "Incorrect id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.workoutTemplateService.DeleteTemplate(ctx, id); err != nil {
+		log.Println("Failed to delete template:", err)
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONError(w, appErr.Message, appErr.Code)
+			return
+		}
+		utils.JSONError(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newWorkoutTemplateResponse(template *models.WorkoutTemplate) models.WorkoutTemplateResponse {
+	response := models.WorkoutTemplateResponse{
+		ID:        template.ID,
+		UserID:    template.UserID,
+		Name:      template.Name,
+		Notes:     template.Notes,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+
+	for _, exercise := range template.Exercises {
+		response.Exercises = append(response.Exercises, models.WorkoutTemplateExerciseResponse{
+			ID:           exercise.ID,
+			ExerciseID:   exercise.ExerciseID,
+			Position:     exercise.Position,
+			TargetSets:   exercise.TargetSets,
+			TargetReps:   exercise.TargetReps,
+			TargetWeight: exercise.TargetWeight,
+			Notes:        exercise.Notes,
+			Exercise:     exercise.Exercise,
+		})
+	}
+
+	return response
+}
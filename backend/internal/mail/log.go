@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogSender logs the recipient and subject of messages instead of sending
+// them, the body with its links is never logged. When dir is set every
+// message is also written there as a .eml file.
+type LogSender struct {
+	dir string
+}
+
+func NewLogSender(dir string) *LogSender {
+	return &LogSender{dir: dir}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	log.Printf("Email to %s: %s", msg.To, msg.Subject)
+
+	if s.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail dir: %w", err)
+	}
+
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	if err := os.WriteFile(filepath.Join(s.dir, name), buildMessage("noreply@localhost", msg), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
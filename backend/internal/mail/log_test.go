@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"backend/internal/config"
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogSender_WritesEmlFile(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewMailer(NewLogSender(dir), "http://localhost:5173")
+
+	if err := mailer.SendPasswordReset(context.Background(), "bob@example.com", "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(files))
+	}
+
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "http://localhost:5173/reset-password?token=abc") {
+		t.Errorf("expected reset link in email, got %q", data)
+	}
+}
+
+func TestLogSender_DoesNotLogBody(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	mailer := NewMailer(NewLogSender(""), "http://localhost:5173")
+	if err := mailer.SendPasswordReset(context.Background(), "bob@example.com", "secret-token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "bob@example.com") {
+		t.Errorf("expected recipient in log, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Errorf("expected no token in log, got %q", buf.String())
+	}
+}
+
+func TestInitMailer(t *testing.T) {
+	tests := []struct {
+		name    string
+		envs    config.Envs
+		wantErr bool
+	}{
+		{"smtp without host", config.Envs{}, true},
+		{"smtp", config.Envs{SMTPHost: "smtp.example.com", SMTPPort: "587"}, false},
+		{"log", config.Envs{MailDriver: MailDriverLog}, false},
+		{"unknown", config.Envs{MailDriver: "sendmail"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InitMailer(&tt.envs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InitMailer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
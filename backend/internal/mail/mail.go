@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"backend/internal/config"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers a single message. SMTPSender is used in production and
+// LogSender for local development and tests.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Mailer builds the emails the API sends and passes them to the sender.
+type Mailer struct {
+	sender      Sender
+	frontendUrl string
+}
+
+func NewMailer(sender Sender, frontendUrl string) *Mailer {
+	return &Mailer{
+		sender:      sender,
+		frontendUrl: frontendUrl,
+	}
+}
+
+const (
+	MailDriverSMTP = "smtp"
+	MailDriverLog  = "log"
+)
+
+// InitMailer selects the sender by MAIL_DRIVER, SMTP is used when it is not
+// set. LogSender has to be chosen explicitly, so a missing SMTP_HOST stops
+// the start instead of silently not sending emails.
+func InitMailer(envs *config.Envs) (*Mailer, error) {
+	switch envs.MailDriver {
+	case "", MailDriverSMTP:
+		if envs.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required, set MAIL_DRIVER=%s to log emails instead", MailDriverLog)
+		}
+		return NewMailer(NewSMTPSender(envs), envs.FrontendUrl), nil
+	case MailDriverLog:
+		return NewMailer(NewLogSender(envs.MailDir), envs.FrontendUrl), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", envs.MailDriver)
+	}
+}
+
+func (m *Mailer) SendEmailVerification(ctx context.Context, to, token string) error {
+	link := m.link("/verify-email", token)
+
+	return m.sender.Send(ctx, &Message{
+		To:      to,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Welcome to Online Workout Tracker!\n\n"+
+			"Confirm your email by opening the link below:\n%s\n\n"+
+			"The link expires in 24 hours.\n", link),
+	})
+}
+
+func (m *Mailer) SendPasswordReset(ctx context.Context, to, token string) error {
+	link := m.link("/reset-password", token)
+
+	return m.sender.Send(ctx, &Message{
+		To:      to,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Somebody requested a password reset for your account.\n\n"+
+			"Set a new password by opening the link below:\n%s\n\n"+
+			"The link expires in 1 hour. If it was not you, ignore this email.\n", link),
+	})
+}
+
+func (m *Mailer) link(path, token string) string {
+	return m.frontendUrl + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+type ProgressionFilter struct {
+	From    *time.Time
+	To      *time.Time
+	Formula string
+	Bucket  string
+}
+
+type ProgressionSet struct {
+	WorkoutID   int       `json:"workout_id"`
+	WorkoutDate time.Time `json:"workout_date"`
+	Weight      float64   `json:"weight"`
+	Reps        int       `json:"reps"`
+	Sets        int       `json:"sets"`
+}
+
+type ProgressionPoint struct {
+	Date               time.Time `json:"date"`
+	Workouts           int       `json:"workouts"`
+	EstimatedOneRepMax float64   `json:"estimated_1rm"`
+	TopSetWeight       float64   `json:"top_set_weight"`
+	TopSetReps         int       `json:"top_set_reps"`
+	TotalVolume        float64   `json:"total_volume"`
+}
+
+type ProgressionResponse struct {
+	ExerciseID int                `json:"exercise_id"`
+	Formula    string             `json:"formula"`
+	Bucket     string             `json:"bucket"`
+	Points     []ProgressionPoint `json:"points"`
+}
+
+type DateRangeFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+type MuscleVolumeRow struct {
+	WeekStart       time.Time `json:"week_start"`
+	MuscleGroupID   int       `json:"muscle_group_id"`
+	MuscleGroupName string    `json:"muscle_group_name"`
+	IsPrimary       bool      `json:"is_primary"`
+	Sets            int       `json:"sets"`
+	Tonnage         float64   `json:"tonnage"`
+}
+
+type MuscleGroupVolume struct {
+	MuscleGroupID    int     `json:"muscle_group_id"`
+	MuscleGroupName  string  `json:"muscle_group_name"`
+	Sets             int     `json:"sets"`
+	SecondarySets    int     `json:"secondary_sets"`
+	Tonnage          float64 `json:"tonnage"`
+	SecondaryTonnage float64 `json:"secondary_tonnage"`
+}
+
+type MuscleVolumeWeek struct {
+	WeekStart    time.Time           `json:"week_start"`
+	MuscleGroups []MuscleGroupVolume `json:"muscle_groups"`
+}
+
+type MuscleVolumeResponse struct {
+	From  time.Time          `json:"from"`
+	To    time.Time          `json:"to"`
+	Weeks []MuscleVolumeWeek `json:"weeks"`
+}
+
+type TrainingTimeWeek struct {
+	WeekStart      time.Time `json:"week_start"`
+	Workouts       int       `json:"workouts"`
+	TotalSeconds   int       `json:"total_seconds"`
+	AverageSeconds int       `json:"average_seconds"`
+}
+
+type TrainingTimeResponse struct {
+	UserID       int                `json:"user_id"`
+	From         time.Time          `json:"from"`
+	To           time.Time          `json:"to"`
+	TotalSeconds int                `json:"total_seconds"`
+	Weeks        []TrainingTimeWeek `json:"weeks"`
+}
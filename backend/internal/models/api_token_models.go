@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// API token scopes, "<resource>:read" allows GET requests to the resource
+// and "<resource>:write" all other methods.
+const (
+	ScopeWorkoutsRead   = "workouts:read"
+	ScopeWorkoutsWrite  = "workouts:write"
+	ScopeTemplatesRead  = "templates:read"
+	ScopeTemplatesWrite = "templates:write"
+	ScopeProgramsRead   = "programs:read"
+	ScopeExercisesRead  = "exercises:read"
+	ScopeExercisesWrite = "exercises:write"
+	ScopeAnalyticsRead  = "analytics:read"
+	ScopeRecordsRead    = "records:read"
+	ScopeFoodsRead      = "foods:read"
+	ScopeFoodsWrite     = "foods:write"
+)
+
+var APITokenScopes = []string{
+	ScopeWorkoutsRead,
+	ScopeWorkoutsWrite,
+	ScopeTemplatesRead,
+	ScopeTemplatesWrite,
+	ScopeProgramsRead,
+	ScopeExercisesRead,
+	ScopeExercisesWrite,
+	ScopeAnalyticsRead,
+	ScopeRecordsRead,
+	ScopeFoodsRead,
+	ScopeFoodsWrite,
+}
+
+// APIToken is a personal access token for scripts, only its hash is stored.
+type APIToken struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	TokenHash   string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type APITokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+type APITokenResponse struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatedAPITokenResponse is the only response with the token itself.
+type CreatedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
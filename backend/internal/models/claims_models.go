@@ -3,6 +3,8 @@ package models
 import "github.com/golang-jwt/jwt/v5"
 
 type Claims struct {
-	UserID int `json:"user_id"`
+	UserID       int `json:"user_id"`
+	SessionID    int `json:"sid,omitempty"`
+	TokenVersion int `json:"ver"`
 	jwt.RegisteredClaims
 }
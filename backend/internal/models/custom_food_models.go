@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	FoodSourceCustom   = "custom"
+	FoodSourceProvider = "provider"
+)
+
+type Nutrients struct {
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Carbs    float64 `json:"carbohydrate"`
+	Fat      float64 `json:"fat"`
+}
+
+// CustomFood is a food from the personal library of a user. At least one of
+// Per100g and PerServing is set, both are set when the serving weight is
+// known.
+type CustomFood struct {
+	ID                 int        `json:"id"`
+	UserID             int        `json:"user_id"`
+	Name               string     `json:"name"`
+	Brand              string     `json:"brand"`
+	Barcode            string     `json:"barcode"`
+	ServingQty         float64    `json:"serving_qty"`
+	ServingUnit        string     `json:"serving_unit"`
+	ServingWeightGrams *float64   `json:"serving_weight_grams"`
+	Per100g            *Nutrients `json:"per_100g"`
+	PerServing         *Nutrients `json:"per_serving"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	IsActive           bool       `json:"is_active"`
+}
+
+type CustomFoodRequest struct {
+	Name               string     `json:"name"`
+	Brand              string     `json:"brand"`
+	Barcode            string     `json:"barcode"`
+	ServingQty         float64    `json:"serving_qty"`
+	ServingUnit        string     `json:"serving_unit"`
+	ServingWeightGrams *float64   `json:"serving_weight_grams"`
+	Per100g            *Nutrients `json:"per_100g"`
+	PerServing         *Nutrients `json:"per_serving"`
+}
+
+// FoodSearchItem is a search result, CustomFoodID is set for foods from the
+// personal library and ID for foods of the nutrition provider.
+type FoodSearchItem struct {
+	Source       string `json:"source"`
+	CustomFoodID int    `json:"custom_food_id,omitempty"`
+	NutritionFood
+}
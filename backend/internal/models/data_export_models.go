@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+)
+
+type DataExport struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	Status      string     `json:"status"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type DataExportResponse struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// The rows below are the files of the export archive.
+
+type ExportProfile struct {
+	ID               int        `json:"id"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type ExportWorkoutExercise struct {
+	ID              int        `json:"id"`
+	WorkoutID       int        `json:"workout_id"`
+	ExerciseID      int        `json:"exercise_id"`
+	ExerciseName    string     `json:"exercise_name"`
+	DurationSeconds *int       `json:"duration_seconds"`
+	DistanceMeters  *float64   `json:"distance_meters"`
+	HeartRateAvg    *int       `json:"heart_rate_avg"`
+	StartedAt       *time.Time `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	Notes           string     `json:"notes"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ExportWorkoutSet struct {
+	ID                int       `json:"id"`
+	WorkoutExerciseID int       `json:"workout_exercise_id"`
+	SetNumber         int       `json:"set_number"`
+	SetType           string    `json:"set_type"`
+	Reps              *int      `json:"reps"`
+	Weight            float64   `json:"weight"`
+	RPE               *float64  `json:"rpe"`
+	DurationSeconds   *int      `json:"duration_seconds"`
+	DistanceMeters    *float64  `json:"distance_meters"`
+	HeartRateAvg      *int      `json:"heart_rate_avg"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ExportFood struct {
+	ID          int       `json:"id"`
+	Date        time.Time `json:"date"`
+	MealType    string    `json:"meal_type"`
+	MealName    string    `json:"meal_name"`
+	EatenAt     *string   `json:"time"`
+	Name        string    `json:"name"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit"`
+	WeightGrams float64   `json:"weight_grams"`
+	Calories    float64   `json:"calories"`
+	Protein     float64   `json:"protein"`
+	Carbs       float64   `json:"carbohydrate"`
+	Fat         float64   `json:"fat"`
+	IsActive    bool      `json:"is_active"`
+}
+
+// ExportIntegration describes a connected service, its credentials are
+// never exported.
+type ExportIntegration struct {
+	Provider    string    `json:"provider"`
+	ConnectedAt time.Time `json:"connected_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
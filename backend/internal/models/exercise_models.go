@@ -2,29 +2,48 @@ package models
 
 import "time"
 
+const (
+	TrackingTypeWeightReps       = "weight_reps"
+	TrackingTypeRepsOnly         = "reps_only"
+	TrackingTypeDuration         = "duration"
+	TrackingTypeDistanceDuration = "distance_duration"
+	TrackingTypeBodyweightPlus   = "bodyweight_plus"
+)
+
 type Exercise struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CategoryID  int       `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	IsActive    bool      `json:"is_active"`
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	CategoryID   int       `json:"category_id"`
+	TrackingType string    `json:"tracking_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	IsActive     bool      `json:"is_active"`
+
+	PrimaryMuscles   []MuscleGroup `json:"primary_muscles"`
+	SecondaryMuscles []MuscleGroup `json:"secondary_muscles"`
 }
 
 type ExerciseRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	CategoryID  int    `json:"category_id"`
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	CategoryID              int    `json:"category_id"`
+	TrackingType            string `json:"tracking_type"`
+	PrimaryMuscleGroupIDs   []int  `json:"primary_muscle_group_ids"`
+	SecondaryMuscleGroupIDs []int  `json:"secondary_muscle_group_ids"`
 }
 
 type ExerciseResponse struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CategoryID  int       `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	CategoryID   int       `json:"category_id"`
+	TrackingType string    `json:"tracking_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	PrimaryMuscles   []MuscleGroup `json:"primary_muscles"`
+	SecondaryMuscles []MuscleGroup `json:"secondary_muscles"`
 }
 
 type ExerciseFilter struct {
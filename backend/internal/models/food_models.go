@@ -2,21 +2,61 @@ package models
 
 import "time"
 
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+	MealSnack     = "snack"
+	MealCustom    = "custom"
+	// MealUnspecified marks entries logged before meals were introduced.
+	MealUnspecified = "unspecified"
+)
+
 type NutritionixFood struct {
-	FoodName           string  `json:"food_name"`
-	ServingQty         float64 `json:"serving_qty"`
-	ServingUint        string  `json:"serving_unit"`
-	ServingWeightGrams float64 `json:"serving_weight_grams"`
-	Calories           float64 `json:"nf_calories"`
-	Protein            float64 `json:"nf_protein"`
-	Carbs              float64 `json:"nf_total_carbohydrate"`
-	Fat                float64 `json:"nf_total_fat"`
+	NixItemID          string                `json:"nix_item_id"`
+	FoodName           string                `json:"food_name"`
+	BrandName          string                `json:"brand_name"`
+	ServingQty         float64               `json:"serving_qty"`
+	ServingUint        string                `json:"serving_unit"`
+	ServingWeightGrams float64               `json:"serving_weight_grams"`
+	Calories           float64               `json:"nf_calories"`
+	Protein            float64               `json:"nf_protein"`
+	Carbs              float64               `json:"nf_total_carbohydrate"`
+	Fat                float64               `json:"nf_total_fat"`
+	FullNutrients      []NutritionixNutrient `json:"full_nutrients"`
+}
+
+type NutritionixNutrient struct {
+	AttrID int     `json:"attr_id"`
+	Value  float64 `json:"value"`
 }
 
 type NutritionixResponse struct {
 	Foods []NutritionixFood `json:"foods"`
 }
 
+type NutritionixSearchResponse struct {
+	Common  []NutritionixFood `json:"common"`
+	Branded []NutritionixFood `json:"branded"`
+}
+
+// NutritionFood is a food returned by a nutrition provider. The nutrients
+// are for ServingQty ServingUnit weighing ServingWeightGrams, the weight is
+// 0 when the provider does not know it.
+type NutritionFood struct {
+	ID                 string  `json:"id,omitempty"`
+	Name               string  `json:"name"`
+	Brand              string  `json:"brand,omitempty"`
+	Barcode            string  `json:"barcode,omitempty"`
+	ServingQty         float64 `json:"serving_qty"`
+	ServingUnit        string  `json:"serving_unit"`
+	ServingWeightGrams float64 `json:"serving_weight_grams"`
+	Calories           float64 `json:"calories"`
+	Protein            float64 `json:"protein"`
+	Carbs              float64 `json:"carbohydrate"`
+	Fat                float64 `json:"fat"`
+}
+
 type Food struct {
 	ID          int       `json:"id"`
 	UserID      int       `json:"user_id"`
@@ -29,17 +69,44 @@ type Food struct {
 	Protein     float64   `json:"protein"`
 	Carbs       float64   `json:"carbohydrate"`
 	Fat         float64   `json:"fat"`
+	MealType    string    `json:"meal_type"`
+	MealName    string    `json:"meal_name"`
+	EatenAt     *string   `json:"time"`
 }
 
+// FoodRequestItem is looked up by the nutrition provider by ProductName, or
+// in the personal library when CustomFoodID or RecipeID is set. The unit of a
+// custom food is g, kg, ml or its serving unit, the quantity of a recipe is in
+// servings. Entries are logged in the order of the items.
 type FoodRequestItem struct {
-	ProductName string  `json:"product_name"`
-	Quantity    float64 `json:"quantity"`
-	Unit        string  `json:"unit"`
+	ProductName  string  `json:"product_name"`
+	CustomFoodID int     `json:"custom_food_id,omitempty"`
+	RecipeID     int     `json:"recipe_id,omitempty"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
 }
 
+// FoodRequest logs all items into one meal. MealType defaults to snack,
+// MealName is required for custom meals and Time is HH:MM.
 type FoodRequest struct {
-	Date  string            `json:"date"`
-	Items []FoodRequestItem `json:"items"`
+	Date     string            `json:"date"`
+	MealType string            `json:"meal_type"`
+	MealName string            `json:"meal_name"`
+	Time     string            `json:"time"`
+	Items    []FoodRequestItem `json:"items"`
+}
+
+// CopyMealRequest copies the meal of FromDate to ToDate, MealName is only
+// used for custom meals.
+type CopyMealRequest struct {
+	FromDate string `json:"from_date"`
+	ToDate   string `json:"to_date"`
+	MealType string `json:"meal_type"`
+	MealName string `json:"meal_name"`
+}
+
+type UpdateFoodRequest struct {
+	Quantity float64 `json:"quantity"`
 }
 
 type FoodResponseItem struct {
@@ -52,6 +119,9 @@ type FoodResponseItem struct {
 	Protein     float64 `json:"protein"`
 	Carbs       float64 `json:"carbohydrate"`
 	Fat         float64 `json:"fat"`
+	MealType    string  `json:"meal_type"`
+	MealName    string  `json:"meal_name,omitempty"`
+	Time        *string `json:"time"`
 }
 
 type FoodResponse struct {
@@ -59,3 +129,33 @@ type FoodResponse struct {
 	Date   time.Time          `json:"date"`
 	Items  []FoodResponseItem `json:"items"`
 }
+
+// Meal groups the entries of a day eaten as one meal, Total is the sum of
+// their nutrients.
+type Meal struct {
+	MealType string
+	MealName string
+	Foods    []Food
+	Total    Nutrients
+}
+
+type FoodDay struct {
+	UserID int
+	Date   time.Time
+	Meals  []Meal
+	Total  Nutrients
+}
+
+type MealResponse struct {
+	MealType string             `json:"meal_type"`
+	MealName string             `json:"meal_name,omitempty"`
+	Items    []FoodResponseItem `json:"items"`
+	Total    Nutrients          `json:"total"`
+}
+
+type FoodDayResponse struct {
+	UserID int            `json:"user_id"`
+	Date   time.Time      `json:"date"`
+	Meals  []MealResponse `json:"meals"`
+	Total  Nutrients      `json:"total"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type MuscleGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ExerciseMuscleGroup struct {
+	ExerciseID  int         `json:"exercise_id"`
+	MuscleGroup MuscleGroup `json:"muscle_group"`
+	IsPrimary   bool        `json:"is_primary"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	RecordTypeMaxWeight    = "max_weight"
+	RecordTypeRepsAtWeight = "reps_at_weight"
+	RecordTypeEstimated1RM = "estimated_1rm"
+	RecordTypeMaxVolume    = "max_volume"
+)
+
+type PersonalRecord struct {
+	ID                int       `json:"id"`
+	UserID            int       `json:"user_id"`
+	ExerciseID        int       `json:"exercise_id"`
+	ExerciseName      string    `json:"exercise_name"`
+	RecordType        string    `json:"record_type"`
+	Value             float64   `json:"value"`
+	Weight            float64   `json:"weight"`
+	Reps              int       `json:"reps"`
+	WorkoutID         int       `json:"workout_id"`
+	WorkoutExerciseID int       `json:"workout_exercise_id"`
+	AchievedAt        time.Time `json:"achieved_at"`
+	IsCurrent         bool      `json:"is_current"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type RecordSet struct {
+	WorkoutID         int       `json:"workout_id"`
+	WorkoutDate       time.Time `json:"workout_date"`
+	WorkoutExerciseID int       `json:"workout_exercise_id"`
+	SetID             int       `json:"set_id"`
+	Reps              int       `json:"reps"`
+	Weight            float64   `json:"weight"`
+}
+
+type PersonalRecordResponse struct {
+	ID                int       `json:"id"`
+	ExerciseID        int       `json:"exercise_id"`
+	ExerciseName      string    `json:"exercise_name,omitempty"`
+	RecordType        string    `json:"record_type"`
+	Value             float64   `json:"value"`
+	Weight            float64   `json:"weight"`
+	Reps              int       `json:"reps"`
+	WorkoutID         int       `json:"workout_id"`
+	WorkoutExerciseID int       `json:"workout_exercise_id"`
+	AchievedAt        time.Time `json:"achieved_at"`
+	IsCurrent         bool      `json:"is_current"`
+}
+
+type ExerciseRecordsResponse struct {
+	ExerciseID int                      `json:"exercise_id"`
+	Current    []PersonalRecordResponse `json:"current"`
+	History    []PersonalRecordResponse `json:"history"`
+}
@@ -0,0 +1,154 @@
+package models
+
+import "time"
+
+const (
+	TrainerClientPending  = "pending"
+	TrainerClientAccepted = "accepted"
+)
+
+// TrainerClient is an invitation of the trainer until the client accepts it,
+// only accepted clients can get programs and share their data.
+type TrainerClient struct {
+	ID         int        `json:"id"`
+	TrainerID  int        `json:"trainer_id"`
+	ClientID   int        `json:"client_id"`
+	Username   string     `json:"username"`
+	Email      string     `json:"email"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	IsActive   bool       `json:"is_active"`
+}
+
+func (c *TrainerClient) Status() string {
+	if c.AcceptedAt == nil {
+		return TrainerClientPending
+	}
+	return TrainerClientAccepted
+}
+
+type TrainerClientRequest struct {
+	Email string `json:"email"`
+}
+
+type TrainerClientResponse struct {
+	ID         int        `json:"id"`
+	ClientID   int        `json:"client_id"`
+	Username   string     `json:"username"`
+	Email      string     `json:"email"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+type TrainerInvitation struct {
+	ID              int       `json:"id"`
+	TrainerID       int       `json:"trainer_id"`
+	TrainerUsername string    `json:"trainer_username"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type Program struct {
+	ID          int              `json:"id"`
+	TrainerID   int              `json:"trainer_id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Weeks       int              `json:"weeks"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	IsActive    bool             `json:"is_active"`
+	Workouts    []ProgramWorkout `json:"workouts,omitempty"`
+}
+
+type ProgramWorkout struct {
+	ID           int    `json:"id"`
+	ProgramID    int    `json:"program_id"`
+	TemplateID   int    `json:"template_id"`
+	TemplateName string `json:"template_name"`
+	Week         int    `json:"week"`
+	Day          int    `json:"day"`
+	Notes        string `json:"notes"`
+}
+
+type ProgramRequest struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Weeks       int                     `json:"weeks"`
+	Workouts    []ProgramWorkoutRequest `json:"workouts"`
+}
+
+type ProgramWorkoutRequest struct {
+	TemplateID int    `json:"template_id"`
+	Week       int    `json:"week"`
+	Day        int    `json:"day"`
+	Notes      string `json:"notes"`
+}
+
+type ProgramResponse struct {
+	ID          int                      `json:"id"`
+	TrainerID   int                      `json:"trainer_id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Weeks       int                      `json:"weeks"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Workouts    []ProgramWorkoutResponse `json:"workouts,omitempty"`
+}
+
+type ProgramWorkoutResponse struct {
+	ID           int    `json:"id"`
+	TemplateID   int    `json:"template_id"`
+	TemplateName string `json:"template_name"`
+	Week         int    `json:"week"`
+	Day          int    `json:"day"`
+	Notes        string `json:"notes"`
+}
+
+type ProgramAssignment struct {
+	ID                int                `json:"id"`
+	ProgramID         int                `json:"program_id"`
+	ProgramName       string             `json:"program_name"`
+	TrainerID         int                `json:"trainer_id"`
+	TrainerName       string             `json:"trainer_name"`
+	ClientID          int                `json:"client_id"`
+	StartDate         time.Time          `json:"start_date"`
+	CreatedAt         time.Time          `json:"created_at"`
+	IsActive          bool               `json:"is_active"`
+	ScheduledWorkouts []ScheduledWorkout `json:"scheduled_workouts,omitempty"`
+}
+
+type ProgramAssignmentRequest struct {
+	ClientID  int       `json:"client_id"`
+	StartDate time.Time `json:"start_date"`
+}
+
+type ProgramAssignmentResponse struct {
+	ID                int                        `json:"id"`
+	ProgramID         int                        `json:"program_id"`
+	ProgramName       string                     `json:"program_name"`
+	TrainerID         int                        `json:"trainer_id"`
+	TrainerName       string                     `json:"trainer_name"`
+	ClientID          int                        `json:"client_id"`
+	StartDate         time.Time                  `json:"start_date"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	ScheduledWorkouts []ScheduledWorkoutResponse `json:"scheduled_workouts,omitempty"`
+}
+
+type ScheduledWorkout struct {
+	ID            int       `json:"id"`
+	AssignmentID  int       `json:"assignment_id"`
+	WorkoutID     int       `json:"workout_id"`
+	Week          int       `json:"week"`
+	Day           int       `json:"day"`
+	ScheduledDate time.Time `json:"scheduled_date"`
+	Notes         string    `json:"notes"`
+}
+
+type ScheduledWorkoutResponse struct {
+	ID            int       `json:"id"`
+	WorkoutID     int       `json:"workout_id"`
+	Week          int       `json:"week"`
+	Day           int       `json:"day"`
+	ScheduledDate time.Time `json:"scheduled_date"`
+	Notes         string    `json:"notes"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Recipe keeps the nutrients of each ingredient as they were when the recipe
+// was saved, Total is their sum and PerServing is Total divided by Servings.
+type Recipe struct {
+	ID               int                `json:"id"`
+	UserID           int                `json:"user_id"`
+	Name             string             `json:"name"`
+	Servings         float64            `json:"servings"`
+	Notes            string             `json:"notes"`
+	TotalWeightGrams float64            `json:"total_weight_grams"`
+	Total            Nutrients          `json:"total"`
+	PerServing       Nutrients          `json:"per_serving"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	IsActive         bool               `json:"is_active"`
+	Ingredients      []RecipeIngredient `json:"ingredients,omitempty"`
+}
+
+type RecipeIngredient struct {
+	ID             int     `json:"id"`
+	RecipeID       int     `json:"recipe_id"`
+	Position       int     `json:"position"`
+	CustomFoodID   *int    `json:"custom_food_id"`
+	ProviderFoodID string  `json:"provider_food_id"`
+	Name           string  `json:"name"`
+	WeightGrams    float64 `json:"weight_grams"`
+	Nutrients
+}
+
+type RecipeRequest struct {
+	Name        string                    `json:"name"`
+	Servings    float64                   `json:"servings"`
+	Notes       string                    `json:"notes"`
+	Ingredients []RecipeIngredientRequest `json:"ingredients"`
+}
+
+// RecipeIngredientRequest refers to a food of the personal library by
+// CustomFoodID, to a food of the nutrition provider by ProviderFoodID, or is
+// recognized by the provider by ProductName.
+type RecipeIngredientRequest struct {
+	CustomFoodID   int     `json:"custom_food_id,omitempty"`
+	ProviderFoodID string  `json:"provider_food_id,omitempty"`
+	ProductName    string  `json:"product_name,omitempty"`
+	WeightGrams    float64 `json:"weight_grams"`
+}
@@ -1,7 +1,23 @@
 package models
 
 type Role struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type Permission struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
+
+type RoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
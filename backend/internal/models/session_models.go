@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// UserSession is a refresh token family, one per logged in device.
+type UserSession struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type RefreshToken struct {
+	ID        int        `json:"id"`
+	SessionID int        `json:"session_id"`
+	UserID    int        `json:"user_id"`
+	UsedAt    *time.Time `json:"used_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type SessionResponse struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// TwoFactor is the TOTP state of a user. The secret is set on setup and
+// 2FA is on only after the first code was confirmed.
+type TwoFactor struct {
+	UserID    int        `json:"user_id"`
+	Secret    *string    `json:"-"`
+	EnabledAt *time.Time `json:"enabled_at"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// LoginChallenge is returned by login instead of the auth cookies when the
+// user has 2FA enabled.
+type LoginChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	Challenge         string    `json:"challenge"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// TwoFactorLoginRequest completes the login, the code is either a TOTP code
+// or a recovery code.
+type TwoFactorLoginRequest struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
@@ -3,14 +3,17 @@ package models
 import "time"
 
 type User struct {
-	ID           int       `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	IsActive     bool      `json:"is_active"`
-	Roles        []Role    `json:"roles,omitempty"`
+	ID                    int        `json:"id"`
+	Username              string     `json:"username"`
+	Email                 string     `json:"email"`
+	PasswordHash          string     `json:"password_hash"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+	IsActive              bool       `json:"is_active"`
+	TokenVersion          int        `json:"token_version"`
+	EmailVerifiedAt       *time.Time `json:"email_verified_at"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	Roles                 []Role     `json:"roles,omitempty"`
 }
 
 type UserAuthRequest struct {
@@ -24,6 +27,27 @@ type UserRegisterRequest struct {
 	Email    string `json:"email"`
 }
 
+// UpdateProfileRequest changes only the fields that are set.
+// UpdateProfileRequest needs CurrentPassword when the email is changed.
+type UpdateProfileRequest struct {
+	Username        *string `json:"username,omitempty"`
+	Email           *string `json:"email,omitempty"`
+	CurrentPassword string  `json:"current_password,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+type DeleteAccountResponse struct {
+	PurgeAt time.Time `json:"purge_at"`
+}
+
 type UserResponse struct {
 	ID        int       `json:"id"`
 	Username  string    `json:"username"`
@@ -34,3 +58,38 @@ type UserResponse struct {
 type AddRoleToUserRequest struct {
 	RoleID int `json:"role_id"`
 }
+
+type UserFilter struct {
+	Search   *string
+	IsActive *bool
+	Page     int
+	Limit    int
+	Offset   int
+}
+
+type LastLogin struct {
+	At        time.Time `json:"at"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+}
+
+// AdminUserResponse is the user as seen in user management, the last login
+// is the start of the latest session.
+type AdminUserResponse struct {
+	ID                    int        `json:"id"`
+	Username              string     `json:"username"`
+	Email                 string     `json:"email"`
+	IsActive              bool       `json:"is_active"`
+	EmailVerifiedAt       *time.Time `json:"email_verified_at"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	Roles                 []string   `json:"roles"`
+	LastLogin             *LastLogin `json:"last_login"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+type AdminUserListResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	Total int                 `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
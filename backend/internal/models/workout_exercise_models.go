@@ -3,39 +3,61 @@ package models
 import "time"
 
 type WorkoutExercise struct {
-	ID         int                  `json:"id"`
-	WorkoutID  int                  `json:"workout_id"`
-	ExerciseID int                  `json:"exercise_id"`
-	Sets       int                  `json:"sets"`
-	Reps       int                  `json:"reps"`
-	Weight     float64              `json:"weight"`
-	Notes      string               `json:"notes"`
-	CreatedAt  time.Time            `json:"created_at"`
-	Exercise   *WorkoutExerciseItem `json:"exercise,omitempty"`
+	ID              int                  `json:"id"`
+	WorkoutID       int                  `json:"workout_id"`
+	ExerciseID      int                  `json:"exercise_id"`
+	Sets            int                  `json:"sets"`
+	Reps            int                  `json:"reps"`
+	Weight          float64              `json:"weight"`
+	DurationSeconds *int                 `json:"duration_seconds,omitempty"`
+	DistanceMeters  *float64             `json:"distance_meters,omitempty"`
+	HeartRateAvg    *int                 `json:"heart_rate_avg,omitempty"`
+	StartedAt       *time.Time           `json:"started_at,omitempty"`
+	EndedAt         *time.Time           `json:"ended_at,omitempty"`
+	Notes           string               `json:"notes"`
+	CreatedAt       time.Time            `json:"created_at"`
+	Exercise        *WorkoutExerciseItem `json:"exercise,omitempty"`
+	WorkoutSets     []WorkoutSet         `json:"workout_sets,omitempty"`
+	NewRecords      []PersonalRecord     `json:"new_records,omitempty"`
 }
 
 type WorkoutExerciseRequest struct {
-	ExerciseID int     `json:"exercise_id"`
-	Sets       int     `json:"sets"`
-	Reps       int     `json:"reps"`
-	Weight     float64 `json:"weight"`
-	Notes      string  `json:"notes"`
+	ExerciseID      int                 `json:"exercise_id"`
+	Sets            int                 `json:"sets"`
+	Reps            int                 `json:"reps"`
+	Weight          float64             `json:"weight"`
+	DurationSeconds *int                `json:"duration_seconds"`
+	DistanceMeters  *float64            `json:"distance_meters"`
+	HeartRateAvg    *int                `json:"heart_rate_avg"`
+	StartedAt       *time.Time          `json:"started_at"`
+	EndedAt         *time.Time          `json:"ended_at"`
+	Notes           string              `json:"notes"`
+	WorkoutSets     []WorkoutSetRequest `json:"workout_sets"`
 }
 
 type WorkoutExerciseResponse struct {
-	ID         int                  `json:"id"`
-	WorkoutID  int                  `json:"workout_id"`
-	ExerciseID int                  `json:"exercise_id"`
-	Sets       int                  `json:"sets"`
-	Reps       int                  `json:"reps"`
-	Weight     float64              `json:"weight"`
-	Notes      string               `json:"notes"`
-	CreatedAt  time.Time            `json:"created_at"`
-	Exercise   *WorkoutExerciseItem `json:"exercise,omitempty"`
+	ID               int                      `json:"id"`
+	WorkoutID        int                      `json:"workout_id"`
+	ExerciseID       int                      `json:"exercise_id"`
+	Sets             int                      `json:"sets"`
+	Reps             int                      `json:"reps"`
+	Weight           float64                  `json:"weight"`
+	DurationSeconds  *int                     `json:"duration_seconds,omitempty"`
+	DistanceMeters   *float64                 `json:"distance_meters,omitempty"`
+	PaceSecondsPerKm *float64                 `json:"pace_seconds_per_km,omitempty"`
+	HeartRateAvg     *int                     `json:"heart_rate_avg,omitempty"`
+	StartedAt        *time.Time               `json:"started_at,omitempty"`
+	EndedAt          *time.Time               `json:"ended_at,omitempty"`
+	Notes            string                   `json:"notes"`
+	CreatedAt        time.Time                `json:"created_at"`
+	Exercise         *WorkoutExerciseItem     `json:"exercise,omitempty"`
+	WorkoutSets      []WorkoutSetResponse     `json:"workout_sets,omitempty"`
+	NewRecords       []PersonalRecordResponse `json:"new_records,omitempty"`
 }
 
 type WorkoutExerciseItem struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	TrackingType string `json:"tracking_type"`
 }
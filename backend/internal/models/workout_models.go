@@ -1,27 +1,83 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Workout struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Date      time.Time `json:"date"`
-	Notes     string    `json:"notes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	IsActive  bool      `json:"is_active"`
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	Date        time.Time  `json:"date"`
+	Notes       string     `json:"notes"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	TotalSets   *int       `json:"total_sets,omitempty"`
+	TotalVolume *float64   `json:"total_volume,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	IsActive    bool       `json:"is_active"`
 }
 
+// WorkoutRequest records whether started_at and ended_at were sent at all, so
+// an update can tell an omitted time (keep it) from an explicit null (clear it).
 type WorkoutRequest struct {
-	Date  time.Time `json:"date"`
-	Notes string    `json:"notes"`
+	Date         time.Time  `json:"date"`
+	Notes        string     `json:"notes"`
+	StartedAt    *time.Time `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at"`
+	StartedAtSet bool       `json:"-"`
+	EndedAtSet   bool       `json:"-"`
+}
+
+func (r *WorkoutRequest) UnmarshalJSON(data []byte) error {
+	type alias WorkoutRequest
+	var body alias
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	_, body.StartedAtSet = fields["started_at"]
+	_, body.EndedAtSet = fields["ended_at"]
+	*r = WorkoutRequest(body)
+	return nil
+}
+
+// WorkoutFilter bounds are matched against started_at, or the workout date
+// when the workout has no start time; To is exclusive.
+type WorkoutFilter struct {
+	From       *time.Time
+	To         *time.Time
+	ExerciseID *int
+	Search     *string
+	Page       int
+	Limit      int
+	Offset     int
+	SortOrder  string
 }
 
 type WorkoutResponse struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Date      time.Time `json:"date"`
-	Notes     string    `json:"notes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              int        `json:"id"`
+	UserID          int        `json:"user_id"`
+	Date            time.Time  `json:"date"`
+	Notes           string     `json:"notes"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds *int       `json:"duration_seconds,omitempty"`
+	TotalSets       *int       `json:"total_sets,omitempty"`
+	TotalVolume     *float64   `json:"total_volume,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type WorkoutListResponse struct {
+	Workouts []WorkoutResponse `json:"workouts"`
+	Total    int               `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
 }
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Messages sent by a client over the live session socket.
+const (
+	SessionMessageCompleteSet    = "complete_set"
+	SessionMessageUpdateSet      = "update_set"
+	SessionMessageDeleteSet      = "delete_set"
+	SessionMessageStartRestTimer = "start_rest_timer"
+	SessionMessageStopRestTimer  = "stop_rest_timer"
+)
+
+// Events broadcast to every device connected to the session.
+const (
+	SessionEventSetCompleted     = "set_completed"
+	SessionEventSetUpdated       = "set_updated"
+	SessionEventSetDeleted       = "set_deleted"
+	SessionEventRestTimerStarted = "rest_timer_started"
+	SessionEventRestTimerStopped = "rest_timer_stopped"
+	SessionEventRestTimerTick    = "rest_timer_tick"
+	SessionEventSessionFinished  = "session_finished"
+	SessionEventError            = "error"
+)
+
+type WorkoutSession struct {
+	WorkoutID  int        `json:"workout_id"`
+	UserID     int        `json:"user_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	RestEndsAt *time.Time `json:"rest_ends_at,omitempty"`
+}
+
+type WorkoutSessionResponse struct {
+	WorkoutID  int        `json:"workout_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	RestEndsAt *time.Time `json:"rest_ends_at,omitempty"`
+}
+
+type SessionMessage struct {
+	Type              string             `json:"type"`
+	WorkoutExerciseID int                `json:"workout_exercise_id"`
+	SetID             int                `json:"set_id"`
+	Set               *WorkoutSetRequest `json:"set"`
+	RestSeconds       int                `json:"rest_seconds"`
+}
+
+type SessionEvent struct {
+	Type              string      `json:"type"`
+	WorkoutID         int         `json:"workout_id"`
+	WorkoutExerciseID int         `json:"workout_exercise_id,omitempty"`
+	SetID             int         `json:"set_id,omitempty"`
+	Set               *WorkoutSet `json:"set,omitempty"`
+	RestEndsAt        *time.Time  `json:"rest_ends_at,omitempty"`
+	Workout           *Workout    `json:"workout,omitempty"`
+	SentAt            time.Time   `json:"sent_at"`
+}
+
+type SessionEventResponse struct {
+	Type              string              `json:"type"`
+	WorkoutID         int                 `json:"workout_id"`
+	WorkoutExerciseID int                 `json:"workout_exercise_id,omitempty"`
+	SetID             int                 `json:"set_id,omitempty"`
+	Set               *WorkoutSetResponse `json:"set,omitempty"`
+	RestEndsAt        *time.Time          `json:"rest_ends_at,omitempty"`
+	RemainingSeconds  *int                `json:"remaining_seconds,omitempty"`
+	Workout           *WorkoutResponse    `json:"workout,omitempty"`
+	Message           string              `json:"message,omitempty"`
+	SentAt            time.Time           `json:"sent_at"`
+}
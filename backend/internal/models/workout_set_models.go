@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+const (
+	SetTypeWarmup  = "warmup"
+	SetTypeWorking = "working"
+	SetTypeDrop    = "drop"
+	SetTypeFailure = "failure"
+)
+
+type WorkoutSet struct {
+	ID                int              `json:"id"`
+	WorkoutExerciseID int              `json:"workout_exercise_id"`
+	SetNumber         int              `json:"set_number"`
+	SetType           string           `json:"set_type"`
+	Reps              int              `json:"reps"`
+	Weight            float64          `json:"weight"`
+	RPE               *float64         `json:"rpe,omitempty"`
+	DurationSeconds   *int             `json:"duration_seconds,omitempty"`
+	DistanceMeters    *float64         `json:"distance_meters,omitempty"`
+	HeartRateAvg      *int             `json:"heart_rate_avg,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	NewRecords        []PersonalRecord `json:"new_records,omitempty"`
+}
+
+type WorkoutSetRequest struct {
+	SetNumber       int      `json:"set_number"`
+	SetType         string   `json:"set_type"`
+	Reps            int      `json:"reps"`
+	Weight          float64  `json:"weight"`
+	RPE             *float64 `json:"rpe"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	HeartRateAvg    *int     `json:"heart_rate_avg"`
+}
+
+type WorkoutSetResponse struct {
+	ID                int                      `json:"id"`
+	WorkoutExerciseID int                      `json:"workout_exercise_id"`
+	SetNumber         int                      `json:"set_number"`
+	SetType           string                   `json:"set_type"`
+	Reps              int                      `json:"reps"`
+	Weight            float64                  `json:"weight"`
+	RPE               *float64                 `json:"rpe,omitempty"`
+	DurationSeconds   *int                     `json:"duration_seconds,omitempty"`
+	DistanceMeters    *float64                 `json:"distance_meters,omitempty"`
+	PaceSecondsPerKm  *float64                 `json:"pace_seconds_per_km,omitempty"`
+	HeartRateAvg      *int                     `json:"heart_rate_avg,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+	NewRecords        []PersonalRecordResponse `json:"new_records,omitempty"`
+}
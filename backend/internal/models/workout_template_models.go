@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type WorkoutTemplate struct {
+	ID        int                       `json:"id"`
+	UserID    int                       `json:"user_id"`
+	Name      string                    `json:"name"`
+	Notes     string                    `json:"notes"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+	IsActive  bool                      `json:"is_active"`
+	Exercises []WorkoutTemplateExercise `json:"exercises,omitempty"`
+}
+
+type WorkoutTemplateExercise struct {
+	ID           int                  `json:"id"`
+	TemplateID   int                  `json:"template_id"`
+	ExerciseID   int                  `json:"exercise_id"`
+	Position     int                  `json:"position"`
+	TargetSets   int                  `json:"target_sets"`
+	TargetReps   *int                 `json:"target_reps,omitempty"`
+	TargetWeight float64              `json:"target_weight"`
+	Notes        string               `json:"notes"`
+	Exercise     *WorkoutExerciseItem `json:"exercise,omitempty"`
+}
+
+type WorkoutTemplateRequest struct {
+	Name      string                           `json:"name"`
+	Notes     string                           `json:"notes"`
+	Exercises []WorkoutTemplateExerciseRequest `json:"exercises"`
+}
+
+type WorkoutTemplateExerciseRequest struct {
+	ExerciseID   int     `json:"exercise_id"`
+	TargetSets   int     `json:"target_sets"`
+	TargetReps   *int    `json:"target_reps,omitempty"`
+	TargetWeight float64 `json:"target_weight"`
+	Notes        string  `json:"notes"`
+}
+
+type TemplateFromWorkoutRequest struct {
+	Name  string `json:"name"`
+	Notes string `json:"notes"`
+}
+
+type WorkoutFromTemplateRequest struct {
+	Date  time.Time `json:"date"`
+	Notes string    `json:"notes"`
+}
+
+type WorkoutTemplateResponse struct {
+	ID        int                               `json:"id"`
+	UserID    int                               `json:"user_id"`
+	Name      string                            `json:"name"`
+	Notes     string                            `json:"notes"`
+	CreatedAt time.Time                         `json:"created_at"`
+	UpdatedAt time.Time                         `json:"updated_at"`
+	Exercises []WorkoutTemplateExerciseResponse `json:"exercises,omitempty"`
+}
+
+type WorkoutTemplateExerciseResponse struct {
+	ID           int                  `json:"id"`
+	ExerciseID   int                  `json:"exercise_id"`
+	Position     int                  `json:"position"`
+	TargetSets   int                  `json:"target_sets"`
+	TargetReps   *int                 `json:"target_reps,omitempty"`
+	TargetWeight float64              `json:"target_weight"`
+	Notes        string               `json:"notes"`
+	Exercise     *WorkoutExerciseItem `json:"exercise,omitempty"`
+}
@@ -234,6 +234,27 @@ func (c *FatSecretAuthClient) GetFoodEntries(ctx context.Context, accessToken, a
 	return entries, nil
 }
 
+// SignedParams adds the OAuth 1.0 parameters and the signature to params of a
+// request that is signed with the consumer key only, as the FatSecret
+// Platform API expects for requests made not on behalf of a user.
+func SignedParams(method, urlStr string, params map[string]string, consumerKey, consumerSecret string) map[string]string {
+	signed := map[string]string{
+		"oauth_consumer_key":     consumerKey,
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        fmt.Sprintf("%d", time.Now().Unix()),
+		"oauth_nonce":            generateNonce(),
+		"oauth_version":          "1.0",
+	}
+	for k, v := range params {
+		signed[k] = v
+	}
+
+	baseString := buildBaseString(method, urlStr, signed)
+	signed["oauth_signature"] = signRequest(baseString, consumerSecret, "")
+
+	return signed
+}
+
 func generateNonce() string {
 	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, 32)
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AccountRepository struct {
+	db *sqlx.DB
+}
+
+func NewAccountRepository(db *sqlx.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// UpdateProfile changes the username and the email, nil values are kept. A
+// new email has to be verified again.
+func (r *AccountRepository) UpdateProfile(ctx context.Context, userID int, username, email *string) (*models.User, error) {
+	query := `UPDATE Users
+	SET username = COALESCE($1, username),
+		email = COALESCE($2, email),
+		email_verified_at = CASE WHEN $2::text IS NULL OR $2 = email THEN email_verified_at END,
+		updated_at = NOW()
+	WHERE id = $3
+	AND is_active = TRUE
+	RETURNING id, username, email, created_at, updated_at, email_verified_at`
+
+	user := &models.User{}
+	err := r.db.QueryRowContext(ctx, query, username, email, userID).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.EmailVerifiedAt,
+	)
+	if err != nil {
+		log.Println("Failed to update profile:", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdatePassword sets the new password and logs the user out everywhere.
+func (r *AccountRepository) UpdatePassword(ctx context.Context, userID int, passwordHash string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return 0, err
+	}
+
+	query := `UPDATE Users
+	SET password_hash = $1, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2
+	AND is_active = TRUE`
+
+	result, err := tx.ExecContext(ctx, query, passwordHash, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to update password:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to revoke sessions:", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// ScheduleDeletion deactivates the account, logs the user out everywhere and
+// sets the time after which the data is purged.
+func (r *AccountRepository) ScheduleDeletion(ctx context.Context, userID int, purgeAt time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return 0, err
+	}
+
+	query := `UPDATE Users
+	SET is_active = FALSE, purge_at = $1, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2
+	AND is_active = TRUE`
+
+	result, err := tx.ExecContext(ctx, query, purgeAt, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to schedule account deletion:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to revoke sessions:", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *AccountRepository) GetAccountsToPurge(ctx context.Context, limit int) ([]int, error) {
+	query := `SELECT id
+	FROM Users
+	WHERE purge_at <= NOW()
+	AND purged_at IS NULL
+	AND is_active = FALSE
+	ORDER BY purge_at
+	LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		log.Println("Failed to get accounts to purge:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			log.Println("Failed to scan account to purge:", err)
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// PurgeAccount deletes the workouts, workout templates, foods, FatSecret
+// credentials, recovery codes, trainer relationships and data exports of the
+// account and anonymizes it, so the username and email can be taken again.
+// Program assignments are cancelled on both the client and the trainer side.
+// The account row is locked, other instances skip it; false is returned when
+// the account is locked, reactivated or purged already.
+func (r *AccountRepository) PurgeAccount(ctx context.Context, userID int) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return false, err
+	}
+
+	lockQuery := `SELECT id
+	FROM Users
+	WHERE id = $1
+	AND purge_at <= NOW()
+	AND purged_at IS NULL
+	AND is_active = FALSE
+	FOR UPDATE SKIP LOCKED`
+
+	var id int
+	if err := tx.QueryRowContext(ctx, lockQuery, userID).Scan(&id); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		log.Println("Failed to lock account:", err)
+		return false, err
+	}
+
+	queries := []string{
+		`DELETE FROM PersonalRecords WHERE user_id = $1`,
+		`DELETE FROM ScheduledWorkouts WHERE workout_id IN (SELECT id FROM Workouts WHERE user_id = $1)`,
+		`UPDATE ProgramAssignments SET is_active = FALSE WHERE client_id = $1 OR trainer_id = $1`,
+		`DELETE FROM TrainerClients WHERE client_id = $1 OR trainer_id = $1`,
+		`DELETE FROM WorkoutExercises WHERE workout_id IN (SELECT id FROM Workouts WHERE user_id = $1)`,
+		`DELETE FROM Workouts WHERE user_id = $1`,
+		`DELETE FROM ProgramWorkouts WHERE template_id IN (SELECT id FROM WorkoutTemplates WHERE user_id = $1)`,
+		`DELETE FROM WorkoutTemplates WHERE user_id = $1`,
+		`DELETE FROM Foods WHERE user_id = $1`,
+		`DELETE FROM Recipes WHERE user_id = $1`,
+		`DELETE FROM CustomFoods WHERE user_id = $1`,
+		`DELETE FROM TempFatsecretAuth WHERE user_id = $1`,
+		`DELETE FROM FatsecretAuth WHERE user_id = $1`,
+		`DELETE FROM DataExports WHERE user_id = $1`,
+		`DELETE FROM UserRecoveryCodes WHERE user_id = $1`,
+		`UPDATE Users
+		SET username = 'deleted-' || id,
+			email = 'deleted-' || id || '@deleted.invalid',
+			totp_secret = NULL,
+			totp_enabled_at = NULL,
+			purged_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			tx.Rollback()
+			log.Println("Failed to purge account:", err)
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	email := "new@example.com"
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Users
+	SET username = COALESCE($1, username),
+		email = COALESCE($2, email),
+		email_verified_at = CASE WHEN $2::text IS NULL OR $2 = email THEN email_verified_at END,
+		updated_at = NOW()
+	WHERE id = $3
+	AND is_active = TRUE
+	RETURNING id, username, email, created_at, updated_at, email_verified_at`)).
+		WithArgs(nil, &email, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "created_at", "updated_at", "email_verified_at"}).
+			AddRow(2, "john", email, now, now, nil))
+
+	user, err := repo.UpdateProfile(context.Background(), 2, nil, &email)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", user.Username)
+	assert.Equal(t, email, user.Email)
+	assert.Nil(t, user.EmailVerifiedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET password_hash = $1, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2
+	AND is_active = TRUE`)).
+		WithArgs("hash", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdatePassword(context.Background(), 2, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+	purgeAt := time.Now().AddDate(0, 0, 30)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET is_active = FALSE, purge_at = $1, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2
+	AND is_active = TRUE`)).
+		WithArgs(purgeAt, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	updated, err := repo.ScheduleDeletion(context.Background(), 2, purgeAt)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAccountsToPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id
+	FROM Users
+	WHERE purge_at <= NOW()
+	AND purged_at IS NULL
+	AND is_active = FALSE
+	ORDER BY purge_at
+	LIMIT $1`)).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7))
+
+	userIDs, err := repo.GetAccountsToPurge(context.Background(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 7}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeAccount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id
+	FROM Users
+	WHERE id = $1
+	AND purge_at <= NOW()
+	AND purged_at IS NULL
+	AND is_active = FALSE
+	FOR UPDATE SKIP LOCKED`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM PersonalRecords WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ScheduledWorkouts WHERE workout_id IN (SELECT id FROM Workouts WHERE user_id = $1)`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE ProgramAssignments SET is_active = FALSE WHERE client_id = $1 OR trainer_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM TrainerClients WHERE client_id = $1 OR trainer_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutExercises WHERE workout_id IN (SELECT id FROM Workouts WHERE user_id = $1)`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM Workouts WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ProgramWorkouts WHERE template_id IN (SELECT id FROM WorkoutTemplates WHERE user_id = $1)`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutTemplates WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM Foods WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM Recipes WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM CustomFoods WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM TempFatsecretAuth WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM FatsecretAuth WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM DataExports WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRecoveryCodes WHERE user_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+		SET username = 'deleted-' || id,`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	purged, err := repo.PurgeAccount(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.True(t, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepositoryNegative(t *testing.T) {
+	t.Run("UpdatePassword inactive user", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users`)).
+			WithArgs("hash", 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		updated, err := repo.UpdatePassword(context.Background(), 2, "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, updated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ScheduleDeletion revoke sessions error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions`)).
+			WithArgs(2).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		_, err = repo.ScheduleDeletion(context.Background(), 2, time.Now())
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("PurgeAccount skips locked account", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		purged, err := repo.PurgeAccount(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.False(t, purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("PurgeAccount delete error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM PersonalRecords`)).
+			WithArgs(3).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		purged, err := repo.PurgeAccount(context.Background(), 3)
+		assert.Error(t, err)
+		assert.False(t, purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AnalyticsRepository struct {
+	db *sqlx.DB
+}
+
+func NewAnalyticsRepository(db *sqlx.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// GetProgressionSets returns logged sets of the exercise. Workout exercises
+// without logged sets fall back to their planned sets, reps and weight.
+func (r *AnalyticsRepository) GetProgressionSets(ctx context.Context, userID, exerciseID int, filter *models.ProgressionFilter) (*[]models.ProgressionSet, error) {
+	conditions := []string{"w.is_active = TRUE", "w.user_id = $1", "we.exercise_id = $2"}
+	args := []interface{}{userID, exerciseID}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("w.date >= $%d", len(args)))
+	}
+
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("w.date <= $%d", len(args)))
+	}
+
+	query := `SELECT w.id, w.date, COALESCE(ws.weight, we.weight, 0), COALESCE(ws.reps, we.reps, 0),
+	CASE WHEN ws.id IS NULL THEN we.sets ELSE 1 END
+	FROM Workouts w
+	INNER JOIN WorkoutExercises we ON we.workout_id = w.id
+	LEFT JOIN WorkoutSets ws ON ws.workout_exercise_id = we.id AND ws.set_type <> 'warmup'
+	WHERE ` + strings.Join(conditions, " AND ") + `
+	ORDER BY w.date, w.id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Failed to get progression sets:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sets []models.ProgressionSet
+	for rows.Next() {
+		var set models.ProgressionSet
+		err := rows.Scan(
+			&set.WorkoutID,
+			&set.WorkoutDate,
+			&set.Weight,
+			&set.Reps,
+			&set.Sets,
+		)
+		if err != nil {
+			log.Println("Failed to scan progression set:", err)
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &sets, nil
+}
+
+// GetMuscleVolume sums non-warmup sets and tonnage per week and muscle group.
+// Workout exercises without logged sets fall back to their planned values.
+func (r *AnalyticsRepository) GetMuscleVolume(ctx context.Context, userID int, filter *models.DateRangeFilter) (*[]models.MuscleVolumeRow, error) {
+	query := `SELECT DATE_TRUNC('week', w.date)::date AS week_start, mg.id, mg.name, emg.is_primary,
+	COALESCE(SUM(CASE WHEN ws.id IS NULL THEN we.sets ELSE 1 END), 0),
+	COALESCE(SUM(COALESCE(ws.weight, we.weight, 0) * COALESCE(ws.reps, we.reps, 0) * CASE WHEN ws.id IS NULL THEN COALESCE(we.sets, 0) ELSE 1 END), 0)
+	FROM Workouts w
+	INNER JOIN WorkoutExercises we ON we.workout_id = w.id
+	INNER JOIN ExerciseMuscleGroups emg ON emg.exercise_id = we.exercise_id
+	INNER JOIN MuscleGroups mg ON emg.muscle_group_id = mg.id
+	LEFT JOIN WorkoutSets ws ON ws.workout_exercise_id = we.id AND ws.set_type <> 'warmup'
+	WHERE w.is_active = TRUE
+	AND w.user_id = $1
+	AND w.date >= $2
+	AND w.date <= $3
+	GROUP BY week_start, mg.id, mg.name, emg.is_primary
+	ORDER BY week_start, mg.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, filter.From, filter.To)
+	if err != nil {
+		log.Println("Failed to get muscle volume:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var volume []models.MuscleVolumeRow
+	for rows.Next() {
+		var row models.MuscleVolumeRow
+		err := rows.Scan(
+			&row.WeekStart,
+			&row.MuscleGroupID,
+			&row.MuscleGroupName,
+			&row.IsPrimary,
+			&row.Sets,
+			&row.Tonnage,
+		)
+		if err != nil {
+			log.Println("Failed to scan muscle volume:", err)
+			return nil, err
+		}
+
+		volume = append(volume, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &volume, nil
+}
+
+// GetTrainingTime sums the duration of workouts that have both start and end
+// time per week of the workout date.
+func (r *AnalyticsRepository) GetTrainingTime(ctx context.Context, userID int, filter *models.DateRangeFilter) (*[]models.TrainingTimeWeek, error) {
+	query := `SELECT DATE_TRUNC('week', w.date)::date AS week_start, COUNT(w.id),
+	COALESCE(SUM(EXTRACT(EPOCH FROM (w.ended_at - w.started_at))), 0)::bigint
+	FROM Workouts w
+	WHERE w.is_active = TRUE
+	AND w.user_id = $1
+	AND w.started_at IS NOT NULL
+	AND w.ended_at IS NOT NULL
+	AND w.date >= $2
+	AND w.date <= $3
+	GROUP BY week_start
+	ORDER BY week_start`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, filter.From, filter.To)
+	if err != nil {
+		log.Println("Failed to get training time:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weeks []models.TrainingTimeWeek
+	for rows.Next() {
+		var week models.TrainingTimeWeek
+		err := rows.Scan(
+			&week.WeekStart,
+			&week.Workouts,
+			&week.TotalSeconds,
+		)
+		if err != nil {
+			log.Println("Failed to scan training time:", err)
+			return nil, err
+		}
+
+		weeks = append(weeks, week)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &weeks, nil
+}
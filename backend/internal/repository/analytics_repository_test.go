@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgressionSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAnalyticsRepository(sqlxDB)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	filter := &models.ProgressionFilter{From: &from, To: &to, Formula: "epley", Bucket: models.BucketWeek}
+
+	rows := sqlmock.NewRows([]string{"id", "date", "weight", "reps", "sets"}).
+		AddRow(1, from, 100.0, 5, 1).
+		AddRow(2, from.AddDate(0, 0, 3), 80.0, 8, 3)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT w.id, w.date, COALESCE(ws.weight, we.weight, 0), COALESCE(ws.reps, we.reps, 0),
+	CASE WHEN ws.id IS NULL THEN we.sets ELSE 1 END
+	FROM Workouts w
+	INNER JOIN WorkoutExercises we ON we.workout_id = w.id
+	LEFT JOIN WorkoutSets ws ON ws.workout_exercise_id = we.id AND ws.set_type <> 'warmup'
+	WHERE w.is_active = TRUE AND w.user_id = $1 AND we.exercise_id = $2 AND w.date >= $3 AND w.date <= $4`)).
+		WithArgs(1, 2, from, to).
+		WillReturnRows(rows)
+
+	sets, err := repo.GetProgressionSets(context.Background(), 1, 2, filter)
+	assert.NoError(t, err)
+	assert.Len(t, *sets, 2)
+	assert.Equal(t, 3, (*sets)[1].Sets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMuscleVolume(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAnalyticsRepository(sqlxDB)
+
+	week := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	filter := &models.DateRangeFilter{From: week, To: week.AddDate(0, 0, 6)}
+
+	rows := sqlmock.NewRows([]string{"week_start", "id", "name", "is_primary", "sets", "tonnage"}).
+		AddRow(week, 1, "Грудь", true, 20, 8000.0).
+		AddRow(week, 2, "Спина", true, 6, 3000.0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE_TRUNC('week', w.date)::date AS week_start, mg.id, mg.name, emg.is_primary`)).
+		WithArgs(1, filter.From, filter.To).
+		WillReturnRows(rows)
+
+	volume, err := repo.GetMuscleVolume(context.Background(), 1, filter)
+	assert.NoError(t, err)
+	assert.Len(t, *volume, 2)
+	assert.Equal(t, 20, (*volume)[0].Sets)
+	assert.Equal(t, 3000.0, (*volume)[1].Tonnage)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrainingTime(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAnalyticsRepository(sqlxDB)
+
+	week := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	filter := &models.DateRangeFilter{From: week, To: week.AddDate(0, 0, 13)}
+
+	rows := sqlmock.NewRows([]string{"week_start", "count", "total_seconds"}).
+		AddRow(week, 3, 12600).
+		AddRow(week.AddDate(0, 0, 7), 2, 7200)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE_TRUNC('week', w.date)::date AS week_start, COUNT(w.id),
+	COALESCE(SUM(EXTRACT(EPOCH FROM (w.ended_at - w.started_at))), 0)::bigint`)).
+		WithArgs(1, filter.From, filter.To).
+		WillReturnRows(rows)
+
+	weeks, err := repo.GetTrainingTime(context.Background(), 1, filter)
+	assert.NoError(t, err)
+	assert.Len(t, *weeks, 2)
+	assert.Equal(t, 3, (*weeks)[0].Workouts)
+	assert.Equal(t, 7200, (*weeks)[1].TotalSeconds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnalyticsRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewAnalyticsRepository(sqlxDB)
+
+	t.Run("GetProgressionSets error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT w.id, w.date`)).
+			WithArgs(1, 2).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetProgressionSets(context.Background(), 1, 2, &models.ProgressionFilter{})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetProgressionSets scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "date", "weight", "reps", "sets"}).
+			AddRow("bad", time.Now(), 100.0, 5, 1)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT w.id, w.date`)).
+			WillReturnRows(rows)
+
+		_, err := repository.GetProgressionSets(context.Background(), 1, 2, &models.ProgressionFilter{})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetMuscleVolume error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE_TRUNC('week', w.date)::date`)).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetMuscleVolume(context.Background(), 1, &models.DateRangeFilter{})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetTrainingTime scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"week_start", "count", "total_seconds"}).
+			AddRow("bad", 1, 3600)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT DATE_TRUNC('week', w.date)::date AS week_start, COUNT(w.id)`)).
+			WillReturnRows(rows)
+
+		_, err := repository.GetTrainingTime(context.Background(), 1, &models.DateRangeFilter{})
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type APITokenRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPITokenRepository(db *sqlx.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+func (r *APITokenRepository) CreateToken(ctx context.Context, token *models.APIToken) error {
+	query := `INSERT INTO ApiTokens (user_id, name, token_prefix, token_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		token.UserID,
+		token.Name,
+		token.TokenPrefix,
+		token.TokenHash,
+		pq.Array(token.Scopes),
+		token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		log.Println("Failed to create api token:", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetActiveTokens returns tokens of the user that are not revoked or expired.
+func (r *APITokenRepository) GetActiveTokens(ctx context.Context, userID int) (*[]models.APIToken, error) {
+	query := `SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, created_at
+	FROM ApiTokens
+	WHERE user_id = $1
+	AND revoked_at IS NULL
+	AND expires_at > NOW()
+	ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get api tokens:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []models.APIToken{}
+	for rows.Next() {
+		var token models.APIToken
+		err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.TokenPrefix,
+			pq.Array(&token.Scopes),
+			&token.ExpiresAt,
+			&token.LastUsedAt,
+			&token.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan api token:", err)
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+// UseToken returns the active token with the hash and updates its last use.
+// Tokens of deactivated users are rejected. sql.ErrNoRows is returned when
+// there is no such token.
+func (r *APITokenRepository) UseToken(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	query := `UPDATE ApiTokens t
+	SET last_used_at = NOW()
+	FROM Users u
+	WHERE u.id = t.user_id
+	AND u.is_active = TRUE
+	AND t.token_hash = $1
+	AND t.revoked_at IS NULL
+	AND t.expires_at > NOW()
+	RETURNING t.id, t.user_id, t.name, t.scopes, t.expires_at`
+
+	token := &models.APIToken{}
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		pq.Array(&token.Scopes),
+		&token.ExpiresAt,
+	)
+	if err != nil {
+		log.Println("Failed to use api token:", err)
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *APITokenRepository) RevokeToken(ctx context.Context, userID, tokenID int) (int, error) {
+	query := `UPDATE ApiTokens
+	SET revoked_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, tokenID, userID)
+	if err != nil {
+		log.Println("Failed to revoke api token:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAPITokenRepository(sqlxDB)
+
+	now := time.Now()
+	token := &models.APIToken{
+		UserID:      2,
+		Name:        "import script",
+		TokenPrefix: "wt_abcd",
+		TokenHash:   "hash",
+		Scopes:      []string{models.ScopeWorkoutsRead, models.ScopeWorkoutsWrite},
+		ExpiresAt:   now.AddDate(0, 0, 30),
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ApiTokens (user_id, name, token_prefix, token_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at`)).
+		WithArgs(2, "import script", "wt_abcd", "hash", pq.Array(token.Scopes), token.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, now))
+
+	err = repo.CreateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, token.ID)
+	assert.Equal(t, now, token.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActiveAPITokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAPITokenRepository(sqlxDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_prefix", "scopes", "expires_at", "last_used_at", "created_at"}).
+		AddRow(5, 2, "import script", "wt_abcd", "{workouts:read,foods:write}", now.AddDate(0, 0, 30), now, now).
+		AddRow(4, 2, "export", "wt_efgh", "{analytics:read}", now.AddDate(0, 0, 7), nil, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, created_at
+	FROM ApiTokens
+	WHERE user_id = $1
+	AND revoked_at IS NULL
+	AND expires_at > NOW()`)).
+		WithArgs(2).
+		WillReturnRows(rows)
+
+	tokens, err := repo.GetActiveTokens(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, *tokens, 2)
+	assert.Equal(t, []string{models.ScopeWorkoutsRead, models.ScopeFoodsWrite}, (*tokens)[0].Scopes)
+	assert.Nil(t, (*tokens)[1].LastUsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseAPIToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAPITokenRepository(sqlxDB)
+
+	expiresAt := time.Now().AddDate(0, 0, 30)
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE ApiTokens t
+	SET last_used_at = NOW()
+	FROM Users u
+	WHERE u.id = t.user_id
+	AND u.is_active = TRUE
+	AND t.token_hash = $1`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "scopes", "expires_at"}).
+			AddRow(5, 2, "import script", "{workouts:read}", expiresAt))
+
+	token, err := repo.UseToken(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, token.UserID)
+	assert.Equal(t, []string{models.ScopeWorkoutsRead}, token.Scopes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAPIToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewAPITokenRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE ApiTokens
+	SET revoked_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND revoked_at IS NULL`)).
+		WithArgs(5, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	revoked, err := repo.RevokeToken(context.Background(), 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPITokenRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewAPITokenRepository(sqlxDB)
+
+	t.Run("UseToken unknown token", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE ApiTokens t`)).
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repository.UseToken(context.Background(), "unknown")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("GetActiveTokens query error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name, token_prefix`)).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetActiveTokens(context.Background(), 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("RevokeToken exec error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE ApiTokens`)).
+			WillReturnError(errors.New("exec error"))
+
+		_, err := repository.RevokeToken(context.Background(), 2, 5)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const customFoodColumns = `id, user_id, name, brand, barcode, serving_qty, serving_unit, serving_weight_grams,
+	calories_100g, protein_100g, carbs_100g, fat_100g,
+	calories_serving, protein_serving, carbs_serving, fat_serving,
+	created_at, updated_at, is_active`
+
+type CustomFoodRepository struct {
+	db *sqlx.DB
+}
+
+func NewCustomFoodRepository(db *sqlx.DB) *CustomFoodRepository {
+	return &CustomFoodRepository{db: db}
+}
+
+func (r *CustomFoodRepository) CreateCustomFood(ctx context.Context, food *models.CustomFood) error {
+	query := `INSERT INTO CustomFoods (user_id, name, brand, barcode, serving_qty, serving_unit, serving_weight_grams,
+		calories_100g, protein_100g, carbs_100g, fat_100g,
+		calories_serving, protein_serving, carbs_serving, fat_serving)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	RETURNING id, created_at, updated_at, is_active`
+
+	args := []any{food.UserID, food.Name, food.Brand, food.Barcode, food.ServingQty, food.ServingUnit, food.ServingWeightGrams}
+	args = append(args, nutrientArgs(food.Per100g)...)
+	args = append(args, nutrientArgs(food.PerServing)...)
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&food.ID,
+		&food.CreatedAt,
+		&food.UpdatedAt,
+		&food.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create custom food:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CustomFoodRepository) GetCustomFoodsByUserID(ctx context.Context, userID int) ([]models.CustomFood, error) {
+	query := `SELECT ` + customFoodColumns + `
+	FROM CustomFoods
+	WHERE is_active = TRUE
+	AND user_id = $1
+	ORDER BY name, id`
+
+	return r.queryCustomFoods(ctx, query, userID)
+}
+
+func (r *CustomFoodRepository) GetCustomFoodByUserID(ctx context.Context, userID, foodID int) (*models.CustomFood, error) {
+	query := `SELECT ` + customFoodColumns + `
+	FROM CustomFoods
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`
+
+	food, err := scanCustomFood(r.db.QueryRowContext(ctx, query, userID, foodID))
+	if err != nil {
+		log.Println("Failed to get custom food:", err)
+		return nil, err
+	}
+
+	return food, nil
+}
+
+// SearchCustomFoods matches the query against the name and brand, or the
+// whole barcode. Foods whose name starts with the query come first.
+func (r *CustomFoodRepository) SearchCustomFoods(ctx context.Context, userID int, query string, limit int) ([]models.CustomFood, error) {
+	searchQuery := `SELECT ` + customFoodColumns + `
+	FROM CustomFoods
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND (name ILIKE '%' || $2 || '%' OR brand ILIKE '%' || $2 || '%' OR barcode = $2)
+	ORDER BY name NOT ILIKE $2 || '%', name, id
+	LIMIT $3`
+
+	return r.queryCustomFoods(ctx, searchQuery, userID, query, limit)
+}
+
+func (r *CustomFoodRepository) UpdateCustomFood(ctx context.Context, food *models.CustomFood) error {
+	query := `UPDATE CustomFoods
+	SET name = $1, brand = $2, barcode = $3, serving_qty = $4, serving_unit = $5, serving_weight_grams = $6,
+		calories_100g = $7, protein_100g = $8, carbs_100g = $9, fat_100g = $10,
+		calories_serving = $11, protein_serving = $12, carbs_serving = $13, fat_serving = $14,
+		updated_at = NOW()
+	WHERE id = $15
+	AND user_id = $16
+	AND is_active = TRUE
+	RETURNING created_at, updated_at, is_active`
+
+	args := []any{food.Name, food.Brand, food.Barcode, food.ServingQty, food.ServingUnit, food.ServingWeightGrams}
+	args = append(args, nutrientArgs(food.Per100g)...)
+	args = append(args, nutrientArgs(food.PerServing)...)
+	args = append(args, food.ID, food.UserID)
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&food.CreatedAt,
+		&food.UpdatedAt,
+		&food.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to update custom food:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CustomFoodRepository) DeleteCustomFood(ctx context.Context, userID, foodID int) (int, error) {
+	query := `UPDATE CustomFoods
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, foodID, userID)
+	if err != nil {
+		log.Println("Failed to delete custom food:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to delete custom food result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *CustomFoodRepository) queryCustomFoods(ctx context.Context, query string, args ...any) ([]models.CustomFood, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Failed to get custom foods:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := []models.CustomFood{}
+	for rows.Next() {
+		food, err := scanCustomFood(rows)
+		if err != nil {
+			log.Println("Failed to scan custom food:", err)
+			return nil, err
+		}
+
+		foods = append(foods, *food)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return foods, nil
+}
+
+func scanCustomFood(row rowScanner) (*models.CustomFood, error) {
+	var (
+		food       models.CustomFood
+		per100g    [4]*float64
+		perServing [4]*float64
+	)
+
+	err := row.Scan(
+		&food.ID,
+		&food.UserID,
+		&food.Name,
+		&food.Brand,
+		&food.Barcode,
+		&food.ServingQty,
+		&food.ServingUnit,
+		&food.ServingWeightGrams,
+		&per100g[0], &per100g[1], &per100g[2], &per100g[3],
+		&perServing[0], &perServing[1], &perServing[2], &perServing[3],
+		&food.CreatedAt,
+		&food.UpdatedAt,
+		&food.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	food.Per100g = newNutrients(per100g)
+	food.PerServing = newNutrients(perServing)
+
+	return &food, nil
+}
+
+// nutrientArgs stores a missing set of nutrients as NULL columns.
+func nutrientArgs(n *models.Nutrients) []any {
+	if n == nil {
+		return []any{nil, nil, nil, nil}
+	}
+	return []any{n.Calories, n.Protein, n.Carbs, n.Fat}
+}
+
+func newNutrients(values [4]*float64) *models.Nutrients {
+	if values[0] == nil {
+		return nil
+	}
+
+	n := &models.Nutrients{Calories: *values[0]}
+	if values[1] != nil {
+		n.Protein = *values[1]
+	}
+	if values[2] != nil {
+		n.Carbs = *values[2]
+	}
+	if values[3] != nil {
+		n.Fat = *values[3]
+	}
+
+	return n
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var customFoodRowColumns = []string{"id", "user_id", "name", "brand", "barcode", "serving_qty", "serving_unit", "serving_weight_grams",
+	"calories_100g", "protein_100g", "carbs_100g", "fat_100g",
+	"calories_serving", "protein_serving", "carbs_serving", "fat_serving",
+	"created_at", "updated_at", "is_active"}
+
+func TestCreateCustomFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	food := &models.CustomFood{
+		UserID:      2,
+		Name:        "Protein bar",
+		Brand:       "Local",
+		ServingQty:  1,
+		ServingUnit: "bar",
+		PerServing:  &models.Nutrients{Calories: 200, Protein: 20, Carbs: 18, Fat: 6},
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO CustomFoods`)).
+		WithArgs(2, "Protein bar", "Local", "", 1.0, "bar", nil, nil, nil, nil, nil, 200.0, 20.0, 18.0, 6.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(5, now, now, true))
+
+	err = repo.CreateCustomFood(context.Background(), food)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, food.ID)
+	assert.True(t, food.IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCustomFoodByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`)).
+		WithArgs(2, 5).
+		WillReturnRows(sqlmock.NewRows(customFoodRowColumns).
+			AddRow(5, 2, "Cottage cheese", "", "", 1.0, "", nil, 98.0, 11.0, 3.4, 4.3, nil, nil, nil, nil, now, now, true))
+
+	food, err := repo.GetCustomFoodByUserID(context.Background(), 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Nutrients{Calories: 98, Protein: 11, Carbs: 3.4, Fat: 4.3}, food.Per100g)
+	assert.Nil(t, food.PerServing)
+	assert.Nil(t, food.ServingWeightGrams)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchCustomFoods(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`AND (name ILIKE '%' || $2 || '%' OR brand ILIKE '%' || $2 || '%' OR barcode = $2)
+	ORDER BY name NOT ILIKE $2 || '%', name, id
+	LIMIT $3`)).
+		WithArgs(2, "bar", 20).
+		WillReturnRows(sqlmock.NewRows(customFoodRowColumns).
+			AddRow(5, 2, "Protein bar", "Local", "", 1.0, "bar", 40.0, 500.0, 50.0, 45.0, 15.0, 200.0, 20.0, 18.0, 6.0, now, now, true))
+
+	foods, err := repo.SearchCustomFoods(context.Background(), 2, "bar", 20)
+	assert.NoError(t, err)
+	assert.Len(t, foods, 1)
+	assert.Equal(t, 40.0, *foods[0].ServingWeightGrams)
+	assert.Equal(t, 200.0, foods[0].PerServing.Calories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCustomFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE CustomFoods
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`)).
+		WithArgs(5, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rowsAffected, err := repo.DeleteCustomFood(context.Background(), 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomFoodRepositoryNegative(t *testing.T) {
+	t.Run("GetCustomFoodByUserID not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods`)).
+			WithArgs(2, 5).
+			WillReturnRows(sqlmock.NewRows(customFoodRowColumns))
+
+		food, err := repo.GetCustomFoodByUserID(context.Background(), 2, 5)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, food)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("UpdateCustomFood not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE CustomFoods`)).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "is_active"}))
+
+		err = repo.UpdateCustomFood(context.Background(), &models.CustomFood{ID: 5, UserID: 2, Name: "Bar", ServingQty: 1,
+			Per100g: &models.Nutrients{Calories: 400}})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetCustomFoodsByUserID query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods`)).
+			WithArgs(2).
+			WillReturnError(errors.New("db error"))
+
+		foods, err := repo.GetCustomFoodsByUserID(context.Background(), 2)
+		assert.Error(t, err)
+		assert.Nil(t, foods)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
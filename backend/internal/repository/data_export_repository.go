@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type DataExportRepository struct {
+	db *sqlx.DB
+}
+
+func NewDataExportRepository(db *sqlx.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+// CreateExport replaces the previous exports of the user, so at most one
+// archive per user is stored.
+func (r *DataExportRepository) CreateExport(ctx context.Context, export *models.DataExport) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM DataExports WHERE user_id = $1`, export.UserID); err != nil {
+		tx.Rollback()
+		log.Println("Failed to delete previous exports:", err)
+		return err
+	}
+
+	query := `INSERT INTO DataExports (user_id, status)
+	VALUES ($1, $2)
+	RETURNING id, created_at`
+
+	if err := tx.QueryRowContext(ctx, query, export.UserID, export.Status).Scan(&export.ID, &export.CreatedAt); err != nil {
+		tx.Rollback()
+		log.Println("Failed to create export:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DataExportRepository) GetLatestExport(ctx context.Context, userID int) (*models.DataExport, error) {
+	query := `SELECT id, user_id, status, expires_at, created_at, completed_at
+	FROM DataExports
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT 1`
+
+	export := &models.DataExport{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.ExpiresAt,
+		&export.CreatedAt,
+		&export.CompletedAt,
+	)
+	if err != nil {
+		log.Println("Failed to get export:", err)
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (r *DataExportRepository) CompleteExport(ctx context.Context, exportID int, archive []byte, expiresAt time.Time) error {
+	query := `UPDATE DataExports
+	SET status = 'ready', archive = $1, expires_at = $2, completed_at = NOW()
+	WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, archive, expiresAt, exportID); err != nil {
+		log.Println("Failed to complete export:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DataExportRepository) FailExport(ctx context.Context, exportID int) error {
+	query := `UPDATE DataExports
+	SET status = 'failed', completed_at = NOW()
+	WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, exportID); err != nil {
+		log.Println("Failed to mark export as failed:", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetArchive returns the archive of a ready export that has not expired.
+func (r *DataExportRepository) GetArchive(ctx context.Context, userID, exportID int) ([]byte, error) {
+	query := `SELECT archive
+	FROM DataExports
+	WHERE id = $1
+	AND user_id = $2
+	AND status = 'ready'
+	AND expires_at > NOW()`
+
+	var archive []byte
+	if err := r.db.QueryRowContext(ctx, query, exportID, userID).Scan(&archive); err != nil {
+		log.Println("Failed to get export archive:", err)
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// DeleteExpiredArchives frees the archives of expired exports and returns how
+// many were freed, the exports themselves are kept for their status.
+func (r *DataExportRepository) DeleteExpiredArchives(ctx context.Context) (int, error) {
+	query := `UPDATE DataExports
+	SET archive = NULL
+	WHERE archive IS NOT NULL
+	AND expires_at <= NOW()`
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		log.Println("Failed to delete expired export archives:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *DataExportRepository) GetProfile(ctx context.Context, userID int) (*models.ExportProfile, error) {
+	query := `SELECT id, username, email, email_verified_at, totp_enabled_at IS NOT NULL, created_at, updated_at
+	FROM Users
+	WHERE id = $1`
+
+	profile := &models.ExportProfile{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&profile.ID,
+		&profile.Username,
+		&profile.Email,
+		&profile.EmailVerifiedAt,
+		&profile.TwoFactorEnabled,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		log.Println("Failed to get export profile:", err)
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (r *DataExportRepository) GetWorkouts(ctx context.Context, userID int) ([]models.Workout, error) {
+	query := `SELECT id, user_id, date, COALESCE(notes, ''), started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active
+	FROM Workouts
+	WHERE user_id = $1
+	ORDER BY date, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get export workouts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	workouts := []models.Workout{}
+	for rows.Next() {
+		var workout models.Workout
+		err := rows.Scan(
+			&workout.ID,
+			&workout.UserID,
+			&workout.Date,
+			&workout.Notes,
+			&workout.StartedAt,
+			&workout.EndedAt,
+			&workout.TotalSets,
+			&workout.TotalVolume,
+			&workout.CreatedAt,
+			&workout.UpdatedAt,
+			&workout.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan export workout:", err)
+			return nil, err
+		}
+		workouts = append(workouts, workout)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return workouts, nil
+}
+
+func (r *DataExportRepository) GetWorkoutExercises(ctx context.Context, userID int) ([]models.ExportWorkoutExercise, error) {
+	query := `SELECT we.id, we.workout_id, we.exercise_id, e.name, we.duration_seconds, we.distance_meters, we.heart_rate_avg,
+	we.started_at, we.ended_at, COALESCE(we.notes, ''), we.created_at
+	FROM WorkoutExercises we
+	INNER JOIN Workouts w ON we.workout_id = w.id
+	INNER JOIN Exercises e ON we.exercise_id = e.id
+	WHERE w.user_id = $1
+	ORDER BY we.workout_id, we.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get export workout exercises:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := []models.ExportWorkoutExercise{}
+	for rows.Next() {
+		var exercise models.ExportWorkoutExercise
+		err := rows.Scan(
+			&exercise.ID,
+			&exercise.WorkoutID,
+			&exercise.ExerciseID,
+			&exercise.ExerciseName,
+			&exercise.DurationSeconds,
+			&exercise.DistanceMeters,
+			&exercise.HeartRateAvg,
+			&exercise.StartedAt,
+			&exercise.EndedAt,
+			&exercise.Notes,
+			&exercise.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan export workout exercise:", err)
+			return nil, err
+		}
+		exercises = append(exercises, exercise)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return exercises, nil
+}
+
+func (r *DataExportRepository) GetWorkoutSets(ctx context.Context, userID int) ([]models.ExportWorkoutSet, error) {
+	query := `SELECT ws.id, ws.workout_exercise_id, ws.set_number, ws.set_type, ws.reps, ws.weight, ws.rpe,
+	ws.duration_seconds, ws.distance_meters, ws.heart_rate_avg, ws.created_at
+	FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	INNER JOIN Workouts w ON we.workout_id = w.id
+	WHERE w.user_id = $1
+	ORDER BY ws.workout_exercise_id, ws.set_number`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get export workout sets:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	sets := []models.ExportWorkoutSet{}
+	for rows.Next() {
+		var set models.ExportWorkoutSet
+		err := rows.Scan(
+			&set.ID,
+			&set.WorkoutExerciseID,
+			&set.SetNumber,
+			&set.SetType,
+			&set.Reps,
+			&set.Weight,
+			&set.RPE,
+			&set.DurationSeconds,
+			&set.DistanceMeters,
+			&set.HeartRateAvg,
+			&set.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan export workout set:", err)
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+func (r *DataExportRepository) GetFoods(ctx context.Context, userID int) ([]models.ExportFood, error) {
+	query := `SELECT id, date, meal_type, meal_name, TO_CHAR(eaten_at, 'HH24:MI'), name, quantity, unit, weight_grams,
+		calories, protein, carbs, fat, COALESCE(is_active, TRUE)
+	FROM Foods
+	WHERE user_id = $1
+	ORDER BY date, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get export foods:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := []models.ExportFood{}
+	for rows.Next() {
+		var food models.ExportFood
+		err := rows.Scan(
+			&food.ID,
+			&food.Date,
+			&food.MealType,
+			&food.MealName,
+			&food.EatenAt,
+			&food.Name,
+			&food.Quantity,
+			&food.Unit,
+			&food.WeightGrams,
+			&food.Calories,
+			&food.Protein,
+			&food.Carbs,
+			&food.Fat,
+			&food.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan export food:", err)
+			return nil, err
+		}
+		foods = append(foods, food)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return foods, nil
+}
+
+func (r *DataExportRepository) GetIntegrations(ctx context.Context, userID int) ([]models.ExportIntegration, error) {
+	query := `SELECT 'fatsecret', created_at, updated_at
+	FROM FatsecretAuth
+	WHERE user_id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get export integrations:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	integrations := []models.ExportIntegration{}
+	for rows.Next() {
+		var integration models.ExportIntegration
+		if err := rows.Scan(&integration.Provider, &integration.ConnectedAt, &integration.UpdatedAt); err != nil {
+			log.Println("Failed to scan export integration:", err)
+			return nil, err
+		}
+		integrations = append(integrations, integration)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return integrations, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDataExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	export := &models.DataExport{UserID: 2, Status: models.DataExportStatusPending}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM DataExports WHERE user_id = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO DataExports (user_id, status)
+	VALUES ($1, $2)
+	RETURNING id, created_at`)).
+		WithArgs(2, "pending").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, now))
+	mock.ExpectCommit()
+
+	err = repo.CreateExport(context.Background(), export)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, export.ID)
+	assert.Equal(t, now, export.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLatestDataExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	expiresAt := now.Add(24 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, status, expires_at, created_at, completed_at
+	FROM DataExports
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT 1`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "expires_at", "created_at", "completed_at"}).
+			AddRow(4, 2, "ready", expiresAt, now, now))
+
+	export, err := repo.GetLatestExport(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DataExportStatusReady, export.Status)
+	assert.Equal(t, expiresAt, *export.ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteDataExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	archive := []byte("PK")
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE DataExports
+	SET status = 'ready', archive = $1, expires_at = $2, completed_at = NOW()
+	WHERE id = $3`)).
+		WithArgs(archive, expiresAt, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.CompleteExport(context.Background(), 4, archive, expiresAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDataExportArchive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT archive
+	FROM DataExports
+	WHERE id = $1
+	AND user_id = $2
+	AND status = 'ready'
+	AND expires_at > NOW()`)).
+		WithArgs(4, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"archive"}).AddRow([]byte("PK")))
+
+	archive, err := repo.GetArchive(context.Background(), 2, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("PK"), archive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredExportArchives(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE DataExports
+	SET archive = NULL
+	WHERE archive IS NOT NULL
+	AND expires_at <= NOW()`)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := repo.DeleteExpiredArchives(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExportWorkoutSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe",
+		"duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}).
+		AddRow(1, 3, 1, "warmup", 10, 40.0, nil, nil, nil, nil, now).
+		AddRow(2, 3, 2, "working", 8, 60.0, 8.5, nil, nil, nil, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	INNER JOIN Workouts w ON we.workout_id = w.id
+	WHERE w.user_id = $1`)).
+		WithArgs(2).
+		WillReturnRows(rows)
+
+	sets, err := repo.GetWorkoutSets(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, sets, 2)
+	assert.Nil(t, sets[0].RPE)
+	assert.Equal(t, 8.5, *sets[1].RPE)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExportIntegrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT 'fatsecret', created_at, updated_at
+	FROM FatsecretAuth
+	WHERE user_id = $1`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"provider", "created_at", "updated_at"}).AddRow("fatsecret", now, now))
+
+	integrations, err := repo.GetIntegrations(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ExportIntegration{{Provider: "fatsecret", ConnectedAt: now, UpdatedAt: now}}, integrations)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataExportRepositoryNegative(t *testing.T) {
+	t.Run("CreateExport insert error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM DataExports`)).
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO DataExports`)).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err = repo.CreateExport(context.Background(), &models.DataExport{UserID: 2, Status: models.DataExportStatusPending})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetArchive expired", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT archive`)).
+			WithArgs(4, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"archive"}))
+
+		_, err = repo.GetArchive(context.Background(), 2, 4)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetFoods query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewDataExportRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods`)).
+			WithArgs(2).
+			WillReturnError(errors.New("db error"))
+
+		foods, err := repo.GetFoods(context.Background(), 2)
+		assert.Error(t, err)
+		assert.Nil(t, foods)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
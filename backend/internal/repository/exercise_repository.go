@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ExerciseRepository struct {
@@ -19,8 +20,8 @@ func NewExerciseRepository(db *sqlx.DB) *ExerciseRepository {
 }
 
 func (r *ExerciseRepository) CreateExercise(ctx context.Context, exercise *models.Exercise) error {
-	query := `INSERT INTO Exercises (name, description, category_id)
-	VALUES ($1, $2, $3)
+	query := `INSERT INTO Exercises (name, description, category_id, tracking_type)
+	VALUES ($1, $2, $3, $4)
 	RETURNING id, created_at, updated_at`
 
 	err := r.db.QueryRowContext(
@@ -29,6 +30,7 @@ func (r *ExerciseRepository) CreateExercise(ctx context.Context, exercise *model
 		exercise.Name,
 		exercise.Description,
 		exercise.CategoryID,
+		exercise.TrackingType,
 	).Scan(
 		&exercise.ID,
 		&exercise.CreatedAt,
@@ -73,7 +75,7 @@ func (r *ExerciseRepository) GetExercises(ctx context.Context, filter *models.Ex
 		return nil, 0, err
 	}
 
-	query := "SELECT id, name, description, category_id, created_at, updated_at " + baseQuery
+	query := "SELECT id, name, description, category_id, tracking_type, created_at, updated_at " + baseQuery
 	query += fmt.Sprintf(" ORDER BY %s %s", filter.SortBy, filter.SortOrder)
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
 	args = append(args, filter.Limit, filter.Offset)
@@ -95,6 +97,7 @@ func (r *ExerciseRepository) GetExercises(ctx context.Context, filter *models.Ex
 			&exercise.Name,
 			&exercise.Description,
 			&exercise.CategoryID,
+			&exercise.TrackingType,
 			&exercise.CreatedAt,
 			&exercise.UpdatedAt,
 		)
@@ -115,7 +118,7 @@ func (r *ExerciseRepository) GetExercises(ctx context.Context, filter *models.Ex
 }
 
 func (r *ExerciseRepository) GetExercise(ctx context.Context, id int) (*models.Exercise, error) {
-	query := `SELECT id, name, description, category_id, created_at, updated_at
+	query := `SELECT id, name, description, category_id, tracking_type, created_at, updated_at
 	FROM Exercises
 	WHERE id = $1
 	AND is_active = TRUE`
@@ -128,6 +131,7 @@ func (r *ExerciseRepository) GetExercise(ctx context.Context, id int) (*models.E
 		&exercise.Name,
 		&exercise.Description,
 		&exercise.CategoryID,
+		&exercise.TrackingType,
 		&exercise.CreatedAt,
 		&exercise.UpdatedAt,
 	)
@@ -141,8 +145,8 @@ func (r *ExerciseRepository) GetExercise(ctx context.Context, id int) (*models.E
 
 func (r *ExerciseRepository) UpdateExercise(ctx context.Context, exercise *models.Exercise) error {
 	query := `UPDATE Exercises
-	SET name = $1, description = $2, category_id = $3, updated_at = NOW()
-	WHERE id = $4
+	SET name = $1, description = $2, category_id = $3, tracking_type = $4, updated_at = NOW()
+	WHERE id = $5
 	AND is_active = TRUE
 	RETURNING created_at, updated_at`
 
@@ -152,6 +156,7 @@ func (r *ExerciseRepository) UpdateExercise(ctx context.Context, exercise *model
 		exercise.Name,
 		exercise.Description,
 		exercise.CategoryID,
+		exercise.TrackingType,
 		exercise.ID,
 	).Scan(&exercise.CreatedAt, &exercise.UpdatedAt)
 	if err != nil {
@@ -183,3 +188,108 @@ func (r *ExerciseRepository) DeleteExercise(ctx context.Context, id int) (int, e
 
 	return int(rowsAffected), nil
 }
+
+func (r *ExerciseRepository) GetMuscleGroups(ctx context.Context) (*[]models.MuscleGroup, error) {
+	query := `SELECT id, name, slug
+	FROM MuscleGroups
+	ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("Failed to get muscle groups:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var muscleGroups []models.MuscleGroup
+	for rows.Next() {
+		var muscleGroup models.MuscleGroup
+		if err := rows.Scan(&muscleGroup.ID, &muscleGroup.Name, &muscleGroup.Slug); err != nil {
+			log.Println("Failed to scan muscle group:", err)
+			return nil, err
+		}
+
+		muscleGroups = append(muscleGroups, muscleGroup)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &muscleGroups, nil
+}
+
+func (r *ExerciseRepository) GetExerciseMuscleGroups(ctx context.Context, exerciseIDs []int) (*[]models.ExerciseMuscleGroup, error) {
+	query := `SELECT emg.exercise_id, emg.is_primary, mg.id, mg.name, mg.slug
+	FROM ExerciseMuscleGroups emg
+	INNER JOIN MuscleGroups mg ON emg.muscle_group_id = mg.id
+	WHERE emg.exercise_id = ANY($1)
+	ORDER BY emg.exercise_id, mg.id`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(exerciseIDs))
+	if err != nil {
+		log.Println("Failed to get exercise muscle groups:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var muscleGroups []models.ExerciseMuscleGroup
+	for rows.Next() {
+		var muscleGroup models.ExerciseMuscleGroup
+		err := rows.Scan(
+			&muscleGroup.ExerciseID,
+			&muscleGroup.IsPrimary,
+			&muscleGroup.MuscleGroup.ID,
+			&muscleGroup.MuscleGroup.Name,
+			&muscleGroup.MuscleGroup.Slug,
+		)
+		if err != nil {
+			log.Println("Failed to scan exercise muscle group:", err)
+			return nil, err
+		}
+
+		muscleGroups = append(muscleGroups, muscleGroup)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &muscleGroups, nil
+}
+
+func (r *ExerciseRepository) SetExerciseMuscleGroups(ctx context.Context, exerciseID int, primaryIDs, secondaryIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM ExerciseMuscleGroups WHERE exercise_id = $1`, exerciseID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to clear exercise muscle groups:", err)
+		return err
+	}
+
+	query := `INSERT INTO ExerciseMuscleGroups (exercise_id, muscle_group_id, is_primary)
+	SELECT $1, UNNEST($2::int[]), TRUE
+	UNION ALL
+	SELECT $1, UNNEST($3::int[]), FALSE`
+
+	_, err = tx.ExecContext(ctx, query, exerciseID, pq.Array(primaryIDs), pq.Array(secondaryIDs))
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to add exercise muscle groups:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Commit error:", err)
+		return err
+	}
+
+	return nil
+}
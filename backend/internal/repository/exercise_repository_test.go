@@ -10,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,17 +38,18 @@ func TestCreateExercise(t *testing.T) {
 	now := time.Now()
 
 	exercise := &models.Exercise{
-		Name:        "Приседания",
-		Description: "Упражнение для ног",
-		CategoryID:  1,
+		Name:         "Приседания",
+		Description:  "Упражнение для ног",
+		CategoryID:   1,
+		TrackingType: models.TrackingTypeWeightReps,
 	}
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO Exercises (name, description, category_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO Exercises (name, description, category_id, tracking_type)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at, updated_at
 	`)).
-		WithArgs(exercise.Name, exercise.Description, exercise.CategoryID).
+		WithArgs(exercise.Name, exercise.Description, exercise.CategoryID, exercise.TrackingType).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 				AddRow(1, now, now),
@@ -71,13 +73,13 @@ func TestGetExercise(t *testing.T) {
 	now := time.Now()
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		`SELECT id, name, description, category_id, created_at, updated_at
+		`SELECT id, name, description, category_id, tracking_type, created_at, updated_at
 		FROM Exercises
 		WHERE id = $1
 		AND is_active = TRUE`,
 	)).WithArgs(42).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "category_id", "created_at", "updated_at"}).
-			AddRow(42, "жим лёжа", "грудное упражнение", 2, now, now))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "category_id", "tracking_type", "created_at", "updated_at"}).
+			AddRow(42, "жим лёжа", "грудное упражнение", 2, "weight_reps", now, now))
 
 	ex, err := repo.GetExercise(ctx, 42)
 	if err != nil {
@@ -100,19 +102,20 @@ func TestUpdateExercise(t *testing.T) {
 	now := time.Now()
 
 	exercise := &models.Exercise{
-		ID:          5,
-		Name:        "присед",
-		Description: "ноги",
-		CategoryID:  1,
+		ID:           5,
+		Name:         "присед",
+		Description:  "ноги",
+		CategoryID:   1,
+		TrackingType: models.TrackingTypeWeightReps,
 	}
 
 	mock.ExpectQuery(regexp.QuoteMeta(
 		`UPDATE Exercises
-		SET name = $1, description = $2, category_id = $3, updated_at = NOW()
-		WHERE id = $4
+		SET name = $1, description = $2, category_id = $3, tracking_type = $4, updated_at = NOW()
+		WHERE id = $5
 		AND is_active = TRUE
 		RETURNING created_at, updated_at`,
-	)).WithArgs(exercise.Name, exercise.Description, exercise.CategoryID, exercise.ID).
+	)).WithArgs(exercise.Name, exercise.Description, exercise.CategoryID, exercise.TrackingType, exercise.ID).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).
 			AddRow(now.Add(-time.Hour), now))
 
@@ -171,11 +174,11 @@ func TestGetExercises(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		`SELECT id, name, description, category_id, created_at, updated_at FROM Exercises WHERE is_active = TRUE AND category_id = $1 AND LOWER(name) LIKE $2 ORDER BY name ASC LIMIT $3 OFFSET $4`,
+		`SELECT id, name, description, category_id, tracking_type, created_at, updated_at FROM Exercises WHERE is_active = TRUE AND category_id = $1 AND LOWER(name) LIKE $2 ORDER BY name ASC LIMIT $3 OFFSET $4`,
 	)).WithArgs(*filter.CategoryID, "%жим%", filter.Limit, filter.Offset).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "category_id", "created_at", "updated_at"}).
-			AddRow(10, "жим лёжа", "грудь", 3, now, now).
-			AddRow(11, "жим стоя", "плечи", 3, now, now))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "category_id", "tracking_type", "created_at", "updated_at"}).
+			AddRow(10, "жим лёжа", "грудь", 3, "weight_reps", now, now).
+			AddRow(11, "жим стоя", "плечи", 3, "weight_reps", now, now))
 
 	exs, total, err := repo.GetExercises(ctx, filter)
 	if err != nil {
@@ -227,7 +230,7 @@ func TestExerciseRepository_GetExercise_ErrorScan(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 	repo := NewExerciseRepository(sqlxDB)
 
-	mock.ExpectQuery(`SELECT id, name, description, category_id, created_at, updated_at FROM Exercises WHERE id = \$1 AND is_active = TRUE`).
+	mock.ExpectQuery(`SELECT id, name, description, category_id, tracking_type, created_at, updated_at FROM Exercises WHERE id = \$1 AND is_active = TRUE`).
 		WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description"}).AddRow(1, "name", "desc")) // не все колонки
 
@@ -254,7 +257,7 @@ func TestExerciseRepository_UpdateExercise_Error(t *testing.T) {
 	}
 
 	mock.ExpectQuery(`UPDATE Exercises`).
-		WithArgs(exercise.Name, exercise.Description, exercise.CategoryID, exercise.ID).
+		WithArgs(exercise.Name, exercise.Description, exercise.CategoryID, exercise.TrackingType, exercise.ID).
 		WillReturnError(errors.New("update error"))
 
 	err = repo.UpdateExercise(context.Background(), exercise)
@@ -284,3 +287,72 @@ func TestExerciseRepository_DeleteExercise_Error(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 }
+
+func TestGetMuscleGroups(t *testing.T) {
+	repo, mock, teardown := setupMockRepo(t)
+	defer teardown()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, slug
+	FROM MuscleGroups`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).
+			AddRow(1, "Грудь", "chest").
+			AddRow(2, "Спина", "back"))
+
+	muscleGroups, err := repo.GetMuscleGroups(context.Background())
+	require.NoError(t, err)
+	require.Len(t, *muscleGroups, 2)
+	require.Equal(t, "back", (*muscleGroups)[1].Slug)
+}
+
+func TestGetExerciseMuscleGroups(t *testing.T) {
+	repo, mock, teardown := setupMockRepo(t)
+	defer teardown()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT emg.exercise_id, emg.is_primary, mg.id, mg.name, mg.slug
+	FROM ExerciseMuscleGroups emg
+	INNER JOIN MuscleGroups mg ON emg.muscle_group_id = mg.id
+	WHERE emg.exercise_id = ANY($1)`)).
+		WithArgs(pq.Array([]int{1, 2})).
+		WillReturnRows(sqlmock.NewRows([]string{"exercise_id", "is_primary", "id", "name", "slug"}).
+			AddRow(1, true, 1, "Грудь", "chest").
+			AddRow(1, false, 6, "Трицепс", "triceps").
+			AddRow(2, true, 2, "Спина", "back"))
+
+	muscleGroups, err := repo.GetExerciseMuscleGroups(context.Background(), []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, *muscleGroups, 3)
+	require.False(t, (*muscleGroups)[1].IsPrimary)
+	require.Equal(t, "triceps", (*muscleGroups)[1].MuscleGroup.Slug)
+}
+
+func TestSetExerciseMuscleGroups(t *testing.T) {
+	repo, mock, teardown := setupMockRepo(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ExerciseMuscleGroups WHERE exercise_id = $1`)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO ExerciseMuscleGroups (exercise_id, muscle_group_id, is_primary)`)).
+		WithArgs(1, pq.Array([]int{1}), pq.Array([]int{4, 6})).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	err := repo.SetExerciseMuscleGroups(context.Background(), 1, []int{1}, []int{4, 6})
+	require.NoError(t, err)
+}
+
+func TestExerciseRepository_SetExerciseMuscleGroups_Error(t *testing.T) {
+	repo, mock, teardown := setupMockRepo(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ExerciseMuscleGroups`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO ExerciseMuscleGroups`)).
+		WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+
+	err := repo.SetExerciseMuscleGroups(context.Background(), 1, []int{100}, nil)
+	require.Error(t, err)
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// foodColumns returns the time of the entry as HH:MM.
+const foodColumns = `id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+	meal_type, meal_name, TO_CHAR(eaten_at, 'HH24:MI')`
+
 type FoodRepository struct {
 	db *sqlx.DB
 }
@@ -26,8 +30,9 @@ func (r *FoodRepository) CreateFood(ctx context.Context, foods *[]models.Food) e
 		return err
 	}
 
-	query := `INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	query := `INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`
 
 	stmt, err := tx.PrepareContext(ctx, query)
@@ -51,6 +56,9 @@ func (r *FoodRepository) CreateFood(ctx context.Context, foods *[]models.Food) e
 			food.Protein,
 			food.Carbs,
 			food.Fat,
+			food.MealType,
+			food.MealName,
+			food.EatenAt,
 		).Scan(&(*foods)[i].ID)
 		if err != nil {
 			tx.Rollback()
@@ -69,11 +77,12 @@ func (r *FoodRepository) CreateFood(ctx context.Context, foods *[]models.Food) e
 }
 
 func (r *FoodRepository) GetFoodByDate(ctx context.Context, date time.Time, userID int) (*[]models.Food, error) {
-	query := `SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat
+	query := `SELECT ` + foodColumns + `
 	FROM Foods
 	WHERE user_id = $1
 	AND date::date = $2::date
-	AND is_active = TRUE`
+	AND is_active = TRUE
+	ORDER BY eaten_at NULLS LAST, id`
 
 	rows, err := r.db.QueryContext(
 		ctx,
@@ -86,30 +95,18 @@ func (r *FoodRepository) GetFoodByDate(ctx context.Context, date time.Time, user
 		log.Println("Query error:", err)
 		return nil, err
 	}
+	defer rows.Close()
 
 	var foods []models.Food
 
 	for rows.Next() {
-		var food models.Food
-
-		if err := rows.Scan(
-			&food.ID,
-			&food.UserID,
-			&food.Date,
-			&food.Name,
-			&food.Quantity,
-			&food.Uint,
-			&food.WeightGrams,
-			&food.Calories,
-			&food.Protein,
-			&food.Carbs,
-			&food.Fat,
-		); err != nil {
+		food, err := scanFood(rows)
+		if err != nil {
 			log.Println("Error scan rows:", err)
 			return nil, err
 		}
 
-		foods = append(foods, food)
+		foods = append(foods, *food)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -119,3 +116,141 @@ func (r *FoodRepository) GetFoodByDate(ctx context.Context, date time.Time, user
 
 	return &foods, nil
 }
+
+func (r *FoodRepository) GetFood(ctx context.Context, userID, foodID int) (*models.Food, error) {
+	query := `SELECT ` + foodColumns + `
+	FROM Foods
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`
+
+	food, err := scanFood(r.db.QueryRowContext(ctx, query, foodID, userID))
+	if err != nil {
+		log.Println("Failed to get food:", err)
+		return nil, err
+	}
+
+	return food, nil
+}
+
+// UpdateFoodQuantity sets the quantity of the entry and rescales its weight
+// and nutrients in proportion to the old quantity, entries without a
+// quantity are not matched.
+func (r *FoodRepository) UpdateFoodQuantity(ctx context.Context, userID, foodID int, quantity float64) (*models.Food, error) {
+	query := `UPDATE Foods
+	SET quantity = $1,
+		weight_grams = ROUND((weight_grams * $1 / quantity)::numeric, 2),
+		calories = ROUND((calories * $1 / quantity)::numeric, 2),
+		protein = ROUND((protein * $1 / quantity)::numeric, 2),
+		carbs = ROUND((carbs * $1 / quantity)::numeric, 2),
+		fat = ROUND((fat * $1 / quantity)::numeric, 2)
+	WHERE id = $2
+	AND user_id = $3
+	AND is_active = TRUE
+	AND quantity > 0
+	RETURNING ` + foodColumns
+
+	food, err := scanFood(r.db.QueryRowContext(
+		ctx,
+		query,
+		quantity,
+		foodID,
+		userID,
+	))
+	if err != nil {
+		log.Println("Failed to update food:", err)
+		return nil, err
+	}
+
+	return food, nil
+}
+
+func (r *FoodRepository) DeleteFood(ctx context.Context, userID, foodID int) (int, error) {
+	query := `UPDATE Foods
+	SET is_active = FALSE
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, foodID, userID)
+	if err != nil {
+		log.Println("Failed to delete food:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to delete food result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// CopyMeal copies the active entries of the meal from one date to another
+// with their nutrients and times and returns the new entries.
+func (r *FoodRepository) CopyMeal(ctx context.Context, userID int, fromDate time.Time, mealType, mealName string, toDate time.Time) ([]models.Food, error) {
+	query := `INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	SELECT user_id, $5::timestamp, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at
+	FROM Foods
+	WHERE user_id = $1
+	AND date::date = $2::date
+	AND meal_type = $3
+	AND meal_name = $4
+	AND is_active = TRUE
+	ORDER BY eaten_at NULLS LAST, id
+	RETURNING ` + foodColumns
+
+	rows, err := r.db.QueryContext(ctx, query, userID, fromDate, mealType, mealName, toDate)
+	if err != nil {
+		log.Println("Failed to copy meal:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := []models.Food{}
+	for rows.Next() {
+		food, err := scanFood(rows)
+		if err != nil {
+			log.Println("Failed to scan food:", err)
+			return nil, err
+		}
+
+		foods = append(foods, *food)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return foods, nil
+}
+
+func scanFood(row rowScanner) (*models.Food, error) {
+	var food models.Food
+
+	err := row.Scan(
+		&food.ID,
+		&food.UserID,
+		&food.Date,
+		&food.Name,
+		&food.Quantity,
+		&food.Uint,
+		&food.WeightGrams,
+		&food.Calories,
+		&food.Protein,
+		&food.Carbs,
+		&food.Fat,
+		&food.MealType,
+		&food.MealName,
+		&food.EatenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &food, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
 	"fmt"
 	"regexp"
 	"testing"
@@ -13,6 +14,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var foodRowColumns = []string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams", "calories", "protein", "carbs", "fat",
+	"meal_type", "meal_name", "eaten_at"}
+
 func TestCreateFood(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -23,19 +27,24 @@ func TestCreateFood(t *testing.T) {
 
 	ctx := context.Background()
 	now := time.Now()
+	eatenAt := "08:30"
 	foods := []models.Food{
-		{UserID: 1, Date: now, Name: "apple", Quantity: 2, Uint: "pcs", WeightGrams: 150, Calories: 95, Protein: 0.5, Carbs: 25, Fat: 0.3},
-		{UserID: 1, Date: now, Name: "banana", Quantity: 1, Uint: "pcs", WeightGrams: 120, Calories: 105, Protein: 1.3, Carbs: 27, Fat: 0.4},
+		{UserID: 1, Date: now, Name: "apple", Quantity: 2, Uint: "pcs", WeightGrams: 150, Calories: 95, Protein: 0.5, Carbs: 25, Fat: 0.3,
+			MealType: models.MealBreakfast, EatenAt: &eatenAt},
+		{UserID: 1, Date: now, Name: "banana", Quantity: 1, Uint: "pcs", WeightGrams: 120, Calories: 105, Protein: 1.3, Carbs: 27, Fat: 0.4,
+			MealType: models.MealBreakfast, EatenAt: &eatenAt},
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`))
 
 	for i := range foods {
-		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`)).
 			WithArgs(
 				foods[i].UserID,
@@ -48,6 +57,9 @@ func TestCreateFood(t *testing.T) {
 				foods[i].Protein,
 				foods[i].Carbs,
 				foods[i].Fat,
+				foods[i].MealType,
+				foods[i].MealName,
+				foods[i].EatenAt,
 			).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1))
 	}
@@ -73,15 +85,17 @@ func TestGetFoodByDate(t *testing.T) {
 	userID := 2
 	date := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams", "calories", "protein", "carbs", "fat"}).
-		AddRow(1, userID, date, "milk", 1, "cup", 244, 150, 8, 12, 8).
-		AddRow(2, userID, date, "egg", 2, "pcs", 100, 155, 13, 1.1, 11)
+	rows := sqlmock.NewRows(foodRowColumns).
+		AddRow(1, userID, date, "milk", 1, "cup", 244, 150, 8, 12, 8, "breakfast", "", "08:30").
+		AddRow(2, userID, date, "egg", 2, "pcs", 100, 155, 13, 1.1, 11, "snack", "", nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+	meal_type, meal_name, TO_CHAR(eaten_at, 'HH24:MI')
 	FROM Foods
 	WHERE user_id = $1
 	AND date::date = $2::date
-	AND is_active = TRUE`)).
+	AND is_active = TRUE
+	ORDER BY eaten_at NULLS LAST, id`)).
 		WithArgs(userID, date).
 		WillReturnRows(rows)
 
@@ -89,6 +103,8 @@ func TestGetFoodByDate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, *result, 2)
 	assert.Equal(t, "milk", (*result)[0].Name)
+	assert.Equal(t, "08:30", *(*result)[0].EatenAt)
+	assert.Nil(t, (*result)[1].EatenAt)
 	assert.EqualValues(t, 155, (*result)[1].Calories)
 	assert.EqualValues(t, 155, (*result)[1].Calories)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -106,8 +122,9 @@ func TestCreateFood_PrepareError(t *testing.T) {
 	foods := []models.Food{{UserID: 1}}
 
 	mock.ExpectBegin()
-	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`)).
 		WillReturnError(fmt.Errorf("prepare failed"))
 	mock.ExpectRollback()
@@ -127,20 +144,24 @@ func TestCreateFood_CommitError(t *testing.T) {
 
 	ctx := context.Background()
 	now := time.Now()
-	foods := []models.Food{{UserID: 1, Date: now, Name: "a", Quantity: 1, Uint: "u", WeightGrams: 10, Calories: 10, Protein: 1, Carbs: 1, Fat: 1}}
+	foods := []models.Food{{UserID: 1, Date: now, Name: "a", Quantity: 1, Uint: "u", WeightGrams: 10, Calories: 10, Protein: 1, Carbs: 1, Fat: 1,
+		MealType: models.MealSnack}}
 
 	mock.ExpectBegin()
-	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`)).
 		WillBeClosed()
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods (user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::time)
 	RETURNING id`)).
 		WithArgs(
 			foods[0].UserID, foods[0].Date, foods[0].Name, foods[0].Quantity,
 			foods[0].Uint, foods[0].WeightGrams, foods[0].Calories,
 			foods[0].Protein, foods[0].Carbs, foods[0].Fat,
+			foods[0].MealType, foods[0].MealName, foods[0].EatenAt,
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit().WillReturnError(fmt.Errorf("commit failed"))
@@ -160,11 +181,13 @@ func TestGetFoodByDate_QueryError(t *testing.T) {
 
 	ctx := context.Background()
 	date := time.Now()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+	meal_type, meal_name, TO_CHAR(eaten_at, 'HH24:MI')
 	FROM Foods
 	WHERE user_id = $1
 	AND date::date = $2::date
-	AND is_active = TRUE`)).
+	AND is_active = TRUE
+	ORDER BY eaten_at NULLS LAST, id`)).
 		WillReturnError(fmt.Errorf("query failed"))
 
 	_, err = repo.GetFoodByDate(ctx, date, 1)
@@ -182,13 +205,15 @@ func TestGetFoodByDate_ScanError(t *testing.T) {
 
 	ctx := context.Background()
 	date := time.Now()
-	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams", "calories", "protein", "carbs", "fat"}).
-		AddRow(1, 1, date, nil, 1, "u", 100, 100, 10, 10, 10)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat
+	rows := sqlmock.NewRows(foodRowColumns).
+		AddRow(1, 1, date, nil, 1, "u", 100, 100, 10, 10, 10, "snack", "", nil)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+	meal_type, meal_name, TO_CHAR(eaten_at, 'HH24:MI')
 	FROM Foods
 	WHERE user_id = $1
 	AND date::date = $2::date
-	AND is_active = TRUE`)).
+	AND is_active = TRUE
+	ORDER BY eaten_at NULLS LAST, id`)).
 		WithArgs(1, date).
 		WillReturnRows(rows)
 
@@ -196,3 +221,155 @@ func TestGetFoodByDate_ScanError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Scan")
 }
+
+func TestUpdateFoodQuantity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	ctx := context.Background()
+	date := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Foods
+	SET quantity = $1,
+		weight_grams = ROUND((weight_grams * $1 / quantity)::numeric, 2),
+		calories = ROUND((calories * $1 / quantity)::numeric, 2),
+		protein = ROUND((protein * $1 / quantity)::numeric, 2),
+		carbs = ROUND((carbs * $1 / quantity)::numeric, 2),
+		fat = ROUND((fat * $1 / quantity)::numeric, 2)
+	WHERE id = $2
+	AND user_id = $3
+	AND is_active = TRUE
+	AND quantity > 0`)).
+		WithArgs(3.0, 7, 2).
+		WillReturnRows(sqlmock.NewRows(foodRowColumns).
+			AddRow(7, 2, date, "egg", 3, "pcs", 150, 232.5, 19.5, 1.65, 16.5, "breakfast", "", "08:30"))
+
+	food, err := repo.UpdateFoodQuantity(ctx, 2, 7, 3)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, food.Quantity)
+	assert.EqualValues(t, 232.5, food.Calories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateFoodQuantity_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Foods`)).
+		WithArgs(3.0, 7, 2).
+		WillReturnRows(sqlmock.NewRows(foodRowColumns))
+
+	food, err := repo.UpdateFoodQuantity(context.Background(), 2, 7, 3)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, food)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	date := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`)).
+		WithArgs(7, 2).
+		WillReturnRows(sqlmock.NewRows(foodRowColumns).
+			AddRow(7, 2, date, "egg", 0, "pcs", 0, 155, 13, 1.1, 11, "unspecified", "", nil))
+
+	food, err := repo.GetFood(context.Background(), 2, 7)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, food.Quantity)
+	assert.Equal(t, "egg", food.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Foods
+	SET is_active = FALSE
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`)).
+		WithArgs(7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rowsAffected, err := repo.DeleteFood(context.Background(), 2, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyMeal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	fromDate := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2025, 5, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, $5::timestamp, name, quantity, unit, weight_grams, calories, protein, carbs, fat,
+		meal_type, meal_name, eaten_at
+	FROM Foods
+	WHERE user_id = $1
+	AND date::date = $2::date
+	AND meal_type = $3
+	AND meal_name = $4
+	AND is_active = TRUE`)).
+		WithArgs(2, fromDate, "custom", "Pre-workout", toDate).
+		WillReturnRows(sqlmock.NewRows(foodRowColumns).
+			AddRow(9, 2, toDate, "banana", 1, "pcs", 120, 105, 1.3, 27, 0.4, "custom", "Pre-workout", "17:00").
+			AddRow(10, 2, toDate, "coffee", 1, "cup", 240, 2, 0.3, 0, 0, "custom", "Pre-workout", "17:00"))
+
+	foods, err := repo.CopyMeal(context.Background(), 2, fromDate, "custom", "Pre-workout", toDate)
+	assert.NoError(t, err)
+	assert.Len(t, foods, 2)
+	assert.Equal(t, toDate, foods[0].Date)
+	assert.Equal(t, "Pre-workout", foods[1].MealName)
+	assert.Equal(t, "17:00", *foods[1].EatenAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyMeal_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewFoodRepository(sqlxDB)
+
+	fromDate := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2025, 5, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, fromDate, "lunch", "", toDate).
+		WillReturnRows(sqlmock.NewRows(foodRowColumns))
+
+	foods, err := repo.CopyMeal(context.Background(), 2, fromDate, "lunch", "", toDate)
+	assert.NoError(t, err)
+	assert.Empty(t, foods)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PersonalRecordRepository struct {
+	db *sqlx.DB
+}
+
+func NewPersonalRecordRepository(db *sqlx.DB) *PersonalRecordRepository {
+	return &PersonalRecordRepository{db: db}
+}
+
+func (r *PersonalRecordRepository) GetRecordSets(ctx context.Context, userID, exerciseID int) (*[]models.RecordSet, error) {
+	query := `SELECT w.id, w.date, we.id, ws.id, ws.reps, ws.weight
+	FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	INNER JOIN Workouts w ON we.workout_id = w.id
+	WHERE w.is_active = TRUE
+	AND w.user_id = $1
+	AND we.exercise_id = $2
+	AND w.date <= CURRENT_DATE
+	AND ws.set_type <> 'warmup'
+	AND ws.reps IS NOT NULL
+	ORDER BY w.date, w.id, we.id, ws.set_number`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, exerciseID)
+	if err != nil {
+		log.Println("Failed to get record sets:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sets []models.RecordSet
+	for rows.Next() {
+		var set models.RecordSet
+		err := rows.Scan(
+			&set.WorkoutID,
+			&set.WorkoutDate,
+			&set.WorkoutExerciseID,
+			&set.SetID,
+			&set.Reps,
+			&set.Weight,
+		)
+		if err != nil {
+			log.Println("Failed to scan record set:", err)
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &sets, nil
+}
+
+func (r *PersonalRecordRepository) GetCurrentRecords(ctx context.Context, userID int) (*[]models.PersonalRecord, error) {
+	query := `SELECT pr.id, pr.user_id, pr.exercise_id, e.name, pr.record_type, pr.value, pr.weight, pr.reps,
+	pr.workout_id, pr.workout_exercise_id, pr.achieved_at, pr.is_current, pr.created_at
+	FROM PersonalRecords pr
+	INNER JOIN Exercises e ON pr.exercise_id = e.id
+	WHERE pr.is_current = TRUE
+	AND pr.user_id = $1
+	ORDER BY e.name, pr.record_type, pr.weight DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get personal records:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+func (r *PersonalRecordRepository) GetRecordsByExerciseID(ctx context.Context, userID, exerciseID int) (*[]models.PersonalRecord, error) {
+	query := `SELECT pr.id, pr.user_id, pr.exercise_id, e.name, pr.record_type, pr.value, pr.weight, pr.reps,
+	pr.workout_id, pr.workout_exercise_id, pr.achieved_at, pr.is_current, pr.created_at
+	FROM PersonalRecords pr
+	INNER JOIN Exercises e ON pr.exercise_id = e.id
+	WHERE pr.user_id = $1
+	AND pr.exercise_id = $2
+	ORDER BY pr.achieved_at, pr.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, exerciseID)
+	if err != nil {
+		log.Println("Failed to get personal records:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+func (r *PersonalRecordRepository) ReplaceRecords(ctx context.Context, userID, exerciseID int, records *[]models.PersonalRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM PersonalRecords WHERE user_id = $1 AND exercise_id = $2`, userID, exerciseID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to clear personal records:", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO PersonalRecords (user_id, exercise_id, record_type, value, weight, reps, workout_id, workout_exercise_id, achieved_at, is_current)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id, created_at`)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Prepare statement error:", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for i, record := range *records {
+		err = stmt.QueryRowContext(
+			ctx,
+			userID,
+			exerciseID,
+			record.RecordType,
+			record.Value,
+			record.Weight,
+			record.Reps,
+			record.WorkoutID,
+			record.WorkoutExerciseID,
+			record.AchievedAt,
+			record.IsCurrent,
+		).Scan(
+			&(*records)[i].ID,
+			&(*records)[i].CreatedAt,
+		)
+		if err != nil {
+			tx.Rollback()
+			log.Println("Failed to save personal record:", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func scanPersonalRecords(rows *sql.Rows) (*[]models.PersonalRecord, error) {
+	var records []models.PersonalRecord
+
+	for rows.Next() {
+		var record models.PersonalRecord
+		err := rows.Scan(
+			&record.ID,
+			&record.UserID,
+			&record.ExerciseID,
+			&record.ExerciseName,
+			&record.RecordType,
+			&record.Value,
+			&record.Weight,
+			&record.Reps,
+			&record.WorkoutID,
+			&record.WorkoutExerciseID,
+			&record.AchievedAt,
+			&record.IsCurrent,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan personal record:", err)
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &records, nil
+}
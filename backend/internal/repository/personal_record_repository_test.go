@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var personalRecordColumns = []string{
+	"id", "user_id", "exercise_id", "name", "record_type", "value", "weight", "reps",
+	"workout_id", "workout_exercise_id", "achieved_at", "is_current", "created_at",
+}
+
+func TestGetRecordSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewPersonalRecordRepository(sqlxDB)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "date", "id", "id", "reps", "weight"}).
+		AddRow(1, date, 5, 10, 5, 100.0).
+		AddRow(1, date, 5, 11, 3, 110.0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT w.id, w.date, we.id, ws.id, ws.reps, ws.weight
+	FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	INNER JOIN Workouts w ON we.workout_id = w.id`)).
+		WithArgs(1, 2).
+		WillReturnRows(rows)
+
+	sets, err := repo.GetRecordSets(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, *sets, 2)
+	assert.Equal(t, 11, (*sets)[1].SetID)
+	assert.Equal(t, 110.0, (*sets)[1].Weight)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCurrentRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewPersonalRecordRepository(sqlxDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(personalRecordColumns).
+		AddRow(1, 1, 2, "Bench Press", models.RecordTypeMaxWeight, 110.0, 110.0, 3, 1, 5, now, true, now).
+		AddRow(2, 1, 2, "Bench Press", models.RecordTypeEstimated1RM, 120.0, 110.0, 3, 1, 5, now, true, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pr.id, pr.user_id, pr.exercise_id, e.name, pr.record_type, pr.value, pr.weight, pr.reps,
+	pr.workout_id, pr.workout_exercise_id, pr.achieved_at, pr.is_current, pr.created_at
+	FROM PersonalRecords pr
+	INNER JOIN Exercises e ON pr.exercise_id = e.id
+	WHERE pr.is_current = TRUE
+	AND pr.user_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	records, err := repo.GetCurrentRecords(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, *records, 2)
+	assert.Equal(t, "Bench Press", (*records)[0].ExerciseName)
+	assert.Equal(t, models.RecordTypeEstimated1RM, (*records)[1].RecordType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRecordsByExerciseID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewPersonalRecordRepository(sqlxDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(personalRecordColumns).
+		AddRow(1, 1, 2, "Squat", models.RecordTypeMaxWeight, 100.0, 100.0, 5, 1, 5, now.AddDate(0, 0, -7), false, now).
+		AddRow(2, 1, 2, "Squat", models.RecordTypeMaxWeight, 105.0, 105.0, 5, 2, 8, now, true, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pr.id, pr.user_id, pr.exercise_id, e.name, pr.record_type, pr.value, pr.weight, pr.reps,
+	pr.workout_id, pr.workout_exercise_id, pr.achieved_at, pr.is_current, pr.created_at
+	FROM PersonalRecords pr
+	INNER JOIN Exercises e ON pr.exercise_id = e.id
+	WHERE pr.user_id = $1
+	AND pr.exercise_id = $2`)).
+		WithArgs(1, 2).
+		WillReturnRows(rows)
+
+	records, err := repo.GetRecordsByExerciseID(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, *records, 2)
+	assert.False(t, (*records)[0].IsCurrent)
+	assert.True(t, (*records)[1].IsCurrent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewPersonalRecordRepository(sqlxDB)
+
+	now := time.Now()
+	records := []models.PersonalRecord{
+		{RecordType: models.RecordTypeMaxWeight, Value: 100, Weight: 100, Reps: 5, WorkoutID: 1, WorkoutExerciseID: 5, AchievedAt: now},
+		{RecordType: models.RecordTypeMaxWeight, Value: 105, Weight: 105, Reps: 5, WorkoutID: 2, WorkoutExerciseID: 8, AchievedAt: now, IsCurrent: true},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM PersonalRecords WHERE user_id = $1 AND exercise_id = $2`)).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO PersonalRecords`))
+	for i, record := range records {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO PersonalRecords`)).
+			WithArgs(1, 2, record.RecordType, record.Value, record.Weight, record.Reps, record.WorkoutID, record.WorkoutExerciseID, record.AchievedAt, record.IsCurrent).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(i+1, now))
+	}
+	mock.ExpectCommit()
+
+	err = repo.ReplaceRecords(context.Background(), 1, 2, &records)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, records[0].ID)
+	assert.Equal(t, 2, records[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonalRecordRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewPersonalRecordRepository(sqlxDB)
+
+	t.Run("GetRecordSets error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT w.id, w.date, we.id, ws.id`)).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetRecordSets(context.Background(), 1, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("GetCurrentRecords scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows(personalRecordColumns).
+			AddRow("bad", 1, 2, "Squat", models.RecordTypeMaxWeight, 100.0, 100.0, 5, 1, 5, time.Now(), true, time.Now())
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pr.id, pr.user_id`)).
+			WillReturnRows(rows)
+
+		_, err := repository.GetCurrentRecords(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("ReplaceRecords rollback on delete error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM PersonalRecords`)).
+			WillReturnError(errors.New("delete error"))
+		mock.ExpectRollback()
+
+		err := repository.ReplaceRecords(context.Background(), 1, 2, &[]models.PersonalRecord{})
+		assert.Error(t, err)
+	})
+
+	t.Run("ReplaceRecords rollback on insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM PersonalRecords`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO PersonalRecords`))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO PersonalRecords`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.ReplaceRecords(context.Background(), 1, 2, &[]models.PersonalRecord{{RecordType: models.RecordTypeMaxWeight}})
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
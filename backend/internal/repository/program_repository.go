@@ -0,0 +1,835 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ProgramRepository struct {
+	db *sqlx.DB
+}
+
+func NewProgramRepository(db *sqlx.DB) *ProgramRepository {
+	return &ProgramRepository{db: db}
+}
+
+func (r *ProgramRepository) AddClient(ctx context.Context, trainerClient *models.TrainerClient) error {
+	query := `INSERT INTO TrainerClients (trainer_id, client_id)
+	VALUES ($1, $2)
+	RETURNING id, created_at, is_active`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		trainerClient.TrainerID,
+		trainerClient.ClientID,
+	).Scan(
+		&trainerClient.ID,
+		&trainerClient.CreatedAt,
+		&trainerClient.IsActive,
+	)
+
+	if err != nil {
+		log.Println("Failed to add client:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProgramRepository) GetClients(ctx context.Context, trainerID int) (*[]models.TrainerClient, error) {
+	query := `SELECT tc.id, tc.trainer_id, tc.client_id, u.username, u.email, tc.created_at, tc.accepted_at, tc.is_active
+	FROM TrainerClients tc
+	INNER JOIN Users u ON tc.client_id = u.id
+	WHERE tc.is_active = TRUE
+	AND tc.trainer_id = $1
+	ORDER BY u.username`
+
+	rows, err := r.db.QueryContext(ctx, query, trainerID)
+	if err != nil {
+		log.Println("Failed to get clients:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []models.TrainerClient
+	for rows.Next() {
+		var client models.TrainerClient
+		err := rows.Scan(
+			&client.ID,
+			&client.TrainerID,
+			&client.ClientID,
+			&client.Username,
+			&client.Email,
+			&client.CreatedAt,
+			&client.AcceptedAt,
+			&client.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan client:", err)
+			return nil, err
+		}
+
+		clients = append(clients, client)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &clients, nil
+}
+
+// IsTrainerClient reports whether the client accepted the invitation of the
+// trainer.
+func (r *ProgramRepository) IsTrainerClient(ctx context.Context, trainerID, clientID int) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM TrainerClients
+		WHERE trainer_id = $1
+		AND client_id = $2
+		AND is_active = TRUE
+		AND accepted_at IS NOT NULL
+	)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, trainerID, clientID).Scan(&exists); err != nil {
+		log.Println("Failed to check trainer client:", err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *ProgramRepository) RemoveClient(ctx context.Context, trainerID, clientID int) (int, error) {
+	query := `UPDATE TrainerClients
+	SET is_active = FALSE
+	WHERE trainer_id = $1
+	AND client_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, trainerID, clientID)
+	if err != nil {
+		log.Println("Failed to remove client:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to remove client result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *ProgramRepository) GetInvitations(ctx context.Context, clientID int) ([]models.TrainerInvitation, error) {
+	query := `SELECT tc.id, tc.trainer_id, u.username, tc.created_at
+	FROM TrainerClients tc
+	INNER JOIN Users u ON tc.trainer_id = u.id
+	WHERE tc.is_active = TRUE
+	AND tc.accepted_at IS NULL
+	AND tc.client_id = $1
+	ORDER BY tc.created_at, tc.id`
+
+	rows, err := r.db.QueryContext(ctx, query, clientID)
+	if err != nil {
+		log.Println("Failed to get invitations:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []models.TrainerInvitation{}
+	for rows.Next() {
+		var invitation models.TrainerInvitation
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.TrainerID,
+			&invitation.TrainerUsername,
+			&invitation.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan invitation:", err)
+			return nil, err
+		}
+
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *ProgramRepository) AcceptInvitation(ctx context.Context, clientID, invitationID int) (int, error) {
+	query := `UPDATE TrainerClients
+	SET accepted_at = NOW()
+	WHERE id = $1
+	AND client_id = $2
+	AND is_active = TRUE
+	AND accepted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, invitationID, clientID)
+	if err != nil {
+		log.Println("Failed to accept invitation:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to accept invitation result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *ProgramRepository) DeclineInvitation(ctx context.Context, clientID, invitationID int) (int, error) {
+	query := `UPDATE TrainerClients
+	SET is_active = FALSE
+	WHERE id = $1
+	AND client_id = $2
+	AND is_active = TRUE
+	AND accepted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, invitationID, clientID)
+	if err != nil {
+		log.Println("Failed to decline invitation:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to decline invitation result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// LeaveTrainer ends an accepted relationship from the client side and
+// deactivates the programs the trainer assigned to the client.
+func (r *ProgramRepository) LeaveTrainer(ctx context.Context, clientID, trainerID int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE TrainerClients
+	SET is_active = FALSE
+	WHERE trainer_id = $1
+	AND client_id = $2
+	AND is_active = TRUE
+	AND accepted_at IS NOT NULL`
+
+	result, err := tx.ExecContext(ctx, query, trainerID, clientID)
+	if err != nil {
+		log.Println("Failed to leave trainer:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to leave trainer result:", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	assignmentsQuery := `UPDATE ProgramAssignments
+	SET is_active = FALSE
+	WHERE trainer_id = $1
+	AND client_id = $2
+	AND is_active = TRUE`
+
+	_, err = tx.ExecContext(ctx, assignmentsQuery, trainerID, clientID)
+	if err != nil {
+		log.Println("Failed to cancel program assignments:", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *ProgramRepository) CreateProgram(ctx context.Context, program *models.Program) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO Programs (trainer_id, name, description, weeks)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		program.TrainerID,
+		program.Name,
+		program.Description,
+		program.Weeks,
+	).Scan(
+		&program.ID,
+		&program.CreatedAt,
+		&program.UpdatedAt,
+		&program.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create program:", err)
+		return err
+	}
+
+	if err = insertProgramWorkouts(ctx, tx, program); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProgramRepository) GetProgramsByTrainerID(ctx context.Context, trainerID int) (*[]models.Program, error) {
+	query := `SELECT id, trainer_id, name, description, weeks, created_at, updated_at, is_active
+	FROM Programs
+	WHERE is_active = TRUE
+	AND trainer_id = $1
+	ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, trainerID)
+	if err != nil {
+		log.Println("Failed to get programs:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var programs []models.Program
+	for rows.Next() {
+		var program models.Program
+		err := rows.Scan(
+			&program.ID,
+			&program.TrainerID,
+			&program.Name,
+			&program.Description,
+			&program.Weeks,
+			&program.CreatedAt,
+			&program.UpdatedAt,
+			&program.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan program:", err)
+			return nil, err
+		}
+
+		programs = append(programs, program)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &programs, nil
+}
+
+func (r *ProgramRepository) GetProgramByTrainerID(ctx context.Context, trainerID, programID int) (*models.Program, error) {
+	query := `SELECT id, trainer_id, name, description, weeks, created_at, updated_at, is_active
+	FROM Programs
+	WHERE is_active = TRUE
+	AND trainer_id = $1
+	AND id = $2`
+
+	var program models.Program
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		trainerID,
+		programID,
+	).Scan(
+		&program.ID,
+		&program.TrainerID,
+		&program.Name,
+		&program.Description,
+		&program.Weeks,
+		&program.CreatedAt,
+		&program.UpdatedAt,
+		&program.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to get program:", err)
+		return nil, err
+	}
+
+	workoutsQuery := `SELECT pw.id, pw.program_id, pw.template_id, wt.name, pw.week, pw.day, pw.notes
+	FROM ProgramWorkouts pw
+	INNER JOIN WorkoutTemplates wt ON pw.template_id = wt.id
+	WHERE pw.program_id = $1
+	ORDER BY pw.week, pw.day`
+
+	rows, err := r.db.QueryContext(ctx, workoutsQuery, program.ID)
+	if err != nil {
+		log.Println("Failed to get program workouts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var programWorkout models.ProgramWorkout
+		err := rows.Scan(
+			&programWorkout.ID,
+			&programWorkout.ProgramID,
+			&programWorkout.TemplateID,
+			&programWorkout.TemplateName,
+			&programWorkout.Week,
+			&programWorkout.Day,
+			&programWorkout.Notes,
+		)
+		if err != nil {
+			log.Println("Failed to scan program workout:", err)
+			return nil, err
+		}
+
+		program.Workouts = append(program.Workouts, programWorkout)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &program, nil
+}
+
+func (r *ProgramRepository) UpdateProgram(ctx context.Context, program *models.Program) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE Programs
+	SET name = $1, description = $2, weeks = $3, updated_at = NOW()
+	WHERE id = $4
+	AND trainer_id = $5
+	AND is_active = TRUE
+	RETURNING created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		program.Name,
+		program.Description,
+		program.Weeks,
+		program.ID,
+		program.TrainerID,
+	).Scan(
+		&program.CreatedAt,
+		&program.UpdatedAt,
+		&program.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to update program:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM ProgramWorkouts WHERE program_id = $1`, program.ID)
+	if err != nil {
+		log.Println("Failed to clear program workouts:", err)
+		return err
+	}
+
+	if err = insertProgramWorkouts(ctx, tx, program); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProgramRepository) DeleteProgram(ctx context.Context, trainerID, programID int) (int, error) {
+	query := `UPDATE Programs
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE id = $1
+	AND trainer_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, programID, trainerID)
+	if err != nil {
+		log.Println("Failed to delete program:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to delete program result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *ProgramRepository) AssignProgram(ctx context.Context, assignment *models.ProgramAssignment, program *models.Program) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO ProgramAssignments (program_id, trainer_id, client_id, start_date)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		assignment.ProgramID,
+		assignment.TrainerID,
+		assignment.ClientID,
+		assignment.StartDate,
+	).Scan(
+		&assignment.ID,
+		&assignment.CreatedAt,
+		&assignment.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to assign program:", err)
+		return err
+	}
+
+	scheduleQuery := `INSERT INTO ScheduledWorkouts (assignment_id, workout_id, week, day, scheduled_date)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	for _, programWorkout := range program.Workouts {
+		notes := programWorkout.Notes
+		if notes == "" {
+			notes = programWorkout.TemplateName
+		}
+
+		workout := models.Workout{
+			UserID: assignment.ClientID,
+			Date:   assignment.StartDate.AddDate(0, 0, (programWorkout.Week-1)*7+programWorkout.Day-1),
+			Notes:  notes,
+		}
+
+		if err = instantiateTemplate(ctx, tx, &workout, programWorkout.TemplateID); err != nil {
+			return err
+		}
+
+		scheduled := models.ScheduledWorkout{
+			AssignmentID:  assignment.ID,
+			WorkoutID:     workout.ID,
+			Week:          programWorkout.Week,
+			Day:           programWorkout.Day,
+			ScheduledDate: workout.Date,
+			Notes:         workout.Notes,
+		}
+
+		err = tx.QueryRowContext(
+			ctx,
+			scheduleQuery,
+			scheduled.AssignmentID,
+			scheduled.WorkoutID,
+			scheduled.Week,
+			scheduled.Day,
+			scheduled.ScheduledDate,
+		).Scan(&scheduled.ID)
+		if err != nil {
+			log.Println("Failed to schedule workout:", err)
+			return err
+		}
+
+		assignment.ScheduledWorkouts = append(assignment.ScheduledWorkouts, scheduled)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProgramRepository) GetAssignmentsByClientID(ctx context.Context, clientID int) (*[]models.ProgramAssignment, error) {
+	query := `SELECT pa.id, pa.program_id, p.name, pa.trainer_id, u.username, pa.client_id, pa.start_date, pa.created_at, pa.is_active
+	FROM ProgramAssignments pa
+	INNER JOIN Programs p ON pa.program_id = p.id
+	INNER JOIN Users u ON pa.trainer_id = u.id
+	WHERE pa.is_active = TRUE
+	AND pa.client_id = $1
+	ORDER BY pa.start_date DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, clientID)
+	if err != nil {
+		log.Println("Failed to get program assignments:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanProgramAssignments(rows)
+}
+
+func (r *ProgramRepository) GetAssignmentsByTrainerID(ctx context.Context, trainerID, clientID int) (*[]models.ProgramAssignment, error) {
+	query := `SELECT pa.id, pa.program_id, p.name, pa.trainer_id, u.username, pa.client_id, pa.start_date, pa.created_at, pa.is_active
+	FROM ProgramAssignments pa
+	INNER JOIN Programs p ON pa.program_id = p.id
+	INNER JOIN Users u ON pa.trainer_id = u.id
+	WHERE pa.is_active = TRUE
+	AND pa.trainer_id = $1
+	AND pa.client_id = $2
+	ORDER BY pa.start_date DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, trainerID, clientID)
+	if err != nil {
+		log.Println("Failed to get program assignments:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanProgramAssignments(rows)
+}
+
+func (r *ProgramRepository) GetAssignment(ctx context.Context, assignmentID int) (*models.ProgramAssignment, error) {
+	query := `SELECT pa.id, pa.program_id, p.name, pa.trainer_id, u.username, pa.client_id, pa.start_date, pa.created_at, pa.is_active
+	FROM ProgramAssignments pa
+	INNER JOIN Programs p ON pa.program_id = p.id
+	INNER JOIN Users u ON pa.trainer_id = u.id
+	WHERE pa.is_active = TRUE
+	AND pa.id = $1`
+
+	var assignment models.ProgramAssignment
+
+	err := r.db.QueryRowContext(ctx, query, assignmentID).Scan(
+		&assignment.ID,
+		&assignment.ProgramID,
+		&assignment.ProgramName,
+		&assignment.TrainerID,
+		&assignment.TrainerName,
+		&assignment.ClientID,
+		&assignment.StartDate,
+		&assignment.CreatedAt,
+		&assignment.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to get program assignment:", err)
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+func (r *ProgramRepository) GetScheduledWorkouts(ctx context.Context, assignmentID int) (*[]models.ScheduledWorkout, error) {
+	query := `SELECT sw.id, sw.assignment_id, sw.workout_id, sw.week, sw.day, sw.scheduled_date, w.notes
+	FROM ScheduledWorkouts sw
+	INNER JOIN Workouts w ON sw.workout_id = w.id
+	WHERE w.is_active = TRUE
+	AND sw.assignment_id = $1
+	ORDER BY sw.scheduled_date`
+
+	rows, err := r.db.QueryContext(ctx, query, assignmentID)
+	if err != nil {
+		log.Println("Failed to get scheduled workouts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scheduledWorkouts []models.ScheduledWorkout
+	for rows.Next() {
+		var scheduled models.ScheduledWorkout
+		err := rows.Scan(
+			&scheduled.ID,
+			&scheduled.AssignmentID,
+			&scheduled.WorkoutID,
+			&scheduled.Week,
+			&scheduled.Day,
+			&scheduled.ScheduledDate,
+			&scheduled.Notes,
+		)
+		if err != nil {
+			log.Println("Failed to scan scheduled workout:", err)
+			return nil, err
+		}
+
+		scheduledWorkouts = append(scheduledWorkouts, scheduled)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &scheduledWorkouts, nil
+}
+
+func (r *ProgramRepository) CancelAssignment(ctx context.Context, trainerID, assignmentID int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE ProgramAssignments
+	SET is_active = FALSE
+	WHERE id = $1
+	AND trainer_id = $2
+	AND is_active = TRUE`
+
+	result, err := tx.ExecContext(ctx, query, assignmentID, trainerID)
+	if err != nil {
+		log.Println("Failed to cancel program assignment:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to cancel program assignment result:", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	workoutsQuery := `UPDATE Workouts
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE is_active = TRUE
+	AND date >= CURRENT_DATE
+	AND id IN (
+		SELECT sw.workout_id
+		FROM ScheduledWorkouts sw
+		JOIN ProgramAssignments pa ON pa.id = sw.assignment_id AND pa.trainer_id = $2
+		WHERE sw.assignment_id = $1
+	)`
+
+	_, err = tx.ExecContext(ctx, workoutsQuery, assignmentID, trainerID)
+	if err != nil {
+		log.Println("Failed to remove upcoming scheduled workouts:", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func insertProgramWorkouts(ctx context.Context, tx *sql.Tx, program *models.Program) error {
+	query := `INSERT INTO ProgramWorkouts (program_id, template_id, week, day, notes)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	for i := range program.Workouts {
+		programWorkout := &program.Workouts[i]
+		programWorkout.ProgramID = program.ID
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			programWorkout.ProgramID,
+			programWorkout.TemplateID,
+			programWorkout.Week,
+			programWorkout.Day,
+			programWorkout.Notes,
+		).Scan(&programWorkout.ID)
+		if err != nil {
+			log.Println("Failed to add workout to program:", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanProgramAssignments(rows *sql.Rows) (*[]models.ProgramAssignment, error) {
+	var assignments []models.ProgramAssignment
+
+	for rows.Next() {
+		var assignment models.ProgramAssignment
+		err := rows.Scan(
+			&assignment.ID,
+			&assignment.ProgramID,
+			&assignment.ProgramName,
+			&assignment.TrainerID,
+			&assignment.TrainerName,
+			&assignment.ClientID,
+			&assignment.StartDate,
+			&assignment.CreatedAt,
+			&assignment.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan program assignment:", err)
+			return nil, err
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &assignments, nil
+}
@@ -0,0 +1,432 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddClient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+	client := &models.TrainerClient{TrainerID: 2, ClientID: 5}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO TrainerClients (trainer_id, client_id)
+	VALUES ($1, $2)
+	RETURNING id, created_at, is_active`)).
+		WithArgs(2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "is_active"}).AddRow(1, now, true))
+
+	err = repo.AddClient(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.ID)
+	assert.True(t, client.IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClients(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "trainer_id", "client_id", "username", "email", "created_at", "accepted_at", "is_active"}).
+		AddRow(1, 2, 5, "anna", "anna@example.com", now, now, true).
+		AddRow(2, 2, 6, "ivan", "ivan@example.com", now, nil, true)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT tc.id, tc.trainer_id, tc.client_id, u.username, u.email, tc.created_at, tc.accepted_at, tc.is_active
+	FROM TrainerClients tc`)).
+		WithArgs(2).
+		WillReturnRows(rows)
+
+	clients, err := repo.GetClients(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, *clients, 2)
+	assert.Equal(t, "ivan", (*clients)[1].Username)
+	assert.Equal(t, models.TrainerClientAccepted, (*clients)[0].Status())
+	assert.Equal(t, models.TrainerClientPending, (*clients)[1].Status())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsTrainerClient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`AND is_active = TRUE
+		AND accepted_at IS NOT NULL`)).
+		WithArgs(2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	isClient, err := repo.IsTrainerClient(context.Background(), 2, 5)
+	assert.NoError(t, err)
+	assert.True(t, isClient)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetInvitations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE tc.is_active = TRUE
+	AND tc.accepted_at IS NULL
+	AND tc.client_id = $1`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "trainer_id", "username", "created_at"}).AddRow(1, 2, "coach", now))
+
+	invitations, err := repo.GetInvitations(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, invitations, 1)
+	assert.Equal(t, "coach", invitations[0].TrainerUsername)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE TrainerClients
+	SET accepted_at = NOW()
+	WHERE id = $1
+	AND client_id = $2
+	AND is_active = TRUE
+	AND accepted_at IS NULL`)).
+		WithArgs(1, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.AcceptInvitation(context.Background(), 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeaveTrainer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewProgramRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE TrainerClients
+	SET is_active = FALSE
+	WHERE trainer_id = $1
+	AND client_id = $2
+	AND is_active = TRUE
+	AND accepted_at IS NOT NULL`)).
+		WithArgs(2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE ProgramAssignments
+	SET is_active = FALSE`)).
+		WithArgs(2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	n, err := repo.LeaveTrainer(context.Background(), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeaveTrainer_NotAccepted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewProgramRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE TrainerClients`)).
+		WithArgs(2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	n, err := repo.LeaveTrainer(context.Background(), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProgram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+	program := &models.Program{
+		TrainerID: 2,
+		Name:      "Beginner strength",
+		Weeks:     4,
+		Workouts: []models.ProgramWorkout{
+			{TemplateID: 1, Week: 1, Day: 1},
+			{TemplateID: 2, Week: 1, Day: 3},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Programs (trainer_id, name, description, weeks)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, updated_at, is_active`)).
+		WithArgs(program.TrainerID, program.Name, program.Description, program.Weeks).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(3, now, now, true))
+	for i, workout := range program.Workouts {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ProgramWorkouts (program_id, template_id, week, day, notes)`)).
+			WithArgs(3, workout.TemplateID, workout.Week, workout.Day, workout.Notes).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1))
+	}
+	mock.ExpectCommit()
+
+	err = repo.CreateProgram(context.Background(), program)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, program.ID)
+	assert.Equal(t, 3, program.Workouts[1].ProgramID)
+	assert.Equal(t, 2, program.Workouts[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProgramByTrainerID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, trainer_id, name, description, weeks, created_at, updated_at, is_active
+	FROM Programs`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "trainer_id", "name", "description", "weeks", "created_at", "updated_at", "is_active"}).
+			AddRow(3, 2, "Beginner strength", "", 4, now, now, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pw.id, pw.program_id, pw.template_id, wt.name, pw.week, pw.day, pw.notes`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "program_id", "template_id", "name", "week", "day", "notes"}).
+			AddRow(1, 3, 1, "Push day", 1, 1, "").
+			AddRow(2, 3, 2, "Pull day", 1, 3, ""))
+
+	program, err := repo.GetProgramByTrainerID(context.Background(), 2, 3)
+	assert.NoError(t, err)
+	assert.Len(t, program.Workouts, 2)
+	assert.Equal(t, "Pull day", program.Workouts[1].TemplateName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignProgram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	now := time.Now()
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	program := &models.Program{
+		ID:   3,
+		Name: "Beginner strength",
+		Workouts: []models.ProgramWorkout{
+			{TemplateID: 1, TemplateName: "Push day", Week: 1, Day: 1},
+			{TemplateID: 2, TemplateName: "Pull day", Week: 2, Day: 3},
+		},
+	}
+	assignment := &models.ProgramAssignment{ProgramID: 3, TrainerID: 2, ClientID: 5, StartDate: start}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ProgramAssignments (program_id, trainer_id, client_id, start_date)`)).
+		WithArgs(3, 2, 5, start).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "is_active"}).AddRow(7, now, true))
+
+	dates := []time.Time{start, start.AddDate(0, 0, 9)}
+	for i, workout := range program.Workouts {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes)`)).
+			WithArgs(5, dates[i], workout.TemplateName).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(100+i, now, now, true))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutExercises`)).
+			WithArgs(100+i, workout.TemplateID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutSets`)).
+			WithArgs(100 + i).
+			WillReturnResult(sqlmock.NewResult(0, 6))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ScheduledWorkouts (assignment_id, workout_id, week, day, scheduled_date)`)).
+			WithArgs(7, 100+i, workout.Week, workout.Day, dates[i]).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1))
+	}
+	mock.ExpectCommit()
+
+	err = repo.AssignProgram(context.Background(), assignment, program)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, assignment.ID)
+	assert.Len(t, assignment.ScheduledWorkouts, 2)
+	assert.Equal(t, 101, assignment.ScheduledWorkouts[1].WorkoutID)
+	assert.Equal(t, dates[1], assignment.ScheduledWorkouts[1].ScheduledDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetScheduledWorkouts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT sw.id, sw.assignment_id, sw.workout_id, sw.week, sw.day, sw.scheduled_date, w.notes
+	FROM ScheduledWorkouts sw`)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "assignment_id", "workout_id", "week", "day", "scheduled_date", "notes"}).
+			AddRow(1, 7, 100, 1, 1, date, "Push day"))
+
+	scheduled, err := repo.GetScheduledWorkouts(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Len(t, *scheduled, 1)
+	assert.Equal(t, 100, (*scheduled)[0].WorkoutID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelAssignment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE ProgramAssignments
+	SET is_active = FALSE`)).
+		WithArgs(7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Workouts
+	SET is_active = FALSE, updated_at = NOW()`)).
+		WithArgs(7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+
+	n, err := repo.CancelAssignment(context.Background(), 2, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelAssignment_NotOwned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewProgramRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE ProgramAssignments
+	SET is_active = FALSE`)).
+		WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	n, err := repo.CancelAssignment(context.Background(), 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProgramRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewProgramRepository(sqlxDB)
+
+	t.Run("AddClient error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO TrainerClients`)).
+			WillReturnError(errors.New("insert error"))
+
+		err := repository.AddClient(context.Background(), &models.TrainerClient{})
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateProgram rollback on workout insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Programs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(1, time.Now(), time.Now(), true))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ProgramWorkouts`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateProgram(context.Background(), &models.Program{
+			Workouts: []models.ProgramWorkout{{TemplateID: 1, Week: 1, Day: 1}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("AssignProgram rollback on instantiate error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO ProgramAssignments`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "is_active"}).AddRow(1, time.Now(), true))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.AssignProgram(context.Background(), &models.ProgramAssignment{StartDate: time.Now()}, &models.Program{
+			Workouts: []models.ProgramWorkout{{TemplateID: 1, Week: 1, Day: 1}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetAssignment error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pa.id, pa.program_id`)).
+			WillReturnError(errors.New("get error"))
+
+		_, err := repository.GetAssignment(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("CancelAssignment rollback on workouts update error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE ProgramAssignments`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Workouts`)).
+			WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
+
+		_, err := repository.CancelAssignment(context.Background(), 1, 1)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type RecipeRepository struct {
+	db *sqlx.DB
+}
+
+func NewRecipeRepository(db *sqlx.DB) *RecipeRepository {
+	return &RecipeRepository{db: db}
+}
+
+func (r *RecipeRepository) CreateRecipe(ctx context.Context, recipe *models.Recipe) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO Recipes (user_id, name, servings, notes)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		recipe.UserID,
+		recipe.Name,
+		recipe.Servings,
+		recipe.Notes,
+	).Scan(
+		&recipe.ID,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create recipe:", err)
+		return err
+	}
+
+	if err = insertRecipeIngredients(ctx, tx, recipe); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRecipesByUserID returns the recipes with their totals, without the
+// ingredients.
+func (r *RecipeRepository) GetRecipesByUserID(ctx context.Context, userID int) ([]models.Recipe, error) {
+	query := `SELECT r.id, r.user_id, r.name, r.servings, r.notes, r.created_at, r.updated_at, r.is_active,
+		COALESCE(SUM(ri.weight_grams), 0), COALESCE(SUM(ri.calories), 0), COALESCE(SUM(ri.protein), 0),
+		COALESCE(SUM(ri.carbs), 0), COALESCE(SUM(ri.fat), 0)
+	FROM Recipes r
+	LEFT JOIN RecipeIngredients ri ON ri.recipe_id = r.id
+	WHERE r.is_active = TRUE
+	AND r.user_id = $1
+	GROUP BY r.id
+	ORDER BY r.name, r.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get recipes:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipes := []models.Recipe{}
+	for rows.Next() {
+		var recipe models.Recipe
+		err := rows.Scan(
+			&recipe.ID,
+			&recipe.UserID,
+			&recipe.Name,
+			&recipe.Servings,
+			&recipe.Notes,
+			&recipe.CreatedAt,
+			&recipe.UpdatedAt,
+			&recipe.IsActive,
+			&recipe.TotalWeightGrams,
+			&recipe.Total.Calories,
+			&recipe.Total.Protein,
+			&recipe.Total.Carbs,
+			&recipe.Total.Fat,
+		)
+		if err != nil {
+			log.Println("Failed to scan recipe:", err)
+			return nil, err
+		}
+
+		recipes = append(recipes, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+func (r *RecipeRepository) GetRecipeByUserID(ctx context.Context, userID, recipeID int) (*models.Recipe, error) {
+	query := `SELECT id, user_id, name, servings, notes, created_at, updated_at, is_active
+	FROM Recipes
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`
+
+	var recipe models.Recipe
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		userID,
+		recipeID,
+	).Scan(
+		&recipe.ID,
+		&recipe.UserID,
+		&recipe.Name,
+		&recipe.Servings,
+		&recipe.Notes,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to get recipe:", err)
+		return nil, err
+	}
+
+	ingredientsQuery := `SELECT id, recipe_id, position, custom_food_id, provider_food_id, name, weight_grams,
+		calories, protein, carbs, fat
+	FROM RecipeIngredients
+	WHERE recipe_id = $1
+	ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, ingredientsQuery, recipe.ID)
+	if err != nil {
+		log.Println("Failed to get recipe ingredients:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ingredient models.RecipeIngredient
+		err := rows.Scan(
+			&ingredient.ID,
+			&ingredient.RecipeID,
+			&ingredient.Position,
+			&ingredient.CustomFoodID,
+			&ingredient.ProviderFoodID,
+			&ingredient.Name,
+			&ingredient.WeightGrams,
+			&ingredient.Calories,
+			&ingredient.Protein,
+			&ingredient.Carbs,
+			&ingredient.Fat,
+		)
+		if err != nil {
+			log.Println("Failed to scan recipe ingredient:", err)
+			return nil, err
+		}
+
+		recipe.Ingredients = append(recipe.Ingredients, ingredient)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	sumRecipeIngredients(&recipe)
+
+	return &recipe, nil
+}
+
+// UpdateRecipe replaces the recipe and its ingredients. Food diary entries
+// keep their own copy of the nutrients and are not changed.
+func (r *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *models.Recipe) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE Recipes
+	SET name = $1, servings = $2, notes = $3, updated_at = NOW()
+	WHERE id = $4
+	AND user_id = $5
+	AND is_active = TRUE
+	RETURNING created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		recipe.Name,
+		recipe.Servings,
+		recipe.Notes,
+		recipe.ID,
+		recipe.UserID,
+	).Scan(
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to update recipe:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM RecipeIngredients WHERE recipe_id = $1`, recipe.ID)
+	if err != nil {
+		log.Println("Failed to clear recipe ingredients:", err)
+		return err
+	}
+
+	if err = insertRecipeIngredients(ctx, tx, recipe); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RecipeRepository) DeleteRecipe(ctx context.Context, userID, recipeID int) (int, error) {
+	query := `UPDATE Recipes
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, recipeID, userID)
+	if err != nil {
+		log.Println("Failed to delete recipe:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to delete recipe result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func insertRecipeIngredients(ctx context.Context, tx *sql.Tx, recipe *models.Recipe) error {
+	query := `INSERT INTO RecipeIngredients (recipe_id, position, custom_food_id, provider_food_id, name, weight_grams,
+		calories, protein, carbs, fat)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id`
+
+	for i := range recipe.Ingredients {
+		ingredient := &recipe.Ingredients[i]
+		ingredient.RecipeID = recipe.ID
+		ingredient.Position = i + 1
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			ingredient.RecipeID,
+			ingredient.Position,
+			ingredient.CustomFoodID,
+			ingredient.ProviderFoodID,
+			ingredient.Name,
+			ingredient.WeightGrams,
+			ingredient.Calories,
+			ingredient.Protein,
+			ingredient.Carbs,
+			ingredient.Fat,
+		).Scan(&ingredient.ID)
+		if err != nil {
+			log.Println("Failed to add ingredient to recipe:", err)
+			return err
+		}
+	}
+
+	sumRecipeIngredients(recipe)
+
+	return nil
+}
+
+func sumRecipeIngredients(recipe *models.Recipe) {
+	recipe.TotalWeightGrams = 0
+	recipe.Total = models.Nutrients{}
+
+	for _, ingredient := range recipe.Ingredients {
+		recipe.TotalWeightGrams += ingredient.WeightGrams
+		recipe.Total.Calories += ingredient.Calories
+		recipe.Total.Protein += ingredient.Protein
+		recipe.Total.Carbs += ingredient.Carbs
+		recipe.Total.Fat += ingredient.Fat
+	}
+}
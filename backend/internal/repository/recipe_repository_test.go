@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRecipe(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	customFoodID := 5
+	recipe := &models.Recipe{
+		UserID:   2,
+		Name:     "Chicken rice",
+		Servings: 4,
+		Ingredients: []models.RecipeIngredient{
+			{CustomFoodID: &customFoodID, Name: "Chicken", WeightGrams: 500, Nutrients: models.Nutrients{Calories: 825, Protein: 155, Fat: 18}},
+			{ProviderFoodID: "fake-1", Name: "rice", WeightGrams: 300, Nutrients: models.Nutrients{Calories: 390, Protein: 8.1, Carbs: 84.6, Fat: 0.9}},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Recipes (user_id, name, servings, notes)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, updated_at, is_active`)).
+		WithArgs(2, "Chicken rice", 4.0, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(3, now, now, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO RecipeIngredients`)).
+		WithArgs(3, 1, 5, "", "Chicken", 500.0, 825.0, 155.0, 0.0, 18.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO RecipeIngredients`)).
+		WithArgs(3, 2, nil, "fake-1", "rice", 300.0, 390.0, 8.1, 84.6, 0.9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	mock.ExpectCommit()
+
+	err = repo.CreateRecipe(context.Background(), recipe)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, recipe.ID)
+	assert.Equal(t, 2, recipe.Ingredients[1].Position)
+	assert.Equal(t, 800.0, recipe.TotalWeightGrams)
+	assert.Equal(t, 1215.0, recipe.Total.Calories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRecipeByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name, servings, notes, created_at, updated_at, is_active
+	FROM Recipes
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "servings", "notes", "created_at", "updated_at", "is_active"}).
+			AddRow(3, 2, "Chicken rice", 4.0, "", now, now, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM RecipeIngredients
+	WHERE recipe_id = $1
+	ORDER BY position`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipe_id", "position", "custom_food_id", "provider_food_id", "name",
+			"weight_grams", "calories", "protein", "carbs", "fat"}).
+			AddRow(10, 3, 1, 5, "", "Chicken", 500.0, 825.0, 155.0, 0.0, 18.0).
+			AddRow(11, 3, 2, nil, "fake-1", "rice", 300.0, 390.0, 8.1, 84.6, 0.9))
+
+	recipe, err := repo.GetRecipeByUserID(context.Background(), 2, 3)
+	assert.NoError(t, err)
+	assert.Len(t, recipe.Ingredients, 2)
+	assert.Equal(t, 5, *recipe.Ingredients[0].CustomFoodID)
+	assert.Nil(t, recipe.Ingredients[1].CustomFoodID)
+	assert.Equal(t, 800.0, recipe.TotalWeightGrams)
+	assert.Equal(t, 163.1, recipe.Total.Protein)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRecipesByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Recipes r
+	LEFT JOIN RecipeIngredients ri ON ri.recipe_id = r.id
+	WHERE r.is_active = TRUE
+	AND r.user_id = $1
+	GROUP BY r.id`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "servings", "notes", "created_at", "updated_at", "is_active",
+			"weight_grams", "calories", "protein", "carbs", "fat"}).
+			AddRow(3, 2, "Chicken rice", 4.0, "", now, now, true, 800.0, 1215.0, 163.1, 84.6, 18.9))
+
+	recipes, err := repo.GetRecipesByUserID(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, recipes, 1)
+	assert.Equal(t, 1215.0, recipes[0].Total.Calories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecipeRepositoryNegative(t *testing.T) {
+	t.Run("UpdateRecipe not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Recipes`)).
+			WithArgs("Chicken rice", 4.0, "", 3, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "is_active"}))
+		mock.ExpectRollback()
+
+		err = repo.UpdateRecipe(context.Background(), &models.Recipe{ID: 3, UserID: 2, Name: "Chicken rice", Servings: 4})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CreateRecipe ingredient error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Recipes`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(3, now, now, true))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO RecipeIngredients`)).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err = repo.CreateRecipe(context.Background(), &models.Recipe{UserID: 2, Name: "Soup", Servings: 2,
+			Ingredients: []models.RecipeIngredient{{Name: "water", WeightGrams: 500}}})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DeleteRecipe not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewRecipeRepository(sqlx.NewDb(db, "sqlmock"))
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Recipes`)).
+			WithArgs(3, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rowsAffected, err := repo.DeleteRecipe(context.Background(), 2, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rowsAffected)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -6,12 +6,25 @@ type Repositories struct {
 	ExerciseRepo            *ExerciseRepository
 	CategoryRepo            *CategoryRepository
 	UserRepo                *UserRepository
+	AccountRepo             *AccountRepository
 	RoleRepo                *RoleRepository
 	DBHeathRepo             *DBHeathRepository
 	WorkoutRepo             *WorkoutRepository
 	WorkoutExerciseRepo     *WorkoutExerciseRepository
+	WorkoutSetRepo          *WorkoutSetRepository
+	WorkoutTemplateRepo     *WorkoutTemplateRepository
+	ProgramRepo             *ProgramRepository
+	PersonalRecordRepo      *PersonalRecordRepository
+	AnalyticsRepo           *AnalyticsRepository
 	FoodRepository          *FoodRepository
+	CustomFoodRepo          *CustomFoodRepository
+	RecipeRepo              *RecipeRepository
 	FatSecretAuthRepository *FatSecretAuthRepository
+	SessionRepo             *SessionRepository
+	UserTokenRepo           *UserTokenRepository
+	TwoFactorRepo           *TwoFactorRepository
+	APITokenRepo            *APITokenRepository
+	DataExportRepo          *DataExportRepository
 }
 
 func InitRepositories(dbConn *sqlx.DB) *Repositories {
@@ -19,11 +32,24 @@ func InitRepositories(dbConn *sqlx.DB) *Repositories {
 		ExerciseRepo:            NewExerciseRepository(dbConn),
 		CategoryRepo:            NewCategoryRepository(dbConn),
 		UserRepo:                NewUserRepository(dbConn),
+		AccountRepo:             NewAccountRepository(dbConn),
 		RoleRepo:                NewRoleRepository(dbConn),
 		DBHeathRepo:             NewDBHealthRepository(dbConn),
 		WorkoutRepo:             NewWorkoutRepository(dbConn),
 		WorkoutExerciseRepo:     NewWorkoutExerciseRepository(dbConn),
+		WorkoutSetRepo:          NewWorkoutSetRepository(dbConn),
+		WorkoutTemplateRepo:     NewWorkoutTemplateRepository(dbConn),
+		ProgramRepo:             NewProgramRepository(dbConn),
+		PersonalRecordRepo:      NewPersonalRecordRepository(dbConn),
+		AnalyticsRepo:           NewAnalyticsRepository(dbConn),
 		FoodRepository:          NewFoodRepository(dbConn),
+		CustomFoodRepo:          NewCustomFoodRepository(dbConn),
+		RecipeRepo:              NewRecipeRepository(dbConn),
 		FatSecretAuthRepository: NewFatSecretAuthRepository(dbConn),
+		SessionRepo:             NewSessionRepository(dbConn),
+		UserTokenRepo:           NewUserTokenRepository(dbConn),
+		TwoFactorRepo:           NewTwoFactorRepository(dbConn),
+		APITokenRepo:            NewAPITokenRepository(dbConn),
+		DataExportRepo:          NewDataExportRepository(dbConn),
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type RoleRepository struct {
@@ -17,10 +18,9 @@ func NewRoleRepository(db *sqlx.DB) *RoleRepository {
 }
 
 func (r *RoleRepository) GetRoleByID(ctx context.Context, roleID int) (*models.Role, error) {
-	query := `SELECT id, name, description
+	query := `SELECT id, name, COALESCE(description, '')
 	FROM Roles
-	WHERE id = $1
-	AND is_active = TRUE`
+	WHERE id = $1`
 	role := &models.Role{}
 
 	err := r.db.QueryRowContext(ctx, query, roleID).Scan(
@@ -36,3 +36,186 @@ func (r *RoleRepository) GetRoleByID(ctx context.Context, roleID int) (*models.R
 	return role, err
 
 }
+
+func (r *RoleRepository) GetRoles(ctx context.Context) (*[]models.Role, error) {
+	query := `SELECT r.id, r.name, COALESCE(r.description, ''),
+		COALESCE(ARRAY_AGG(p.name ORDER BY p.name) FILTER (WHERE p.id IS NOT NULL), '{}')
+	FROM Roles r
+	LEFT JOIN RolePermissions rp ON rp.role_id = r.id
+	LEFT JOIN Permissions p ON p.id = rp.permission_id
+	GROUP BY r.id
+	ORDER BY r.id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("Failed to get roles:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(
+			&role.ID,
+			&role.Name,
+			&role.Description,
+			pq.Array(&role.Permissions),
+		); err != nil {
+			log.Println("Failed to scan role:", err)
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &roles, nil
+}
+
+func (r *RoleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	query := `INSERT INTO Roles (name, description)
+	VALUES ($1, $2)
+	RETURNING id`
+
+	if err := r.db.QueryRowContext(ctx, query, role.Name, role.Description).Scan(&role.ID); err != nil {
+		log.Println("Failed to create role:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) GetPermissions(ctx context.Context) (*[]models.Permission, error) {
+	query := `SELECT id, name, COALESCE(description, '')
+	FROM Permissions
+	ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("Failed to get permissions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []models.Permission{}
+	for rows.Next() {
+		var permission models.Permission
+		if err := rows.Scan(&permission.ID, &permission.Name, &permission.Description); err != nil {
+			log.Println("Failed to scan permission:", err)
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &permissions, nil
+}
+
+// SetRolePermissions replaces the permissions of the role. Unknown names are
+// skipped, so they should be validated by the caller.
+func (r *RoleRepository) SetRolePermissions(ctx context.Context, roleID int, permissions []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM RolePermissions WHERE role_id = $1`, roleID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to clear role permissions:", err)
+		return err
+	}
+
+	query := `INSERT INTO RolePermissions (role_id, permission_id)
+	SELECT $1, id
+	FROM Permissions
+	WHERE name = ANY($2)`
+
+	_, err = tx.ExecContext(ctx, query, roleID, pq.Array(permissions))
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to add role permissions:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	query := `SELECT DISTINCT p.name
+	FROM Permissions p
+	INNER JOIN RolePermissions rp ON rp.permission_id = p.id
+	INNER JOIN UserRoles ur ON ur.role_id = rp.role_id
+	WHERE ur.user_id = $1
+	ORDER BY p.name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get user permissions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			log.Println("Failed to scan user permission:", err)
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// GetRoleUserIDs returns the users having the role, whose cached permissions
+// must be dropped after the role changes.
+func (r *RoleRepository) GetRoleUserIDs(ctx context.Context, roleID int) ([]int, error) {
+	query := `SELECT user_id
+	FROM UserRoles
+	WHERE role_id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, roleID)
+	if err != nil {
+		log.Println("Failed to get role users:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			log.Println("Failed to scan role user:", err)
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return userIDs, nil
+}
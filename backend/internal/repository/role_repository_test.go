@@ -3,12 +3,14 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,10 +33,9 @@ func TestGetRoleByID(t *testing.T) {
 	}
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, description
+		SELECT id, name, COALESCE(description, '')
 		FROM Roles
-		WHERE id = $1
-		AND is_active = TRUE`,
+		WHERE id = $1`,
 	)).WithArgs(roleID).WillReturnRows(
 		sqlmock.NewRows([]string{"id", "name", "description"}).
 			AddRow(expectedRole.ID, expectedRole.Name, expectedRole.Description),
@@ -59,7 +60,7 @@ func TestRoleRepository_GetRoleByID_Error(t *testing.T) {
 	ctx := context.Background()
 	roleID := 1
 
-	mock.ExpectQuery(`SELECT id, name, description FROM Roles WHERE id = \$1 AND is_active = TRUE`).
+	mock.ExpectQuery(`SELECT id, name, COALESCE\(description, ''\) FROM Roles WHERE id = \$1`).
 		WithArgs(roleID).
 		WillReturnError(errors.New("some db error"))
 
@@ -70,3 +71,185 @@ func TestRoleRepository_GetRoleByID_Error(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 }
+
+func TestGetRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "permissions"}).
+		AddRow(1, "admin", "Administrator role", "{categories:write,exercises:write}").
+		AddRow(2, "user", "User role", "{}")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Roles r
+	LEFT JOIN RolePermissions rp ON rp.role_id = r.id
+	LEFT JOIN Permissions p ON p.id = rp.permission_id
+	GROUP BY r.id`)).
+		WillReturnRows(rows)
+
+	roles, err := repo.GetRoles(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, *roles, 2)
+	assert.Equal(t, []string{"categories:write", "exercises:write"}, (*roles)[0].Permissions)
+	assert.Empty(t, (*roles)[1].Permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Roles (name, description)
+	VALUES ($1, $2)
+	RETURNING id`)).
+		WithArgs("nutritionist", "Nutrition consultant").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	role := &models.Role{Name: "nutritionist", Description: "Nutrition consultant"}
+	err = repo.CreateRole(context.Background(), role)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, role.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, COALESCE(description, '')
+	FROM Permissions
+	ORDER BY name`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description"}).
+			AddRow(2, "categories:write", "").
+			AddRow(1, "exercises:write", "Manage exercises"))
+
+	permissions, err := repo.GetPermissions(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, *permissions, 2)
+	assert.Equal(t, "categories:write", (*permissions)[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetRolePermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	permissions := []string{"exercises:write", "categories:write"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM RolePermissions WHERE role_id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RolePermissions (role_id, permission_id)
+	SELECT $1, id
+	FROM Permissions
+	WHERE name = ANY($2)`)).
+		WithArgs(3, pq.Array(permissions)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = repo.SetRolePermissions(context.Background(), 3, permissions)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserPermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT p.name
+	FROM Permissions p
+	INNER JOIN RolePermissions rp ON rp.permission_id = p.id
+	INNER JOIN UserRoles ur ON ur.role_id = rp.role_id
+	WHERE ur.user_id = $1`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).
+			AddRow("clients:manage").
+			AddRow("exercises:write"))
+
+	permissions, err := repo.GetUserPermissions(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"clients:manage", "exercises:write"}, permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRoleUserIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewRoleRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id
+	FROM UserRoles
+	WHERE role_id = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2).AddRow(7))
+
+	userIDs, err := repo.GetRoleUserIDs(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 7}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewRoleRepository(sqlxDB)
+
+	t.Run("GetRoleByID not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM Roles`)).
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repository.GetRoleByID(context.Background(), 99)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("SetRolePermissions insert error rolls back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM RolePermissions`)).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RolePermissions`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.SetRolePermissions(context.Background(), 3, []string{"exercises:write"})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetUserPermissions query error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT p.name`)).
+			WithArgs(2).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetUserPermissions(context.Background(), 2)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.UserSession, tokenHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	query := `INSERT INTO UserSessions (user_id, user_agent, ip_address, expires_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, last_used_at`
+
+	err = tx.QueryRowContext(ctx, query, session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt).Scan(
+		&session.ID,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to create session:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO RefreshTokens (session_id, token_hash) VALUES ($1, $2)`, session.ID, tokenHash)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to create refresh token:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `SELECT rt.id, rt.session_id, s.user_id, rt.used_at, s.expires_at, s.revoked_at
+	FROM RefreshTokens rt
+	INNER JOIN UserSessions s ON rt.session_id = s.id
+	WHERE rt.token_hash = $1`
+
+	var token models.RefreshToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.SessionID,
+		&token.UserID,
+		&token.UsedAt,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+	)
+	if err != nil {
+		log.Println("Failed to get refresh token:", err)
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken marks the token as used and issues its successor in the
+// same session. It returns 0 when the token was already used, which means the
+// token was replayed.
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, token *models.RefreshToken, newTokenHash string, expiresAt time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE RefreshTokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`, token.ID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to use refresh token:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to use refresh token result:", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO RefreshTokens (session_id, token_hash) VALUES ($1, $2)`, token.SessionID, newTokenHash)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to create refresh token:", err)
+		return 0, err
+	}
+
+	query := `UPDATE UserSessions
+	SET last_used_at = NOW(), expires_at = $1
+	WHERE id = $2`
+
+	_, err = tx.ExecContext(ctx, query, expiresAt, token.SessionID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to update session:", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *SessionRepository) GetActiveSessions(ctx context.Context, userID int) (*[]models.UserSession, error) {
+	query := `SELECT id, user_id, user_agent, ip_address, created_at, last_used_at, expires_at, revoked_at
+	FROM UserSessions
+	WHERE user_id = $1
+	AND revoked_at IS NULL
+	AND expires_at > NOW()
+	ORDER BY last_used_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get sessions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.UserSession
+	for rows.Next() {
+		var session models.UserSession
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan session:", err)
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &sessions, nil
+}
+
+func (r *SessionRepository) RevokeSession(ctx context.Context, userID, sessionID int) (int, error) {
+	query := `UPDATE UserSessions
+	SET revoked_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		log.Println("Failed to revoke session:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to revoke session result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *SessionRepository) RevokeAllSessions(ctx context.Context, userID int) (int, error) {
+	query := `UPDATE UserSessions
+	SET revoked_at = NOW()
+	WHERE user_id = $1
+	AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to revoke sessions:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to revoke sessions result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
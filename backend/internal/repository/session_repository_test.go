@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionColumns = []string{
+	"id", "user_id", "user_agent", "ip_address", "created_at", "last_used_at", "expires_at", "revoked_at",
+}
+
+func TestCreateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	now := time.Now()
+	session := &models.UserSession{
+		UserID:    3,
+		UserAgent: "Mozilla/5.0",
+		IPAddress: "10.0.0.1",
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO UserSessions (user_id, user_agent, ip_address, expires_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, last_used_at`)).
+		WithArgs(session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "last_used_at"}).AddRow(7, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RefreshTokens (session_id, token_hash) VALUES ($1, $2)`)).
+		WithArgs(7, "hash").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateSession(context.Background(), session, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, session.ID)
+	assert.Equal(t, now, session.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	expiresAt := time.Now().Add(time.Hour)
+	usedAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT rt.id, rt.session_id, s.user_id, rt.used_at, s.expires_at, s.revoked_at
+	FROM RefreshTokens rt
+	INNER JOIN UserSessions s ON rt.session_id = s.id
+	WHERE rt.token_hash = $1`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id", "used_at", "expires_at", "revoked_at"}).
+			AddRow(11, 7, 3, usedAt, expiresAt, nil))
+
+	token, err := repo.GetRefreshToken(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 11, token.ID)
+	assert.Equal(t, 7, token.SessionID)
+	assert.Equal(t, 3, token.UserID)
+	assert.Equal(t, usedAt, *token.UsedAt)
+	assert.Nil(t, token.RevokedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	token := &models.RefreshToken{ID: 11, SessionID: 7}
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE RefreshTokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`)).
+		WithArgs(11).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RefreshTokens (session_id, token_hash) VALUES ($1, $2)`)).
+		WithArgs(7, "new-hash").
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions
+	SET last_used_at = NOW(), expires_at = $1
+	WHERE id = $2`)).
+		WithArgs(expiresAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	rotated, err := repo.RotateRefreshToken(context.Background(), token, "new-hash", expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rotated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateRefreshToken_AlreadyUsed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE RefreshTokens SET used_at = NOW()`)).
+		WithArgs(11).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	rotated, err := repo.RotateRefreshToken(context.Background(), &models.RefreshToken{ID: 11, SessionID: 7}, "new-hash", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rotated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActiveSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows(sessionColumns).
+		AddRow(7, 3, "Mozilla/5.0", "10.0.0.1", now, now, now.Add(time.Hour), nil).
+		AddRow(8, 3, "okhttp/4.12", "10.0.0.2", now, now, now.Add(time.Hour), nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, user_agent, ip_address, created_at, last_used_at, expires_at, revoked_at
+	FROM UserSessions
+	WHERE user_id = $1
+	AND revoked_at IS NULL
+	AND expires_at > NOW()`)).
+		WithArgs(3).
+		WillReturnRows(rows)
+
+	sessions, err := repo.GetActiveSessions(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, *sessions, 2)
+	assert.Equal(t, "okhttp/4.12", (*sessions)[1].UserAgent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions
+	SET revoked_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND revoked_at IS NULL`)).
+		WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	revoked, err := repo.RevokeSession(context.Background(), 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewSessionRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions
+	SET revoked_at = NOW()
+	WHERE user_id = $1
+	AND revoked_at IS NULL`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	revoked, err := repo.RevokeAllSessions(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewSessionRepository(sqlxDB)
+
+	t.Run("CreateSession rollback on token insert error", func(t *testing.T) {
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO UserSessions`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "last_used_at"}).AddRow(7, now, now))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RefreshTokens`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateSession(context.Background(), &models.UserSession{UserID: 3}, "hash")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetRefreshToken error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT rt.id, rt.session_id`)).
+			WillReturnError(errors.New("query error"))
+
+		_, err := repository.GetRefreshToken(context.Background(), "hash")
+		assert.Error(t, err)
+	})
+
+	t.Run("RotateRefreshToken rollback on insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE RefreshTokens SET used_at = NOW()`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO RefreshTokens`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		_, err := repository.RotateRefreshToken(context.Background(), &models.RefreshToken{ID: 11, SessionID: 7}, "new-hash", time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("GetActiveSessions scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows(sessionColumns).
+			AddRow("bad", 3, "Mozilla/5.0", "10.0.0.1", time.Now(), time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, user_agent`)).
+			WillReturnRows(rows)
+
+		_, err := repository.GetActiveSessions(context.Background(), 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("RevokeSession error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions`)).
+			WillReturnError(errors.New("update error"))
+
+		_, err := repository.RevokeSession(context.Background(), 3, 7)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
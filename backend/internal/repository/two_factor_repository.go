@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TwoFactorRepository struct {
+	db *sqlx.DB
+}
+
+func NewTwoFactorRepository(db *sqlx.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+func (r *TwoFactorRepository) GetTwoFactor(ctx context.Context, userID int) (*models.TwoFactor, error) {
+	query := `SELECT id, totp_secret, totp_enabled_at
+	FROM Users
+	WHERE id = $1
+	AND is_active = TRUE`
+
+	twoFactor := &models.TwoFactor{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&twoFactor.EnabledAt,
+	)
+	if err != nil {
+		log.Println("Failed to get two factor:", err)
+		return nil, err
+	}
+
+	return twoFactor, nil
+}
+
+// SetSecret stores a new secret pending confirmation. It does nothing when
+// 2FA is already enabled, so an enabled secret can't be replaced silently.
+func (r *TwoFactorRepository) SetSecret(ctx context.Context, userID int, secret string) (int, error) {
+	query := `UPDATE Users
+	SET totp_secret = $1, updated_at = NOW()
+	WHERE id = $2
+	AND totp_enabled_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, secret, userID)
+	if err != nil {
+		log.Println("Failed to set two factor secret:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// EnableTwoFactor turns 2FA on and stores the recovery codes. sql.ErrNoRows
+// is returned when there is no pending secret.
+func (r *TwoFactorRepository) EnableTwoFactor(ctx context.Context, userID int, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	query := `UPDATE Users
+	SET totp_enabled_at = NOW(), updated_at = NOW()
+	WHERE id = $1
+	AND totp_secret IS NOT NULL
+	AND totp_enabled_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to enable two factor:", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to get rows affected:", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes invalidates all recovery codes of the user and stores
+// the new ones.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *TwoFactorRepository) DisableTwoFactor(ctx context.Context, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	query := `UPDATE Users
+	SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = NOW()
+	WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		tx.Rollback()
+		log.Println("Failed to disable two factor:", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM UserRecoveryCodes WHERE user_id = $1`, userID); err != nil {
+		tx.Rollback()
+		log.Println("Failed to delete recovery codes:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks the code as used, 0 is returned for unknown or
+// already used codes.
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (int, error) {
+	query := `UPDATE UserRecoveryCodes
+	SET used_at = NOW()
+	WHERE user_id = $1
+	AND code_hash = $2
+	AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		log.Println("Failed to use recovery code:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *TwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID int) (int, error) {
+	query := `SELECT COUNT(*)
+	FROM UserRecoveryCodes
+	WHERE user_id = $1
+	AND used_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		log.Println("Failed to count recovery codes:", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM UserRecoveryCodes WHERE user_id = $1`, userID); err != nil {
+		log.Println("Failed to delete recovery codes:", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO UserRecoveryCodes (user_id, code_hash)
+	VALUES ($1, $2)`)
+	if err != nil {
+		log.Println("Prepare statement error:", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, codeHash := range codeHashes {
+		if _, err := stmt.ExecContext(ctx, userID, codeHash); err != nil {
+			log.Println("Failed to create recovery code:", err)
+			return err
+		}
+	}
+
+	return nil
+}
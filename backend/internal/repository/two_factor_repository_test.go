@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, totp_secret, totp_enabled_at
+	FROM Users
+	WHERE id = $1
+	AND is_active = TRUE`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "totp_secret", "totp_enabled_at"}).AddRow(4, "SECRET", now))
+
+	twoFactor, err := repo.GetTwoFactor(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, twoFactor.UserID)
+	assert.Equal(t, "SECRET", *twoFactor.Secret)
+	assert.Equal(t, now, *twoFactor.EnabledAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetTwoFactorSecret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET totp_secret = $1, updated_at = NOW()
+	WHERE id = $2
+	AND totp_enabled_at IS NULL`)).
+		WithArgs("SECRET", 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	updated, err := repo.SetSecret(context.Background(), 4, "SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET totp_enabled_at = NOW(), updated_at = NOW()
+	WHERE id = $1
+	AND totp_secret IS NOT NULL
+	AND totp_enabled_at IS NULL`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRecoveryCodes WHERE user_id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO UserRecoveryCodes (user_id, code_hash)`))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO UserRecoveryCodes (user_id, code_hash)`)).
+		WithArgs(4, "hash1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO UserRecoveryCodes (user_id, code_hash)`)).
+		WithArgs(4, "hash2").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	err = repo.EnableTwoFactor(context.Background(), 4, []string{"hash1", "hash2"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = NOW()
+	WHERE id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRecoveryCodes WHERE user_id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+
+	err = repo.DisableTwoFactor(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserRecoveryCodes
+	SET used_at = NOW()
+	WHERE user_id = $1
+	AND code_hash = $2
+	AND used_at IS NULL`)).
+		WithArgs(4, "hash1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	used, err := repo.UseRecoveryCode(context.Background(), 4, "hash1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountRecoveryCodes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewTwoFactorRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)
+	FROM UserRecoveryCodes
+	WHERE user_id = $1
+	AND used_at IS NULL`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.CountRecoveryCodes(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewTwoFactorRepository(sqlxDB)
+
+	t.Run("GetTwoFactor not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, totp_secret, totp_enabled_at`)).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repository.GetTwoFactor(context.Background(), 4)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("EnableTwoFactor without pending secret", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET totp_enabled_at = NOW()`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.EnableTwoFactor(context.Background(), 4, []string{"hash1"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("ReplaceRecoveryCodes rollback on insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRecoveryCodes`)).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO UserRecoveryCodes`))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO UserRecoveryCodes`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.ReplaceRecoveryCodes(context.Background(), 4, []string{"hash1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("DisableTwoFactor rollback on delete error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET totp_secret = NULL`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRecoveryCodes`)).
+			WillReturnError(errors.New("delete error"))
+		mock.ExpectRollback()
+
+		err := repository.DisableTwoFactor(context.Background(), 4)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
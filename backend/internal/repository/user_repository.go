@@ -3,9 +3,13 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -90,7 +94,7 @@ func (r *UserRepository) AddUserRole(ctx context.Context, userID, roleID int) er
 }
 
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	query := `SELECT id, username, email, password_hash, created_at
+	query := `SELECT id, username, email, password_hash, created_at, email_verified_at, is_active, password_reset_required
 	FROM Users
 	WHERE email = $1`
 	user := &models.User{}
@@ -101,6 +105,9 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 		&user.Email,
 		&user.PasswordHash,
 		&user.CreatedAt,
+		&user.EmailVerifiedAt,
+		&user.IsActive,
+		&user.PasswordResetRequired,
 	)
 	if err != nil {
 		log.Println("Failed to get user by email:", err)
@@ -238,3 +245,231 @@ func (r *UserRepository) GetUserRoles(ctx context.Context, userID int) (*[]model
 
 	return &roles, nil
 }
+
+func (r *UserRepository) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	query := `SELECT token_version
+	FROM Users
+	WHERE id = $1
+	AND is_active = TRUE`
+
+	var version int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&version); err != nil {
+		log.Println("Failed to get token version:", err)
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// IncrementTokenVersion invalidates every access token issued to the user
+// before the call.
+func (r *UserRepository) IncrementTokenVersion(ctx context.Context, userID int) (int, error) {
+	query := `UPDATE Users
+	SET token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $1
+	AND is_active = TRUE
+	RETURNING token_version`
+
+	var version int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&version); err != nil {
+		log.Println("Failed to increment token version:", err)
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *UserRepository) RemoveUserRole(ctx context.Context, userID, roleID int) (int, error) {
+	query := `DELETE FROM UserRoles
+	WHERE user_id = $1
+	AND role_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, roleID)
+	if err != nil {
+		log.Println("Failed to remove user role:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// adminUserColumns selects the user with role names and the latest session
+// as the last login.
+const adminUserColumns = `SELECT u.id, u.username, u.email, u.is_active, u.email_verified_at, u.password_reset_required, u.created_at,
+	ARRAY(SELECT r.name FROM UserRoles ur INNER JOIN Roles r ON r.id = ur.role_id WHERE ur.user_id = u.id ORDER BY r.name),
+	s.created_at, s.ip_address, s.user_agent
+	FROM Users u
+	LEFT JOIN LATERAL (
+		SELECT created_at, ip_address, user_agent
+		FROM UserSessions
+		WHERE user_id = u.id
+		ORDER BY created_at DESC
+		LIMIT 1
+	) s ON TRUE`
+
+func (r *UserRepository) SearchUsers(ctx context.Context, filter *models.UserFilter) (*[]models.AdminUserResponse, int, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	paramIndex := 1
+
+	if filter.Search != nil {
+		conditions = append(conditions, fmt.Sprintf("(LOWER(u.username) LIKE $%d OR LOWER(u.email) LIKE $%d)", paramIndex, paramIndex))
+		args = append(args, "%"+strings.ToLower(*filter.Search)+"%")
+		paramIndex++
+	}
+
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("u.is_active = $%d", paramIndex))
+		args = append(args, *filter.IsActive)
+		paramIndex++
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	countQuery := "SELECT COUNT(*) FROM Users u" + where
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		log.Println("Failed to get total users:", err)
+		return nil, 0, err
+	}
+
+	query := adminUserColumns + where
+	query += fmt.Sprintf(" ORDER BY u.created_at DESC, u.id DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Failed to search users:", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []models.AdminUserResponse{}
+	for rows.Next() {
+		user, err := scanAdminUser(rows)
+		if err != nil {
+			log.Println("Failed to scan user:", err)
+			return nil, 0, err
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, 0, err
+	}
+
+	return &users, total, nil
+}
+
+func (r *UserRepository) GetAdminUser(ctx context.Context, userID int) (*models.AdminUserResponse, error) {
+	query := adminUserColumns + `
+	WHERE u.id = $1`
+
+	user, err := scanAdminUser(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		log.Println("Failed to get user:", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetUserActive blocks or unblocks the user. Blocking also invalidates the
+// access tokens issued so far.
+func (r *UserRepository) SetUserActive(ctx context.Context, userID int, active bool) (int, error) {
+	query := `UPDATE Users
+	SET is_active = $1,
+		token_version = token_version + CASE WHEN $1 THEN 0 ELSE 1 END,
+		purge_at = CASE WHEN $1 THEN NULL ELSE purge_at END,
+		updated_at = NOW()
+	WHERE id = $2
+	AND is_active <> $1
+	AND purged_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, active, userID)
+	if err != nil {
+		log.Println("Failed to set user active:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// RequirePasswordReset blocks the login until the password is reset by
+// email and invalidates the access tokens issued so far.
+func (r *UserRepository) RequirePasswordReset(ctx context.Context, userID int) (int, error) {
+	query := `UPDATE Users
+	SET password_reset_required = TRUE, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to require password reset:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to get rows affected:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAdminUser(row rowScanner) (*models.AdminUserResponse, error) {
+	var (
+		user        models.AdminUserResponse
+		lastLoginAt sql.NullTime
+		ipAddress   sql.NullString
+		userAgent   sql.NullString
+	)
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.IsActive,
+		&user.EmailVerifiedAt,
+		&user.PasswordResetRequired,
+		&user.CreatedAt,
+		pq.Array(&user.Roles),
+		&lastLoginAt,
+		&ipAddress,
+		&userAgent,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Roles == nil {
+		user.Roles = []string{}
+	}
+
+	if lastLoginAt.Valid {
+		user.LastLogin = &models.LastLogin{
+			At:        lastLoginAt.Time,
+			IPAddress: ipAddress.String,
+			UserAgent: userAgent.String,
+		}
+	}
+
+	return &user, nil
+}
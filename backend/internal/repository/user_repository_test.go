@@ -3,6 +3,7 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -83,14 +84,15 @@ func TestGetUserByEmail(t *testing.T) {
 	repo := NewUserRepository(sqlxDB)
 
 	email := "alice@example.com"
-	existing := &models.User{ID: 5, Username: "alice", Email: email, PasswordHash: "pass", CreatedAt: time.Now()}
+	verifiedAt := time.Now()
+	existing := &models.User{ID: 5, Username: "alice", Email: email, PasswordHash: "pass", CreatedAt: time.Now(), EmailVerifiedAt: &verifiedAt, IsActive: true}
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, username, email, password_hash, created_at
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, username, email, password_hash, created_at, email_verified_at, is_active, password_reset_required
 	FROM Users
 	WHERE email = $1`)).
 		WithArgs(email).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "created_at"}).
-			AddRow(existing.ID, existing.Username, existing.Email, existing.PasswordHash, existing.CreatedAt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "created_at", "email_verified_at", "is_active", "password_reset_required"}).
+			AddRow(existing.ID, existing.Username, existing.Email, existing.PasswordHash, existing.CreatedAt, existing.EmailVerifiedAt, true, false))
 
 	user, err := repo.GetUserByEmail(context.Background(), email)
 	assert.NoError(t, err)
@@ -333,7 +335,7 @@ func TestUserRepository_GetUserByEmail_ErrorQueryRow(t *testing.T) {
 
 	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
 
-	mock.ExpectQuery("SELECT id, username, email, password_hash, created_at FROM Users WHERE email = \\$1").
+	mock.ExpectQuery("SELECT id, username, email, password_hash, created_at, email_verified_at, is_active, password_reset_required FROM Users WHERE email = \\$1").
 		WithArgs("test@example.com").
 		WillReturnError(errors.New("query failed"))
 
@@ -445,3 +447,215 @@ func TestUserRepository_GetUserRoles_ErrorRowsScan(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, roles)
 }
+
+func TestGetTokenVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT token_version
+	FROM Users
+	WHERE id = $1
+	AND is_active = TRUE`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(3))
+
+	version, err := repo.GetTokenVersion(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIncrementTokenVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Users
+	SET token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $1
+	AND is_active = TRUE
+	RETURNING token_version`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(4))
+
+	version, err := repo.IncrementTokenVersion(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_IncrementTokenVersion_ErrorQueryRow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Users SET token_version`)).
+		WithArgs(1).
+		WillReturnError(errors.New("query failed"))
+
+	_, err = repo.IncrementTokenVersion(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+func TestRemoveUserRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserRoles
+	WHERE user_id = $1
+	AND role_id = $2`)).
+		WithArgs(2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	removed, err := repo.RemoveUserRole(context.Background(), 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	search := "Ali"
+	active := true
+	filter := &models.UserFilter{Search: &search, IsActive: &active, Page: 2, Limit: 10, Offset: 10}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Users u WHERE TRUE AND (LOWER(u.username) LIKE $1 OR LOWER(u.email) LIKE $1) AND u.is_active = $2`)).
+		WithArgs("%ali%", true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "username", "email", "is_active", "email_verified_at", "password_reset_required", "created_at", "roles", "last_login_at", "ip_address", "user_agent"}).
+		AddRow(5, "alice", "alice@example.com", true, now, false, now, "{admin,user}", now, "10.0.0.1", "Firefox").
+		AddRow(6, "alina", "alina@example.com", true, nil, true, now, "{}", nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE TRUE AND (LOWER(u.username) LIKE $1 OR LOWER(u.email) LIKE $1) AND u.is_active = $2 ORDER BY u.created_at DESC, u.id DESC LIMIT $3 OFFSET $4`)).
+		WithArgs("%ali%", true, 10, 10).
+		WillReturnRows(rows)
+
+	users, total, err := repo.SearchUsers(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, total)
+	assert.Len(t, *users, 2)
+	assert.Equal(t, []string{"admin", "user"}, (*users)[0].Roles)
+	assert.Equal(t, "10.0.0.1", (*users)[0].LastLogin.IPAddress)
+	assert.Empty(t, (*users)[1].Roles)
+	assert.Nil(t, (*users)[1].LastLogin)
+	assert.True(t, (*users)[1].PasswordResetRequired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAdminUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`LEFT JOIN LATERAL (
+		SELECT created_at, ip_address, user_agent
+		FROM UserSessions
+		WHERE user_id = u.id
+		ORDER BY created_at DESC
+		LIMIT 1
+	) s ON TRUE
+	WHERE u.id = $1`)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "is_active", "email_verified_at", "password_reset_required", "created_at", "roles", "last_login_at", "ip_address", "user_agent"}).
+			AddRow(5, "alice", "alice@example.com", false, now, false, now, "{user}", now, "10.0.0.1", "Firefox"))
+
+	user, err := repo.GetAdminUser(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.False(t, user.IsActive)
+	assert.Equal(t, now, user.LastLogin.At)
+	assert.Equal(t, "Firefox", user.LastLogin.UserAgent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetUserActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET is_active = $1,
+		token_version = token_version + CASE WHEN $1 THEN 0 ELSE 1 END,
+		purge_at = CASE WHEN $1 THEN NULL ELSE purge_at END,
+		updated_at = NOW()
+	WHERE id = $2
+	AND is_active <> $1
+	AND purged_at IS NULL`)).
+		WithArgs(false, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	updated, err := repo.SetUserActive(context.Background(), 5, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRequirePasswordReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET password_reset_required = TRUE, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $1`)).
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	updated, err := repo.RequirePasswordReset(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SearchUsers_ErrorCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Users u WHERE TRUE`)).
+		WillReturnError(errors.New("query failed"))
+
+	users, _, err := repo.SearchUsers(context.Background(), &models.UserFilter{Page: 1, Limit: 20})
+	assert.Error(t, err)
+	assert.Nil(t, users)
+}
+
+func TestUserRepository_GetAdminUser_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE u.id = $1`)).
+		WithArgs(99).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetAdminUser(context.Background(), 99)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
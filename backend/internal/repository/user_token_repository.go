@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type UserTokenRepository struct {
+	db *sqlx.DB
+}
+
+func NewUserTokenRepository(db *sqlx.DB) *UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+// CreateToken replaces unused tokens of the same purpose, so only the link
+// from the latest email works.
+func (r *UserTokenRepository) CreateToken(ctx context.Context, token *models.UserToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM UserTokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`, token.UserID, token.Purpose)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to delete user tokens:", err)
+		return err
+	}
+
+	query := `INSERT INTO UserTokens (user_id, purpose, token_hash, expires_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, query, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt).Scan(
+		&token.ID,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to create user token:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifyEmail uses the token and marks the email of its user as verified.
+// sql.ErrNoRows is returned when the token is unknown, used or expired.
+func (r *UserTokenRepository) VerifyEmail(ctx context.Context, tokenHash string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return 0, err
+	}
+
+	userID, err := useToken(ctx, tx, tokenHash, models.TokenPurposeEmailVerification)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query := `UPDATE Users
+	SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+	WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		tx.Rollback()
+		log.Println("Failed to verify email:", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// ResetPassword uses the token, sets the new password and logs the user out
+// everywhere. sql.ErrNoRows is returned when the token is unknown, used or
+// expired.
+func (r *UserTokenRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return 0, err
+	}
+
+	userID, err := useToken(ctx, tx, tokenHash, models.TokenPurposePasswordReset)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query := `UPDATE Users
+	SET password_hash = $1, password_reset_required = FALSE, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2`
+
+	if _, err := tx.ExecContext(ctx, query, passwordHash, userID); err != nil {
+		tx.Rollback()
+		log.Println("Failed to reset password:", err)
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to revoke sessions:", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Transaction commit error:", err)
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func useToken(ctx context.Context, tx *sql.Tx, tokenHash, purpose string) (int, error) {
+	query := `UPDATE UserTokens
+	SET used_at = NOW()
+	WHERE token_hash = $1
+	AND purpose = $2
+	AND used_at IS NULL
+	AND expires_at > NOW()
+	RETURNING user_id`
+
+	var userID int
+	if err := tx.QueryRowContext(ctx, query, tokenHash, purpose).Scan(&userID); err != nil {
+		log.Println("Failed to use user token:", err)
+		return 0, err
+	}
+
+	return userID, nil
+}
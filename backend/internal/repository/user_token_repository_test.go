@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUserToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewUserTokenRepository(sqlxDB)
+
+	now := time.Now()
+	token := &models.UserToken{
+		UserID:    3,
+		Purpose:   models.TokenPurposePasswordReset,
+		TokenHash: "hash",
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserTokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`)).
+		WithArgs(3, models.TokenPurposePasswordReset).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO UserTokens (user_id, purpose, token_hash, expires_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at`)).
+		WithArgs(3, models.TokenPurposePasswordReset, "hash", token.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(9, now))
+	mock.ExpectCommit()
+
+	err = repo.CreateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, token.ID)
+	assert.Equal(t, now, token.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerifyEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewUserTokenRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE UserTokens
+	SET used_at = NOW()
+	WHERE token_hash = $1
+	AND purpose = $2
+	AND used_at IS NULL
+	AND expires_at > NOW()
+	RETURNING user_id`)).
+		WithArgs("hash", models.TokenPurposeEmailVerification).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+	WHERE id = $1`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	userID, err := repo.VerifyEmail(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, userID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewUserTokenRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE UserTokens
+	SET used_at = NOW()`)).
+		WithArgs("hash", models.TokenPurposePasswordReset).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users
+	SET password_hash = $1, password_reset_required = FALSE, token_version = token_version + 1, updated_at = NOW()
+	WHERE id = $2`)).
+		WithArgs("new-password-hash", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE UserSessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`)).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	userID, err := repo.ResetPassword(context.Background(), "hash", "new-password-hash")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, userID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewUserTokenRepository(sqlxDB)
+
+	t.Run("CreateToken rollback on insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM UserTokens`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO UserTokens`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateToken(context.Background(), &models.UserToken{UserID: 3, Purpose: models.TokenPurposeEmailVerification})
+		assert.Error(t, err)
+	})
+
+	t.Run("VerifyEmail invalid token", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE UserTokens`)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repository.VerifyEmail(context.Background(), "hash")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("ResetPassword rollback on update error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE UserTokens`)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE Users`)).
+			WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
+
+		_, err := repository.ResetPassword(context.Background(), "hash", "new-password-hash")
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,12 +16,20 @@ func NewWorkoutExerciseRepository(db *sqlx.DB) *WorkoutExerciseRepository {
 	return &WorkoutExerciseRepository{db: db}
 }
 
+// AddExerciseToWorkout inserts the exercise together with its sets, so a
+// failed set never leaves an exercise without them.
 func (r *WorkoutExerciseRepository) AddExerciseToWorkout(ctx context.Context, workoutExercise *models.WorkoutExercise) error {
-	query := `INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, notes)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	query := `INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, duration_seconds, distance_meters, heart_rate_avg, started_at, ended_at, notes)
+	VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8, $9, $10, $11)
 	RETURNING id, created_at`
 
-	err := r.db.QueryRowContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		workoutExercise.WorkoutID,
@@ -29,6 +37,11 @@ func (r *WorkoutExerciseRepository) AddExerciseToWorkout(ctx context.Context, wo
 		workoutExercise.Sets,
 		workoutExercise.Reps,
 		workoutExercise.Weight,
+		workoutExercise.DurationSeconds,
+		workoutExercise.DistanceMeters,
+		workoutExercise.HeartRateAvg,
+		workoutExercise.StartedAt,
+		workoutExercise.EndedAt,
 		workoutExercise.Notes,
 	).Scan(
 		&workoutExercise.ID,
@@ -36,19 +49,36 @@ func (r *WorkoutExerciseRepository) AddExerciseToWorkout(ctx context.Context, wo
 	)
 
 	if err != nil {
+		tx.Rollback()
 		log.Println("Failed to add exercise to workout:", err)
 		return err
 	}
 
+	for i := range workoutExercise.WorkoutSets {
+		workoutExercise.WorkoutSets[i].WorkoutExerciseID = workoutExercise.ID
+	}
+
+	if err := insertWorkoutSets(ctx, tx, workoutExercise.WorkoutSets); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Commit error:", err)
+		return err
+	}
+
 	return nil
 }
 
 func (r *WorkoutExerciseRepository) GetExercisesByWorkoutID(ctx context.Context, workoutID int) (*[]models.WorkoutExercise, error) {
-	query := `SELECT we.id, we.workout_id, we.exercise_id, we.sets, we.reps, we.weight, we.notes, we.created_at,
-	e.id, e.name, e.description
+	query := `SELECT we.id, we.workout_id, we.exercise_id, COALESCE(we.sets, 0), COALESCE(we.reps, 0), COALESCE(we.weight, 0),
+	we.duration_seconds, we.distance_meters, we.heart_rate_avg, we.started_at, we.ended_at, we.notes, we.created_at,
+	e.id, e.name, e.description, e.tracking_type
 	FROM WorkoutExercises we
 	INNER JOIN Exercises e ON we.exercise_id = e.id
-	WHERE we.workout_id = $1`
+	WHERE we.workout_id = $1
+	ORDER BY we.id`
 
 	rows, err := r.db.QueryContext(
 		ctx,
@@ -74,11 +104,17 @@ func (r *WorkoutExerciseRepository) GetExercisesByWorkoutID(ctx context.Context,
 			&workoutExercise.Sets,
 			&workoutExercise.Reps,
 			&workoutExercise.Weight,
+			&workoutExercise.DurationSeconds,
+			&workoutExercise.DistanceMeters,
+			&workoutExercise.HeartRateAvg,
+			&workoutExercise.StartedAt,
+			&workoutExercise.EndedAt,
 			&workoutExercise.Notes,
 			&workoutExercise.CreatedAt,
 			&exercise.ID,
 			&exercise.Name,
 			&exercise.Description,
+			&exercise.TrackingType,
 		)
 		if err != nil {
 			log.Println("Failed to scan workout exercise:", err)
@@ -101,8 +137,9 @@ func (r *WorkoutExerciseRepository) GetExercisesByWorkoutID(ctx context.Context,
 }
 
 func (r *WorkoutExerciseRepository) GetExerciseByWorkoutID(ctx context.Context, workoutID, workoutExerciseID int) (*models.WorkoutExercise, error) {
-	query := `SELECT we.id, we.workout_id, we.exercise_id, we.sets, we.reps, we.weight, we.notes, we.created_at,
-	e.id, e.name, e.description
+	query := `SELECT we.id, we.workout_id, we.exercise_id, COALESCE(we.sets, 0), COALESCE(we.reps, 0), COALESCE(we.weight, 0),
+	we.duration_seconds, we.distance_meters, we.heart_rate_avg, we.started_at, we.ended_at, we.notes, we.created_at,
+	e.id, e.name, e.description, e.tracking_type
 	FROM WorkoutExercises we
 	INNER JOIN Exercises e ON we.exercise_id = e.id
 	WHERE we.workout_id = $1
@@ -123,11 +160,17 @@ func (r *WorkoutExerciseRepository) GetExerciseByWorkoutID(ctx context.Context,
 		&workoutExercise.Sets,
 		&workoutExercise.Reps,
 		&workoutExercise.Weight,
+		&workoutExercise.DurationSeconds,
+		&workoutExercise.DistanceMeters,
+		&workoutExercise.HeartRateAvg,
+		&workoutExercise.StartedAt,
+		&workoutExercise.EndedAt,
 		&workoutExercise.Notes,
 		&workoutExercise.CreatedAt,
 		&exercise.ID,
 		&exercise.Name,
 		&exercise.Description,
+		&exercise.TrackingType,
 	)
 	if err != nil {
 		log.Println("Failed to scan workout exercise:", err)
@@ -141,20 +184,35 @@ func (r *WorkoutExerciseRepository) GetExerciseByWorkoutID(ctx context.Context,
 	return &workoutExercise, nil
 }
 
+// UpdateExerciseInWorkout replaces the exercise and its sets in one
+// transaction, records and analytics read the sets before the summary.
 func (r *WorkoutExerciseRepository) UpdateExerciseInWorkout(ctx context.Context, workoutExercise *models.WorkoutExercise) error {
 	query := `UPDATE WorkoutExercises
-	SET exercise_id = $1, sets = $2, reps = $3, weight = $4, notes = $5
-	WHERE id = $6
-	AND workout_id = $7
+	SET exercise_id = $1, sets = NULLIF($2, 0), reps = NULLIF($3, 0), weight = $4,
+	duration_seconds = $5, distance_meters = $6, heart_rate_avg = $7,
+	started_at = $8, ended_at = $9, notes = $10
+	WHERE id = $11
+	AND workout_id = $12
 	RETURNING created_at`
 
-	err := r.db.QueryRowContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		workoutExercise.ExerciseID,
 		workoutExercise.Sets,
 		workoutExercise.Reps,
 		workoutExercise.Weight,
+		workoutExercise.DurationSeconds,
+		workoutExercise.DistanceMeters,
+		workoutExercise.HeartRateAvg,
+		workoutExercise.StartedAt,
+		workoutExercise.EndedAt,
 		workoutExercise.Notes,
 		workoutExercise.ID,
 		workoutExercise.WorkoutID,
@@ -163,10 +221,32 @@ func (r *WorkoutExerciseRepository) UpdateExerciseInWorkout(ctx context.Context,
 	)
 
 	if err != nil {
+		tx.Rollback()
 		log.Println("Failed to update exercise in workout:", err)
 		return err
 	}
 
+	_, err = tx.ExecContext(ctx, `DELETE FROM WorkoutSets WHERE workout_exercise_id = $1`, workoutExercise.ID)
+	if err != nil {
+		tx.Rollback()
+		log.Println("Failed to clear workout sets:", err)
+		return err
+	}
+
+	for i := range workoutExercise.WorkoutSets {
+		workoutExercise.WorkoutSets[i].WorkoutExerciseID = workoutExercise.ID
+	}
+
+	if err := insertWorkoutSets(ctx, tx, workoutExercise.WorkoutSets); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Commit error:", err)
+		return err
+	}
+
 	return nil
 }
 
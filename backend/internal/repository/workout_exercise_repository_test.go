@@ -25,11 +25,13 @@ func TestAddExerciseToWorkout(t *testing.T) {
 	we := &models.WorkoutExercise{WorkoutID: 1, ExerciseID: 2, Sets: 3, Reps: 10, Weight: 50.5, Notes: "note"}
 	createdAt := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, notes)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, duration_seconds, distance_meters, heart_rate_avg, started_at, ended_at, notes)
+	VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8, $9, $10, $11)
 	RETURNING id, created_at`)).
-		WithArgs(we.WorkoutID, we.ExerciseID, we.Sets, we.Reps, we.Weight, we.Notes).
+		WithArgs(we.WorkoutID, we.ExerciseID, we.Sets, we.Reps, we.Weight, we.DurationSeconds, we.DistanceMeters, we.HeartRateAvg, we.StartedAt, we.EndedAt, we.Notes).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, createdAt))
+	mock.ExpectCommit()
 
 	err = repo.AddExerciseToWorkout(ctx, we)
 	assert.NoError(t, err)
@@ -38,6 +40,71 @@ func TestAddExerciseToWorkout(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAddExerciseToWorkoutWithSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWorkoutExerciseRepository(sqlx.NewDb(db, "sqlmock"))
+
+	we := &models.WorkoutExercise{
+		WorkoutID:  1,
+		ExerciseID: 2,
+		Sets:       2,
+		Reps:       5,
+		Weight:     100,
+		WorkoutSets: []models.WorkoutSet{
+			{SetNumber: 1, SetType: models.SetTypeWorking, Reps: 5, Weight: 100},
+			{SetNumber: 2, SetType: models.SetTypeWorking, Reps: 5, Weight: 100},
+		},
+	}
+	createdAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutExercises`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, createdAt))
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO WorkoutSets`))
+	prep.ExpectQuery().
+		WithArgs(5, 1, models.SetTypeWorking, 5, 100.0, nil, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "set_number", "created_at"}).AddRow(11, 1, createdAt))
+	prep.ExpectQuery().
+		WithArgs(5, 2, models.SetTypeWorking, 5, 100.0, nil, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "set_number", "created_at"}).AddRow(12, 2, createdAt))
+	mock.ExpectCommit()
+
+	err = repo.AddExerciseToWorkout(context.Background(), we)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, we.WorkoutSets[1].WorkoutExerciseID)
+	assert.Equal(t, 12, we.WorkoutSets[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddExerciseToWorkoutRollsBackOnSetError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWorkoutExerciseRepository(sqlx.NewDb(db, "sqlmock"))
+
+	we := &models.WorkoutExercise{
+		WorkoutID:   1,
+		ExerciseID:  2,
+		WorkoutSets: []models.WorkoutSet{{SetNumber: 1, SetType: models.SetTypeWorking, Reps: 5}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutExercises`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, time.Now()))
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO WorkoutSets`)).
+		ExpectQuery().
+		WillReturnError(context.DeadlineExceeded)
+	mock.ExpectRollback()
+
+	err = repo.AddExerciseToWorkout(context.Background(), we)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetExercisesByWorkoutID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -51,14 +118,16 @@ func TestGetExercisesByWorkoutID(t *testing.T) {
 	createdAt := time.Now()
 
 	rows := sqlmock.NewRows([]string{
-		"id", "workout_id", "exercise_id", "sets", "reps", "weight", "notes", "created_at",
-		"id", "name", "description",
+		"id", "workout_id", "exercise_id", "sets", "reps", "weight",
+		"duration_seconds", "distance_meters", "heart_rate_avg", "started_at", "ended_at", "notes", "created_at",
+		"id", "name", "description", "tracking_type",
 	}).
-		AddRow(5, workoutID, 2, 3, 10, 50.5, "note", createdAt, 2, "ex", "desc").
-		AddRow(6, workoutID, 3, 4, 8, 40.0, "note2", createdAt, 3, "ex2", "desc2")
+		AddRow(5, workoutID, 2, 3, 10, 50.5, nil, nil, nil, createdAt, createdAt.Add(10*time.Minute), "note", createdAt, 2, "ex", "desc", "weight_reps").
+		AddRow(6, workoutID, 3, 0, 0, 0.0, 1500, 5000.0, 150, nil, nil, "note2", createdAt, 3, "ex2", "desc2", "distance_duration")
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id, we.sets, we.reps, we.weight, we.notes, we.created_at,
-	e.id, e.name, e.description
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id, COALESCE(we.sets, 0), COALESCE(we.reps, 0), COALESCE(we.weight, 0),
+	we.duration_seconds, we.distance_meters, we.heart_rate_avg, we.started_at, we.ended_at, we.notes, we.created_at,
+	e.id, e.name, e.description, e.tracking_type
 	FROM WorkoutExercises we
 	INNER JOIN Exercises e ON we.exercise_id = e.id
 	WHERE we.workout_id = $1`)).
@@ -70,6 +139,12 @@ func TestGetExercisesByWorkoutID(t *testing.T) {
 	assert.Len(t, *exs, 2)
 	assert.Equal(t, 5, (*exs)[0].ID)
 	assert.Equal(t, "ex", (*exs)[0].Exercise.Name)
+	assert.Nil(t, (*exs)[0].DurationSeconds)
+	assert.WithinDuration(t, createdAt.Add(10*time.Minute), *(*exs)[0].EndedAt, time.Second)
+	assert.Nil(t, (*exs)[1].StartedAt)
+	assert.Equal(t, 1500, *(*exs)[1].DurationSeconds)
+	assert.Equal(t, 5000.0, *(*exs)[1].DistanceMeters)
+	assert.Equal(t, "distance_duration", (*exs)[1].Exercise.TrackingType)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -86,15 +161,16 @@ func TestGetExerciseByWorkoutID(t *testing.T) {
 	exID := 5
 	createdAt := time.Now()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id, we.sets, we.reps, we.weight, we.notes, we.created_at,
-	e.id, e.name, e.description
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id, COALESCE(we.sets, 0), COALESCE(we.reps, 0), COALESCE(we.weight, 0),
+	we.duration_seconds, we.distance_meters, we.heart_rate_avg, we.started_at, we.ended_at, we.notes, we.created_at,
+	e.id, e.name, e.description, e.tracking_type
 	FROM WorkoutExercises we
 	INNER JOIN Exercises e ON we.exercise_id = e.id
 	WHERE we.workout_id = $1
 	AND we.id = $2`)).
 		WithArgs(workoutID, exID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "workout_id", "exercise_id", "sets", "reps", "weight", "notes", "created_at", "id", "name", "description"}).
-			AddRow(exID, workoutID, 2, 3, 10, 50.5, "note", createdAt, 2, "ex", "desc"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workout_id", "exercise_id", "sets", "reps", "weight", "duration_seconds", "distance_meters", "heart_rate_avg", "started_at", "ended_at", "notes", "created_at", "id", "name", "description", "tracking_type"}).
+			AddRow(exID, workoutID, 2, 3, 10, 50.5, nil, nil, nil, nil, nil, "note", createdAt, 2, "ex", "desc", "weight_reps"))
 
 	we, err := repo.GetExerciseByWorkoutID(ctx, workoutID, exID)
 	assert.NoError(t, err)
@@ -112,20 +188,39 @@ func TestUpdateExerciseInWorkout(t *testing.T) {
 	repo := NewWorkoutExerciseRepository(sqlxDB)
 
 	ctx := context.Background()
-	we := &models.WorkoutExercise{ID: 5, WorkoutID: 1, ExerciseID: 3, Sets: 4, Reps: 12, Weight: 60.0, Notes: "upd"}
+	we := &models.WorkoutExercise{ID: 5, WorkoutID: 1, ExerciseID: 3, Sets: 2, Reps: 12, Weight: 60.0, Notes: "upd"}
+	we.WorkoutSets = []models.WorkoutSet{
+		{SetNumber: 1, SetType: models.SetTypeWorking, Reps: 12, Weight: 60.0},
+		{SetNumber: 2, SetType: models.SetTypeWorking, Reps: 12, Weight: 60.0},
+	}
 	createdAt := time.Now()
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutExercises
-	SET exercise_id = $1, sets = $2, reps = $3, weight = $4, notes = $5
-	WHERE id = $6
-	AND workout_id = $7
+	SET exercise_id = $1, sets = NULLIF($2, 0), reps = NULLIF($3, 0), weight = $4,
+	duration_seconds = $5, distance_meters = $6, heart_rate_avg = $7,
+	started_at = $8, ended_at = $9, notes = $10
+	WHERE id = $11
+	AND workout_id = $12
 	RETURNING created_at`)).
-		WithArgs(we.ExerciseID, we.Sets, we.Reps, we.Weight, we.Notes, we.ID, we.WorkoutID).
+		WithArgs(we.ExerciseID, we.Sets, we.Reps, we.Weight, we.DurationSeconds, we.DistanceMeters, we.HeartRateAvg, we.StartedAt, we.EndedAt, we.Notes, we.ID, we.WorkoutID).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutSets WHERE workout_exercise_id = $1`)).
+		WithArgs(we.ID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO WorkoutSets`))
+	for i := range we.WorkoutSets {
+		prep.ExpectQuery().
+			WithArgs(we.ID, i+1, models.SetTypeWorking, 12, 60.0, nil, nil, nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "set_number", "created_at"}).AddRow(20+i, i+1, createdAt))
+	}
+	mock.ExpectCommit()
 
 	err = repo.UpdateExerciseInWorkout(ctx, we)
 	assert.NoError(t, err)
 	assert.WithinDuration(t, createdAt, we.CreatedAt, time.Second)
+	assert.Equal(t, 21, we.WorkoutSets[1].ID)
+	assert.Equal(t, we.ID, we.WorkoutSets[1].WorkoutExerciseID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -162,8 +257,10 @@ func TestWorkoutExerciseRepositoryNegative(t *testing.T) {
 	repository := NewWorkoutExerciseRepository(sqlxDB)
 
 	t.Run("AddExerciseToWorkout error", func(t *testing.T) {
+		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutExercises`)).
 			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
 
 		err := repository.AddExerciseToWorkout(context.Background(), &models.WorkoutExercise{})
 		assert.Error(t, err)
@@ -179,9 +276,10 @@ func TestWorkoutExerciseRepositoryNegative(t *testing.T) {
 
 	t.Run("GetExercisesByWorkoutID scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"we.id", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight", "we.notes", "we.created_at",
-			"e.id", "e.name", "e.description",
-		}).AddRow("bad", 1, 1, 3, 12, 50.0, "note", time.Now(), 1, "Push-up", "Chest exercise")
+			"we.id", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight",
+			"we.duration_seconds", "we.distance_meters", "we.heart_rate_avg", "we.started_at", "we.ended_at", "we.notes", "we.created_at",
+			"e.id", "e.name", "e.description", "e.tracking_type",
+		}).AddRow("bad", 1, 1, 3, 12, 50.0, nil, nil, nil, nil, nil, "note", time.Now(), 1, "Push-up", "Chest exercise", "reps_only")
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id`)).
 			WillReturnRows(rows)
@@ -200,9 +298,10 @@ func TestWorkoutExerciseRepositoryNegative(t *testing.T) {
 
 	t.Run("GetExerciseByWorkoutID scan error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"we.id", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight", "we.notes", "we.created_at",
-			"e.id", "e.name", "e.description",
-		}).AddRow("bad", 1, 1, 3, 12, 50.0, "note", time.Now(), 1, "Push-up", "Chest exercise")
+			"we.id", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight",
+			"we.duration_seconds", "we.distance_meters", "we.heart_rate_avg", "we.started_at", "we.ended_at", "we.notes", "we.created_at",
+			"e.id", "e.name", "e.description", "e.tracking_type",
+		}).AddRow("bad", 1, 1, 3, 12, 50.0, nil, nil, nil, nil, nil, "note", time.Now(), 1, "Push-up", "Chest exercise", "reps_only")
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT we.id, we.workout_id, we.exercise_id`)).
 			WithArgs(1, 1).WillReturnRows(rows)
@@ -212,8 +311,10 @@ func TestWorkoutExerciseRepositoryNegative(t *testing.T) {
 	})
 
 	t.Run("UpdateExerciseInWorkout error", func(t *testing.T) {
+		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutExercises`)).
 			WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
 
 		err := repository.UpdateExerciseInWorkout(context.Background(), &models.WorkoutExercise{})
 		assert.Error(t, err)
@@ -3,7 +3,10 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -17,8 +20,8 @@ func NewWorkoutRepository(db *sqlx.DB) *WorkoutRepository {
 }
 
 func (r *WorkoutRepository) CreateWorkout(ctx context.Context, workout *models.Workout) error {
-	query := `INSERT INTO Workouts (user_id, date, notes)
-	VALUES ($1, $2, $3)
+	query := `INSERT INTO Workouts (user_id, date, notes, started_at, ended_at)
+	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id, created_at, updated_at, is_active`
 
 	err := r.db.QueryRowContext(
@@ -27,6 +30,8 @@ func (r *WorkoutRepository) CreateWorkout(ctx context.Context, workout *models.W
 		workout.UserID,
 		workout.Date,
 		workout.Notes,
+		workout.StartedAt,
+		workout.EndedAt,
 	).Scan(
 		&workout.ID,
 		&workout.CreatedAt,
@@ -42,16 +47,54 @@ func (r *WorkoutRepository) CreateWorkout(ctx context.Context, workout *models.W
 	return nil
 }
 
-func (r *WorkoutRepository) GetWorkoutsByUserID(ctx context.Context, userID int) (*[]models.Workout, error) {
-	query := `SELECT id, user_id, date, notes, created_at, updated_at, is_active
-	FROM Workouts
-	WHERE is_active = TRUE
-	AND user_id = $1`
+func (r *WorkoutRepository) GetWorkoutsByUserID(ctx context.Context, userID int, filter *models.WorkoutFilter) (*[]models.Workout, int, error) {
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+	paramIndex := 2
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("COALESCE(started_at, date) >= $%d", paramIndex))
+		args = append(args, *filter.From)
+		paramIndex++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("COALESCE(started_at, date) < $%d", paramIndex))
+		args = append(args, *filter.To)
+		paramIndex++
+	}
+
+	if filter.ExerciseID != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM WorkoutExercises we WHERE we.workout_id = Workouts.id AND we.exercise_id = $%d)", paramIndex))
+		args = append(args, *filter.ExerciseID)
+		paramIndex++
+	}
+
+	if filter.Search != nil {
+		conditions = append(conditions, fmt.Sprintf("LOWER(notes) LIKE $%d", paramIndex))
+		args = append(args, "%"+escapeLike(strings.ToLower(*filter.Search))+"%")
+		paramIndex++
+	}
+
+	baseQuery := "FROM Workouts WHERE is_active = TRUE AND " + strings.Join(conditions, " AND ")
+
+	countQuery := "SELECT COUNT(*) " + baseQuery
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		log.Println("Failed to get total workouts:", err)
+		return nil, 0, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	query := "SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active " + baseQuery
+	query += fmt.Sprintf(" ORDER BY COALESCE(started_at, date) %s, id %s", filter.SortOrder, filter.SortOrder)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Println("Failed to get workouts:", err)
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -63,13 +106,17 @@ func (r *WorkoutRepository) GetWorkoutsByUserID(ctx context.Context, userID int)
 			&workout.UserID,
 			&workout.Date,
 			&workout.Notes,
+			&workout.StartedAt,
+			&workout.EndedAt,
+			&workout.TotalSets,
+			&workout.TotalVolume,
 			&workout.CreatedAt,
 			&workout.UpdatedAt,
 			&workout.IsActive,
 		)
 		if err != nil {
 			log.Println("Failed to scan workout:", err)
-			return nil, err
+			return nil, 0, err
 		}
 
 		workouts = append(workouts, workout)
@@ -77,14 +124,14 @@ func (r *WorkoutRepository) GetWorkoutsByUserID(ctx context.Context, userID int)
 
 	if err := rows.Err(); err != nil {
 		log.Println("Rows error:", err)
-		return nil, err
+		return nil, 0, err
 	}
 
-	return &workouts, nil
+	return &workouts, total, nil
 }
 
 func (r *WorkoutRepository) GetWorkoutByUserID(ctx context.Context, userID int, workoutID int) (*models.Workout, error) {
-	query := `SELECT id, user_id, date, notes, created_at, updated_at, is_active
+	query := `SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active
 	FROM Workouts
 	WHERE is_active = TRUE
 	AND user_id = $1
@@ -102,6 +149,10 @@ func (r *WorkoutRepository) GetWorkoutByUserID(ctx context.Context, userID int,
 		&workout.UserID,
 		&workout.Date,
 		&workout.Notes,
+		&workout.StartedAt,
+		&workout.EndedAt,
+		&workout.TotalSets,
+		&workout.TotalVolume,
 		&workout.CreatedAt,
 		&workout.UpdatedAt,
 		&workout.IsActive,
@@ -117,20 +168,24 @@ func (r *WorkoutRepository) GetWorkoutByUserID(ctx context.Context, userID int,
 
 func (r *WorkoutRepository) UpdateWorkoutByUserID(ctx context.Context, workout *models.Workout) error {
 	query := `UPDATE Workouts 
-	SET date = $1, notes = $2, updated_at = NOW()
-	WHERE id = $3
+	SET date = $1, notes = $2, started_at = $3, ended_at = $4, updated_at = NOW()
+	WHERE id = $5
 	AND is_active = TRUE
-	AND user_id = $4
-	RETURNING created_at, updated_at, is_active`
+	AND user_id = $6
+	RETURNING total_sets, total_volume, created_at, updated_at, is_active`
 
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
 		workout.Date,
 		workout.Notes,
+		workout.StartedAt,
+		workout.EndedAt,
 		workout.ID,
 		workout.UserID,
 	).Scan(
+		&workout.TotalSets,
+		&workout.TotalVolume,
 		&workout.CreatedAt,
 		&workout.UpdatedAt,
 		&workout.IsActive,
@@ -166,3 +221,159 @@ func (r *WorkoutRepository) DeleteWorkoutByUserID(ctx context.Context, id, userI
 
 	return int(rowsAffected), nil
 }
+
+func (r *WorkoutRepository) StartWorkout(ctx context.Context, workout *models.Workout) error {
+	query := `UPDATE Workouts
+	SET started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE
+	AND ended_at IS NULL
+	RETURNING date, notes, started_at, created_at, updated_at, is_active`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		workout.ID,
+		workout.UserID,
+	).Scan(
+		&workout.Date,
+		&workout.Notes,
+		&workout.StartedAt,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+		&workout.IsActive,
+	)
+
+	if err != nil {
+		log.Println("Failed to start workout:", err)
+		return err
+	}
+
+	return nil
+}
+
+// FinishWorkout closes a started workout and stores its totals; warmup sets
+// count towards the number of sets but not towards the volume.
+func (r *WorkoutRepository) FinishWorkout(ctx context.Context, workout *models.Workout) error {
+	query := `UPDATE Workouts w
+	SET ended_at = NOW(), total_sets = totals.sets, total_volume = totals.volume, updated_at = NOW()
+	FROM (
+		SELECT COUNT(ws.id) AS sets,
+		COALESCE(SUM(ws.weight * COALESCE(ws.reps, 0)) FILTER (WHERE ws.set_type <> 'warmup'), 0) AS volume
+		FROM WorkoutExercises we
+		INNER JOIN WorkoutSets ws ON ws.workout_exercise_id = we.id
+		WHERE we.workout_id = $1
+	) totals
+	WHERE w.id = $1
+	AND w.user_id = $2
+	AND w.is_active = TRUE
+	AND w.started_at IS NOT NULL
+	AND w.ended_at IS NULL
+	RETURNING w.date, w.notes, w.started_at, w.ended_at, w.total_sets, w.total_volume, w.created_at, w.updated_at, w.is_active`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		workout.ID,
+		workout.UserID,
+	).Scan(
+		&workout.Date,
+		&workout.Notes,
+		&workout.StartedAt,
+		&workout.EndedAt,
+		&workout.TotalSets,
+		&workout.TotalVolume,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+		&workout.IsActive,
+	)
+
+	if err != nil {
+		log.Println("Failed to finish workout:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutRepository) CreateWorkoutFromTemplate(ctx context.Context, workout *models.Workout, templateID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	if err = instantiateTemplate(ctx, tx, workout, templateID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func instantiateTemplate(ctx context.Context, tx *sql.Tx, workout *models.Workout, templateID int) error {
+	workoutQuery := `INSERT INTO Workouts (user_id, date, notes)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at, is_active`
+
+	err := tx.QueryRowContext(
+		ctx,
+		workoutQuery,
+		workout.UserID,
+		workout.Date,
+		workout.Notes,
+	).Scan(
+		&workout.ID,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+		&workout.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create workout:", err)
+		return err
+	}
+
+	exercisesQuery := `INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, notes)
+	SELECT $1, te.exercise_id, te.target_sets, te.target_reps, te.target_weight, te.notes
+	FROM WorkoutTemplateExercises te
+	WHERE te.template_id = $2
+	ORDER BY te.position`
+
+	_, err = tx.ExecContext(ctx, exercisesQuery, workout.ID, templateID)
+	if err != nil {
+		log.Println("Failed to copy template exercises to workout:", err)
+		return err
+	}
+
+	setsQuery := `INSERT INTO WorkoutSets (workout_exercise_id, set_number, set_type, reps, weight)
+	SELECT we.id, gs.n, 'working', we.reps, we.weight
+	FROM WorkoutExercises we
+	CROSS JOIN LATERAL generate_series(1, we.sets) AS gs(n)
+	WHERE we.workout_id = $1`
+
+	_, err = tx.ExecContext(ctx, setsQuery, workout.ID)
+	if err != nil {
+		log.Println("Failed to create workout sets from template:", err)
+		return err
+	}
+
+	return nil
+}
+
+// escapeLike escapes the LIKE wildcards, so % and _ in a search term match
+// literally. Backslash is the default LIKE escape character in Postgres.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
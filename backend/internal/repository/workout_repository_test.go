@@ -3,6 +3,7 @@ package repository
 import (
 	"backend/internal/models"
 	"context"
+	"database/sql"
 	"fmt"
 	"regexp"
 	"testing"
@@ -23,14 +24,16 @@ func TestCreateWorkout(t *testing.T) {
 
 	ctx := context.Background()
 	date := time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)
-	w := &models.Workout{UserID: 3, Date: date, Notes: "note"}
+	started := time.Date(2025, 5, 14, 18, 0, 0, 0, time.UTC)
+	ended := started.Add(75 * time.Minute)
+	w := &models.Workout{UserID: 3, Date: date, Notes: "note", StartedAt: &started, EndedAt: &ended}
 	created := time.Now()
 	updated := created.Add(10 * time.Minute)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes)
-	VALUES ($1, $2, $3)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes, started_at, ended_at)
+	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id, created_at, updated_at, is_active`)).
-		WithArgs(w.UserID, w.Date, w.Notes).
+		WithArgs(w.UserID, w.Date, w.Notes, w.StartedAt, w.EndedAt).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).
 			AddRow(7, created, updated, true))
 
@@ -59,24 +62,44 @@ func TestGetWorkoutsByUserID(t *testing.T) {
 	t2 := time.Date(2025, 5, 11, 0, 0, 0, 0, time.UTC)
 	c2 := time.Now()
 	u2 := c2.Add(2 * time.Minute)
-
-	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "created_at", "updated_at", "is_active"}).
-		AddRow(8, userID, t1, "n1", c1, u1, true).
-		AddRow(9, userID, t2, "n2", c2, u2, true)
-
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
-	FROM Workouts
-	WHERE is_active = TRUE
-	AND user_id = $1`)).
-		WithArgs(userID).
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+		AddRow(8, userID, t1, "n1", nil, nil, nil, nil, c1, u1, true).
+		AddRow(9, userID, t2, "n2", c2, u2, 12, 3150.5, c2, u2, true)
+
+	exerciseID := 3
+	search := "Legs"
+	filter := &models.WorkoutFilter{
+		From:       &from,
+		ExerciseID: &exerciseID,
+		Search:     &search,
+		Page:       2,
+		Limit:      2,
+		Offset:     2,
+		SortOrder:  "desc",
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT COUNT(*) FROM Workouts WHERE is_active = TRUE AND user_id = $1 AND COALESCE(started_at, date) >= $2 AND EXISTS (SELECT 1 FROM WorkoutExercises we WHERE we.workout_id = Workouts.id AND we.exercise_id = $3) AND LOWER(notes) LIKE $4`,
+	)).WithArgs(userID, from, exerciseID, "%legs%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active FROM Workouts WHERE is_active = TRUE AND user_id = $1 AND COALESCE(started_at, date) >= $2 AND EXISTS (SELECT 1 FROM WorkoutExercises we WHERE we.workout_id = Workouts.id AND we.exercise_id = $3) AND LOWER(notes) LIKE $4 ORDER BY COALESCE(started_at, date) desc, id desc LIMIT $5 OFFSET $6`,
+	)).WithArgs(userID, from, exerciseID, "%legs%", filter.Limit, filter.Offset).
 		WillReturnRows(rows)
 
-	list, err := repo.GetWorkoutsByUserID(ctx, userID)
+	list, total, err := repo.GetWorkoutsByUserID(ctx, userID, filter)
 	assert.NoError(t, err)
+	assert.Equal(t, 4, total)
 	assert.Len(t, *list, 2)
 	assert.Equal(t, 8, (*list)[0].ID)
 	assert.Equal(t, t1, (*list)[0].Date)
 	assert.Equal(t, "n2", (*list)[1].Notes)
+	assert.Nil(t, (*list)[0].StartedAt)
+	assert.Equal(t, 12, *(*list)[1].TotalSets)
+	assert.Equal(t, 3150.5, *(*list)[1].TotalVolume)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -95,14 +118,14 @@ func TestGetWorkoutByUserID(t *testing.T) {
 	c := time.Now()
 	u := c.Add(time.Hour)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active
 	FROM Workouts
 	WHERE is_active = TRUE
 	AND user_id = $1
 	AND id = $2`)).
 		WithArgs(userID, workoutID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "created_at", "updated_at", "is_active"}).
-			AddRow(workoutID, userID, d, "notes", c, u, true))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+			AddRow(workoutID, userID, d, "notes", nil, nil, nil, nil, c, u, true))
 
 	w, err := repo.GetWorkoutByUserID(ctx, userID, workoutID)
 	assert.NoError(t, err)
@@ -126,14 +149,14 @@ func TestUpdateWorkoutByUserID(t *testing.T) {
 	updated := created.Add(5 * time.Minute)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts 
-	SET date = $1, notes = $2, updated_at = NOW()
-	WHERE id = $3
+	SET date = $1, notes = $2, started_at = $3, ended_at = $4, updated_at = NOW()
+	WHERE id = $5
 	AND is_active = TRUE
-	AND user_id = $4
-	RETURNING created_at, updated_at, is_active`)).
-		WithArgs(w.Date, w.Notes, w.ID, w.UserID).
-		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "is_active"}).
-			AddRow(created, updated, true))
+	AND user_id = $6
+	RETURNING total_sets, total_volume, created_at, updated_at, is_active`)).
+		WithArgs(w.Date, w.Notes, w.StartedAt, w.EndedAt, w.ID, w.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+			AddRow(nil, nil, created, updated, true))
 
 	err = repo.UpdateWorkoutByUserID(ctx, w)
 	assert.NoError(t, err)
@@ -177,10 +200,10 @@ func TestCreateWorkout_ScanError(t *testing.T) {
 	ctx := context.Background()
 	w := &models.Workout{UserID: 3, Date: time.Now(), Notes: "note"}
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes)
-	VALUES ($1, $2, $3)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes, started_at, ended_at)
+	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id, created_at, updated_at, is_active`)).
-		WithArgs(w.UserID, w.Date, w.Notes).
+		WithArgs(w.UserID, w.Date, w.Notes, w.StartedAt, w.EndedAt).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(nil, nil, nil, nil))
 
 	err := repo.CreateWorkout(ctx, w)
@@ -193,13 +216,26 @@ func TestGetWorkoutsByUserID_QueryError(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 	repo := NewWorkoutRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
-	FROM Workouts
-	WHERE is_active = TRUE
-	AND user_id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active FROM Workouts`)).
 		WillReturnError(fmt.Errorf("query failed"))
 
-	_, err := repo.GetWorkoutsByUserID(context.Background(), 1)
+	_, _, err := repo.GetWorkoutsByUserID(context.Background(), 1, &models.WorkoutFilter{Limit: 20, SortOrder: "desc"})
+	assert.Error(t, err)
+}
+
+func TestGetWorkoutsByUserID_CountError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
+		WillReturnError(fmt.Errorf("count failed"))
+
+	_, _, err := repo.GetWorkoutsByUserID(context.Background(), 1, &models.WorkoutFilter{Limit: 20, SortOrder: "desc"})
 	assert.Error(t, err)
 }
 
@@ -210,16 +246,17 @@ func TestGetWorkoutsByUserID_ScanError(t *testing.T) {
 	repo := NewWorkoutRepository(sqlxDB)
 
 	now := time.Now()
-	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "created_at", "updated_at", "is_active"}).
-		AddRow(1, 1, nil, "n", now, now, true)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
-	FROM Workouts
-	WHERE is_active = TRUE
-	AND user_id = $1`)).
+	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+		AddRow(1, 1, nil, "n", nil, nil, nil, nil, now, now, true)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
 		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
+		WithArgs(1, 20, 0).
 		WillReturnRows(rows)
 
-	_, err := repo.GetWorkoutsByUserID(context.Background(), 1)
+	_, _, err := repo.GetWorkoutsByUserID(context.Background(), 1, &models.WorkoutFilter{Limit: 20, SortOrder: "desc"})
 	assert.Error(t, err)
 }
 
@@ -231,17 +268,18 @@ func TestGetWorkoutsByUserID_RowsErr(t *testing.T) {
 
 	now := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "created_at", "updated_at", "is_active"}).
-		AddRow(1, 1, "not-a-date", "note", now, now, true)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+		AddRow(1, 1, "not-a-date", "note", nil, nil, nil, nil, now, now, true)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
-	FROM Workouts
-	WHERE is_active = TRUE
-	AND user_id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
 		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active FROM Workouts WHERE is_active = TRUE AND user_id = $1`)).
+		WithArgs(1, 20, 0).
 		WillReturnRows(rows)
 
-	_, err := repo.GetWorkoutsByUserID(context.Background(), 1)
+	_, _, err := repo.GetWorkoutsByUserID(context.Background(), 1, &models.WorkoutFilter{Limit: 20, SortOrder: "desc"})
 	assert.Error(t, err)
 }
 
@@ -251,13 +289,13 @@ func TestGetWorkoutByUserID_ScanError(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 	repo := NewWorkoutRepository(sqlxDB)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, created_at, updated_at, is_active
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at, total_sets, total_volume, created_at, updated_at, is_active
 	FROM Workouts
 	WHERE is_active = TRUE
 	AND user_id = $1
 	AND id = $2`)).
 		WithArgs(1, 1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "created_at", "updated_at", "is_active"}).AddRow(nil, nil, nil, nil, nil, nil, nil))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).AddRow(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 
 	_, err := repo.GetWorkoutByUserID(context.Background(), 1, 1)
 	assert.Error(t, err)
@@ -271,13 +309,13 @@ func TestUpdateWorkoutByUserID_ScanError(t *testing.T) {
 
 	w := &models.Workout{ID: 1, UserID: 1, Date: time.Now(), Notes: "n"}
 	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts 
-	SET date = $1, notes = $2, updated_at = NOW()
-	WHERE id = $3
+	SET date = $1, notes = $2, started_at = $3, ended_at = $4, updated_at = NOW()
+	WHERE id = $5
 	AND is_active = TRUE
-	AND user_id = $4
-	RETURNING created_at, updated_at, is_active`)).
-		WithArgs(w.Date, w.Notes, w.ID, w.UserID).
-		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "is_active"}).AddRow(nil, nil, nil))
+	AND user_id = $6
+	RETURNING total_sets, total_volume, created_at, updated_at, is_active`)).
+		WithArgs(w.Date, w.Notes, w.StartedAt, w.EndedAt, w.ID, w.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"total_sets", "total_volume", "created_at", "updated_at", "is_active"}).AddRow(nil, nil, nil, nil, nil))
 
 	err := repo.UpdateWorkoutByUserID(context.Background(), w)
 	assert.Error(t, err)
@@ -316,3 +354,154 @@ func TestDeleteWorkoutByUserID_RowsAffectedError(t *testing.T) {
 	_, err := repo.DeleteWorkoutByUserID(context.Background(), 1, 1)
 	assert.Error(t, err)
 }
+
+func TestStartWorkout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	w := &models.Workout{ID: 12, UserID: 7}
+	date := time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC)
+	started := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts
+	SET started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE
+	AND ended_at IS NULL`)).
+		WithArgs(w.ID, w.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"date", "notes", "started_at", "created_at", "updated_at", "is_active"}).
+			AddRow(date, "Leg day", started, started, started, true))
+
+	err = repo.StartWorkout(context.Background(), w)
+	assert.NoError(t, err)
+	assert.Equal(t, "Leg day", w.Notes)
+	assert.WithinDuration(t, started, *w.StartedAt, time.Second)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartWorkout_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts`)).
+		WithArgs(1, 1).
+		WillReturnError(sql.ErrNoRows)
+
+	err := repo.StartWorkout(context.Background(), &models.Workout{ID: 1, UserID: 1})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestFinishWorkout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	w := &models.Workout{ID: 12, UserID: 7}
+	date := time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC)
+	started := time.Now().Add(-time.Hour)
+	ended := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts w
+	SET ended_at = NOW(), total_sets = totals.sets, total_volume = totals.volume, updated_at = NOW()`)).
+		WithArgs(w.ID, w.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+			AddRow(date, "Leg day", started, ended, 15, 5200.0, started, ended, true))
+
+	err = repo.FinishWorkout(context.Background(), w)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, *w.TotalSets)
+	assert.Equal(t, 5200.0, *w.TotalVolume)
+	assert.WithinDuration(t, ended, *w.EndedAt, time.Second)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinishWorkout_QueryError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts w`)).
+		WillReturnError(fmt.Errorf("update failed"))
+
+	err := repo.FinishWorkout(context.Background(), &models.Workout{ID: 1, UserID: 1})
+	assert.Error(t, err)
+}
+
+func TestCreateWorkoutFromTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	ctx := context.Background()
+	date := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	w := &models.Workout{UserID: 3, Date: date, Notes: "Push day"}
+	created := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts (user_id, date, notes)`)).
+		WithArgs(w.UserID, w.Date, w.Notes).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).
+			AddRow(8, created, created, true))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutExercises (workout_id, exercise_id, sets, reps, weight, notes)
+	SELECT $1, te.exercise_id`)).
+		WithArgs(8, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutSets (workout_exercise_id, set_number, set_type, reps, weight)`)).
+		WithArgs(8).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+
+	err = repo.CreateWorkoutFromTemplate(ctx, w, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, w.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateWorkoutFromTemplate_CopyError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Workouts`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).
+			AddRow(8, time.Now(), time.Now(), true))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutExercises`)).
+		WillReturnError(fmt.Errorf("copy failed"))
+	mock.ExpectRollback()
+
+	err := repo.CreateWorkoutFromTemplate(context.Background(), &models.Workout{UserID: 1}, 2)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"legs", "legs"},
+		{"100%", `100\%`},
+		{"leg_day", `leg\_day`},
+		{`a\b`, `a\\b`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeLike(tt.value))
+	}
+}
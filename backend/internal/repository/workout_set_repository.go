@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type WorkoutSetRepository struct {
+	db *sqlx.DB
+}
+
+func NewWorkoutSetRepository(db *sqlx.DB) *WorkoutSetRepository {
+	return &WorkoutSetRepository{db: db}
+}
+
+const createWorkoutSetQuery = `INSERT INTO WorkoutSets (workout_exercise_id, set_number, set_type, reps, weight, rpe, duration_seconds, distance_meters, heart_rate_avg)
+	VALUES ($1, COALESCE(NULLIF($2, 0), (SELECT COALESCE(MAX(set_number), 0) + 1 FROM WorkoutSets WHERE workout_exercise_id = $1)), $3, NULLIF($4, 0), $5, $6, $7, $8, $9)
+	RETURNING id, set_number, created_at`
+
+func (r *WorkoutSetRepository) CreateSet(ctx context.Context, set *models.WorkoutSet) error {
+	err := r.db.QueryRowContext(
+		ctx,
+		createWorkoutSetQuery,
+		set.WorkoutExerciseID,
+		set.SetNumber,
+		set.SetType,
+		set.Reps,
+		set.Weight,
+		set.RPE,
+		set.DurationSeconds,
+		set.DistanceMeters,
+		set.HeartRateAvg,
+	).Scan(
+		&set.ID,
+		&set.SetNumber,
+		&set.CreatedAt,
+	)
+
+	if err != nil {
+		log.Println("Failed to create workout set:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutSetRepository) CreateSets(ctx context.Context, sets *[]models.WorkoutSet) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Transaction begin error:", err)
+		return err
+	}
+
+	if err := insertWorkoutSets(ctx, tx, *sets); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Commit error:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutSetRepository) GetSetsByWorkoutExerciseID(ctx context.Context, workoutExerciseID int) (*[]models.WorkoutSet, error) {
+	query := `SELECT id, workout_exercise_id, set_number, set_type, COALESCE(reps, 0), weight, rpe,
+	duration_seconds, distance_meters, heart_rate_avg, created_at
+	FROM WorkoutSets
+	WHERE workout_exercise_id = $1
+	ORDER BY set_number`
+
+	rows, err := r.db.QueryContext(ctx, query, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to get workout sets:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWorkoutSets(rows)
+}
+
+func (r *WorkoutSetRepository) GetSetsByWorkoutID(ctx context.Context, workoutID int) (*[]models.WorkoutSet, error) {
+	query := `SELECT ws.id, ws.workout_exercise_id, ws.set_number, ws.set_type, COALESCE(ws.reps, 0), ws.weight, ws.rpe,
+	ws.duration_seconds, ws.distance_meters, ws.heart_rate_avg, ws.created_at
+	FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	WHERE we.workout_id = $1
+	ORDER BY ws.workout_exercise_id, ws.set_number`
+
+	rows, err := r.db.QueryContext(ctx, query, workoutID)
+	if err != nil {
+		log.Println("Failed to get workout sets:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWorkoutSets(rows)
+}
+
+func (r *WorkoutSetRepository) GetSet(ctx context.Context, workoutExerciseID, setID int) (*models.WorkoutSet, error) {
+	query := `SELECT id, workout_exercise_id, set_number, set_type, COALESCE(reps, 0), weight, rpe,
+	duration_seconds, distance_meters, heart_rate_avg, created_at
+	FROM WorkoutSets
+	WHERE workout_exercise_id = $1
+	AND id = $2`
+
+	var set models.WorkoutSet
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		workoutExerciseID,
+		setID,
+	).Scan(
+		&set.ID,
+		&set.WorkoutExerciseID,
+		&set.SetNumber,
+		&set.SetType,
+		&set.Reps,
+		&set.Weight,
+		&set.RPE,
+		&set.DurationSeconds,
+		&set.DistanceMeters,
+		&set.HeartRateAvg,
+		&set.CreatedAt,
+	)
+	if err != nil {
+		log.Println("Failed to get workout set:", err)
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+func (r *WorkoutSetRepository) UpdateSet(ctx context.Context, set *models.WorkoutSet) error {
+	query := `UPDATE WorkoutSets
+	SET set_number = COALESCE(NULLIF($1, 0), set_number), set_type = $2, reps = NULLIF($3, 0), weight = $4, rpe = $5,
+	duration_seconds = $6, distance_meters = $7, heart_rate_avg = $8
+	WHERE id = $9
+	AND workout_exercise_id = $10
+	RETURNING set_number, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		set.SetNumber,
+		set.SetType,
+		set.Reps,
+		set.Weight,
+		set.RPE,
+		set.DurationSeconds,
+		set.DistanceMeters,
+		set.HeartRateAvg,
+		set.ID,
+		set.WorkoutExerciseID,
+	).Scan(
+		&set.SetNumber,
+		&set.CreatedAt,
+	)
+
+	if err != nil {
+		log.Println("Failed to update workout set:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutSetRepository) DeleteSet(ctx context.Context, workoutExerciseID, setID int) (int, error) {
+	query := `DELETE FROM WorkoutSets
+	WHERE id = $1
+	AND workout_exercise_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, setID, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to delete workout set:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Result error:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func scanWorkoutSets(rows *sql.Rows) (*[]models.WorkoutSet, error) {
+	var sets []models.WorkoutSet
+
+	for rows.Next() {
+		var set models.WorkoutSet
+
+		err := rows.Scan(
+			&set.ID,
+			&set.WorkoutExerciseID,
+			&set.SetNumber,
+			&set.SetType,
+			&set.Reps,
+			&set.Weight,
+			&set.RPE,
+			&set.DurationSeconds,
+			&set.DistanceMeters,
+			&set.HeartRateAvg,
+			&set.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to scan workout set:", err)
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &sets, nil
+}
+
+// insertWorkoutSets creates the sets inside the caller's transaction and fills
+// their ids, set numbers and creation times.
+func insertWorkoutSets(ctx context.Context, tx *sql.Tx, sets []models.WorkoutSet) error {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, createWorkoutSetQuery)
+	if err != nil {
+		log.Println("Prepare statement error:", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for i := range sets {
+		set := &sets[i]
+		err = stmt.QueryRowContext(
+			ctx,
+			set.WorkoutExerciseID,
+			set.SetNumber,
+			set.SetType,
+			set.Reps,
+			set.Weight,
+			set.RPE,
+			set.DurationSeconds,
+			set.DistanceMeters,
+			set.HeartRateAvg,
+		).Scan(
+			&set.ID,
+			&set.SetNumber,
+			&set.CreatedAt,
+		)
+		if err != nil {
+			log.Println("Failed to create workout set:", err)
+			return err
+		}
+	}
+
+	return nil
+}
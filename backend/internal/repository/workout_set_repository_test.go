@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	rpe := 8.5
+	set := &models.WorkoutSet{WorkoutExerciseID: 5, SetType: models.SetTypeWorking, Reps: 5, Weight: 100, RPE: &rpe}
+	createdAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutSets (workout_exercise_id, set_number, set_type, reps, weight, rpe, duration_seconds, distance_meters, heart_rate_avg)`)).
+		WithArgs(set.WorkoutExerciseID, 0, set.SetType, set.Reps, set.Weight, set.RPE, set.DurationSeconds, set.DistanceMeters, set.HeartRateAvg).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "set_number", "created_at"}).AddRow(1, 3, createdAt))
+
+	err = repo.CreateSet(ctx, set)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, set.ID)
+	assert.Equal(t, 3, set.SetNumber)
+	assert.WithinDuration(t, createdAt, set.CreatedAt, time.Second)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+	sets := []models.WorkoutSet{
+		{WorkoutExerciseID: 5, SetNumber: 1, SetType: models.SetTypeWorking, Reps: 5, Weight: 100},
+		{WorkoutExerciseID: 5, SetNumber: 2, SetType: models.SetTypeWorking, Reps: 3, Weight: 105},
+		{WorkoutExerciseID: 5, SetNumber: 3, SetType: models.SetTypeWorking, Reps: 1, Weight: 110},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO WorkoutSets`))
+	for i := range sets {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutSets`)).
+			WithArgs(sets[i].WorkoutExerciseID, sets[i].SetNumber, sets[i].SetType, sets[i].Reps, sets[i].Weight, sets[i].RPE, sets[i].DurationSeconds, sets[i].DistanceMeters, sets[i].HeartRateAvg).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "set_number", "created_at"}).AddRow(i+1, sets[i].SetNumber, now))
+	}
+	mock.ExpectCommit()
+
+	err = repo.CreateSets(ctx, &sets)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sets[0].ID)
+	assert.Equal(t, 3, sets[2].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSetsByWorkoutExerciseID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe", "duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}).
+		AddRow(1, 5, 1, "warmup", 10, 60.0, nil, nil, nil, nil, now).
+		AddRow(2, 5, 2, "working", 0, 0.0, nil, 600, 2000.0, 145, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, workout_exercise_id, set_number, set_type, COALESCE(reps, 0), weight, rpe,
+	duration_seconds, distance_meters, heart_rate_avg, created_at
+	FROM WorkoutSets
+	WHERE workout_exercise_id = $1
+	ORDER BY set_number`)).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	sets, err := repo.GetSetsByWorkoutExerciseID(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, *sets, 2)
+	assert.Nil(t, (*sets)[0].DurationSeconds)
+	assert.Equal(t, 600, *(*sets)[1].DurationSeconds)
+	assert.Equal(t, 2000.0, *(*sets)[1].DistanceMeters)
+	assert.Equal(t, 145, *(*sets)[1].HeartRateAvg)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSetsByWorkoutID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe", "duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}).
+		AddRow(1, 5, 1, "working", 5, 100.0, nil, nil, nil, nil, now).
+		AddRow(2, 6, 1, "drop", 12, 40.0, nil, nil, nil, nil, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT ws.id, ws.workout_exercise_id, ws.set_number, ws.set_type, COALESCE(ws.reps, 0), ws.weight, ws.rpe,
+	ws.duration_seconds, ws.distance_meters, ws.heart_rate_avg, ws.created_at
+	FROM WorkoutSets ws
+	INNER JOIN WorkoutExercises we ON ws.workout_exercise_id = we.id
+	WHERE we.workout_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	sets, err := repo.GetSetsByWorkoutID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, *sets, 2)
+	assert.Equal(t, 6, (*sets)[1].WorkoutExerciseID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, workout_exercise_id, set_number, set_type, COALESCE(reps, 0), weight, rpe,
+	duration_seconds, distance_meters, heart_rate_avg, created_at
+	FROM WorkoutSets
+	WHERE workout_exercise_id = $1
+	AND id = $2`)).
+		WithArgs(5, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe", "duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}).
+			AddRow(2, 5, 2, "failure", 8, 80.0, 10.0, nil, nil, nil, now))
+
+	set, err := repo.GetSet(ctx, 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "failure", set.SetType)
+	assert.Equal(t, 80.0, set.Weight)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+	set := &models.WorkoutSet{ID: 2, WorkoutExerciseID: 5, SetType: models.SetTypeWorking, Reps: 6, Weight: 102.5}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutSets
+	SET set_number = COALESCE(NULLIF($1, 0), set_number), set_type = $2, reps = NULLIF($3, 0), weight = $4, rpe = $5,
+	duration_seconds = $6, distance_meters = $7, heart_rate_avg = $8
+	WHERE id = $9
+	AND workout_exercise_id = $10
+	RETURNING set_number, created_at`)).
+		WithArgs(set.SetNumber, set.SetType, set.Reps, set.Weight, set.RPE, set.DurationSeconds, set.DistanceMeters, set.HeartRateAvg, set.ID, set.WorkoutExerciseID).
+		WillReturnRows(sqlmock.NewRows([]string{"set_number", "created_at"}).AddRow(2, now))
+
+	err = repo.UpdateSet(ctx, set)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, set.SetNumber)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutSetRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutSets
+	WHERE id = $1
+	AND workout_exercise_id = $2`)).
+		WithArgs(2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.DeleteSet(context.Background(), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkoutSetRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewWorkoutSetRepository(sqlxDB)
+
+	t.Run("CreateSet error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutSets`)).
+			WillReturnError(errors.New("insert error"))
+
+		err := repository.CreateSet(context.Background(), &models.WorkoutSet{})
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateSets rollback on insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO WorkoutSets`))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutSets`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateSets(context.Background(), &[]models.WorkoutSet{{WorkoutExerciseID: 1, Reps: 5}})
+		assert.Error(t, err)
+	})
+
+	t.Run("GetSetsByWorkoutExerciseID scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe", "duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}).
+			AddRow("bad", 5, 1, "working", 5, 100.0, nil, nil, nil, nil, time.Now())
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, workout_exercise_id, set_number`)).
+			WillReturnRows(rows)
+
+		_, err := repository.GetSetsByWorkoutExerciseID(context.Background(), 5)
+		assert.Error(t, err)
+	})
+
+	t.Run("GetSet error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, workout_exercise_id, set_number`)).
+			WillReturnError(errors.New("get error"))
+
+		_, err := repository.GetSet(context.Background(), 5, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateSet error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutSets`)).
+			WillReturnError(errors.New("update error"))
+
+		err := repository.UpdateSet(context.Background(), &models.WorkoutSet{})
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteSet rows affected error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutSets`)).
+			WillReturnResult(sqlmock.NewErrorResult(errors.New("rows affected error")))
+
+		_, err := repository.DeleteSet(context.Background(), 5, 1)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type WorkoutTemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewWorkoutTemplateRepository(db *sqlx.DB) *WorkoutTemplateRepository {
+	return &WorkoutTemplateRepository{db: db}
+}
+
+func (r *WorkoutTemplateRepository) CreateTemplate(ctx context.Context, template *models.WorkoutTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO WorkoutTemplates (user_id, name, notes)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		template.UserID,
+		template.Name,
+		template.Notes,
+	).Scan(
+		&template.ID,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&template.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create workout template:", err)
+		return err
+	}
+
+	if err = insertTemplateExercises(ctx, tx, template); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutTemplateRepository) CreateTemplateFromWorkout(ctx context.Context, template *models.WorkoutTemplate, workoutID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	templateQuery := `INSERT INTO WorkoutTemplates (user_id, name, notes)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		templateQuery,
+		template.UserID,
+		template.Name,
+		template.Notes,
+	).Scan(
+		&template.ID,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&template.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to create workout template:", err)
+		return err
+	}
+
+	exercisesQuery := `INSERT INTO WorkoutTemplateExercises (template_id, exercise_id, position, target_sets, target_reps, target_weight, notes)
+	SELECT $1, we.exercise_id, ROW_NUMBER() OVER (ORDER BY we.id),
+		COALESCE(NULLIF(ws.set_count, 0), we.sets, 1),
+		COALESCE(ws.reps, we.reps),
+		COALESCE(ws.weight, we.weight, 0),
+		COALESCE(we.notes, '')
+	FROM WorkoutExercises we
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) OVER () AS set_count, reps, weight
+		FROM WorkoutSets
+		WHERE workout_exercise_id = we.id
+		AND set_type <> 'warmup'
+		ORDER BY weight DESC, reps DESC NULLS LAST
+		LIMIT 1
+	) ws ON TRUE
+	WHERE we.workout_id = $2`
+
+	_, err = tx.ExecContext(ctx, exercisesQuery, template.ID, workoutID)
+	if err != nil {
+		log.Println("Failed to copy workout exercises to template:", err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutTemplateRepository) GetTemplatesByUserID(ctx context.Context, userID int) (*[]models.WorkoutTemplate, error) {
+	query := `SELECT id, user_id, name, notes, created_at, updated_at, is_active
+	FROM WorkoutTemplates
+	WHERE is_active = TRUE
+	AND user_id = $1
+	ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println("Failed to get workout templates:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []models.WorkoutTemplate
+	for rows.Next() {
+		var template models.WorkoutTemplate
+		err := rows.Scan(
+			&template.ID,
+			&template.UserID,
+			&template.Name,
+			&template.Notes,
+			&template.CreatedAt,
+			&template.UpdatedAt,
+			&template.IsActive,
+		)
+		if err != nil {
+			log.Println("Failed to scan workout template:", err)
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &templates, nil
+}
+
+func (r *WorkoutTemplateRepository) GetTemplateByUserID(ctx context.Context, userID, templateID int) (*models.WorkoutTemplate, error) {
+	query := `SELECT id, user_id, name, notes, created_at, updated_at, is_active
+	FROM WorkoutTemplates
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`
+
+	var template models.WorkoutTemplate
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		userID,
+		templateID,
+	).Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.Notes,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&template.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to get workout template:", err)
+		return nil, err
+	}
+
+	exercisesQuery := `SELECT te.id, te.template_id, te.exercise_id, te.position, te.target_sets, te.target_reps, te.target_weight, te.notes,
+	e.id, e.name, e.description
+	FROM WorkoutTemplateExercises te
+	INNER JOIN Exercises e ON te.exercise_id = e.id
+	WHERE te.template_id = $1
+	ORDER BY te.position`
+
+	rows, err := r.db.QueryContext(ctx, exercisesQuery, template.ID)
+	if err != nil {
+		log.Println("Failed to get workout template exercises:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var templateExercise models.WorkoutTemplateExercise
+		var exercise models.WorkoutExerciseItem
+
+		err := rows.Scan(
+			&templateExercise.ID,
+			&templateExercise.TemplateID,
+			&templateExercise.ExerciseID,
+			&templateExercise.Position,
+			&templateExercise.TargetSets,
+			&templateExercise.TargetReps,
+			&templateExercise.TargetWeight,
+			&templateExercise.Notes,
+			&exercise.ID,
+			&exercise.Name,
+			&exercise.Description,
+		)
+		if err != nil {
+			log.Println("Failed to scan workout template exercise:", err)
+			return nil, err
+		}
+
+		if exercise.ID != 0 {
+			templateExercise.Exercise = &exercise
+		}
+
+		template.Exercises = append(template.Exercises, templateExercise)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Rows error:", err)
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *WorkoutTemplateRepository) UpdateTemplate(ctx context.Context, template *models.WorkoutTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Failed to begin transaction:", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			log.Println("Error, rollback transaction")
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE WorkoutTemplates
+	SET name = $1, notes = $2, updated_at = NOW()
+	WHERE id = $3
+	AND user_id = $4
+	AND is_active = TRUE
+	RETURNING created_at, updated_at, is_active`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		template.Name,
+		template.Notes,
+		template.ID,
+		template.UserID,
+	).Scan(
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&template.IsActive,
+	)
+	if err != nil {
+		log.Println("Failed to update workout template:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM WorkoutTemplateExercises WHERE template_id = $1`, template.ID)
+	if err != nil {
+		log.Println("Failed to clear workout template exercises:", err)
+		return err
+	}
+
+	if err = insertTemplateExercises(ctx, tx, template); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Println("Failed to commit transaction:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkoutTemplateRepository) DeleteTemplate(ctx context.Context, userID, templateID int) (int, error) {
+	query := `UPDATE WorkoutTemplates
+	SET is_active = FALSE, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, templateID, userID)
+	if err != nil {
+		log.Println("Failed to delete workout template:", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Failed to delete workout template result:", err)
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func insertTemplateExercises(ctx context.Context, tx *sql.Tx, template *models.WorkoutTemplate) error {
+	query := `INSERT INTO WorkoutTemplateExercises (template_id, exercise_id, position, target_sets, target_reps, target_weight, notes)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id`
+
+	for i := range template.Exercises {
+		templateExercise := &template.Exercises[i]
+		templateExercise.TemplateID = template.ID
+		templateExercise.Position = i + 1
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			templateExercise.TemplateID,
+			templateExercise.ExerciseID,
+			templateExercise.Position,
+			templateExercise.TargetSets,
+			templateExercise.TargetReps,
+			templateExercise.TargetWeight,
+			templateExercise.Notes,
+		).Scan(&templateExercise.ID)
+		if err != nil {
+			log.Println("Failed to add exercise to workout template:", err)
+			return err
+		}
+	}
+
+	return nil
+}
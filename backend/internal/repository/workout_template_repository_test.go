@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"backend/internal/models"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+	reps := 8
+	template := &models.WorkoutTemplate{
+		UserID: 3,
+		Name:   "Push day",
+		Exercises: []models.WorkoutTemplateExercise{
+			{ExerciseID: 1, TargetSets: 4, TargetReps: &reps, TargetWeight: 80},
+			{ExerciseID: 2, TargetSets: 3, TargetWeight: 20},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplates (user_id, name, notes)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at, is_active`)).
+		WithArgs(template.UserID, template.Name, template.Notes).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(5, now, now, true))
+
+	for i, te := range template.Exercises {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplateExercises (template_id, exercise_id, position, target_sets, target_reps, target_weight, notes)`)).
+			WithArgs(5, te.ExerciseID, i+1, te.TargetSets, te.TargetReps, te.TargetWeight, te.Notes).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 10))
+	}
+	mock.ExpectCommit()
+
+	err = repo.CreateTemplate(ctx, template)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, template.ID)
+	assert.Equal(t, 2, template.Exercises[1].Position)
+	assert.Equal(t, 11, template.Exercises[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTemplateFromWorkout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+	template := &models.WorkoutTemplate{UserID: 3, Name: "Legs"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplates (user_id, name, notes)`)).
+		WithArgs(template.UserID, template.Name, template.Notes).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(6, now, now, true))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutTemplateExercises (template_id, exercise_id, position, target_sets, target_reps, target_weight, notes)
+	SELECT $1, we.exercise_id, ROW_NUMBER() OVER (ORDER BY we.id),
+		COALESCE(NULLIF(ws.set_count, 0), we.sets, 1),
+		COALESCE(ws.reps, we.reps),`)).
+		WithArgs(6, 9).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	err = repo.CreateTemplateFromWorkout(ctx, template, 9)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, template.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTemplatesByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "notes", "created_at", "updated_at", "is_active"}).
+		AddRow(1, 3, "Legs", "", now, now, true).
+		AddRow(2, 3, "Push day", "chest focus", now, now, true)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name, notes, created_at, updated_at, is_active
+	FROM WorkoutTemplates
+	WHERE is_active = TRUE
+	AND user_id = $1
+	ORDER BY name`)).
+		WithArgs(3).
+		WillReturnRows(rows)
+
+	templates, err := repo.GetTemplatesByUserID(ctx, 3)
+	assert.NoError(t, err)
+	assert.Len(t, *templates, 2)
+	assert.Equal(t, "Push day", (*templates)[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTemplateByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name, notes, created_at, updated_at, is_active
+	FROM WorkoutTemplates
+	WHERE is_active = TRUE
+	AND user_id = $1
+	AND id = $2`)).
+		WithArgs(3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "notes", "created_at", "updated_at", "is_active"}).
+			AddRow(1, 3, "Push day", "", now, now, true))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT te.id, te.template_id, te.exercise_id, te.position, te.target_sets, te.target_reps, te.target_weight, te.notes,
+	e.id, e.name, e.description
+	FROM WorkoutTemplateExercises te`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "template_id", "exercise_id", "position", "target_sets", "target_reps", "target_weight", "notes", "id", "name", "description"}).
+			AddRow(10, 1, 4, 1, 4, 8, 80.0, "", 4, "Bench press", "Barbell bench press").
+			AddRow(11, 1, 7, 2, 3, 12, 20.0, "", 7, "Dips", "Bodyweight dips").
+			AddRow(12, 1, 9, 3, 3, nil, 0.0, "", 9, "Plank", "Front plank hold"))
+
+	template, err := repo.GetTemplateByUserID(ctx, 3, 1)
+	assert.NoError(t, err)
+	assert.Len(t, template.Exercises, 3)
+	assert.Equal(t, "Dips", template.Exercises[1].Exercise.Name)
+	assert.Equal(t, 12, *template.Exercises[1].TargetReps)
+	assert.Nil(t, template.Exercises[2].TargetReps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	ctx := context.Background()
+	now := time.Now()
+	reps := 5
+	template := &models.WorkoutTemplate{
+		ID:        1,
+		UserID:    3,
+		Name:      "Pull day",
+		Exercises: []models.WorkoutTemplateExercise{{ExerciseID: 5, TargetSets: 5, TargetReps: &reps, TargetWeight: 120}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutTemplates
+	SET name = $1, notes = $2, updated_at = NOW()`)).
+		WithArgs(template.Name, template.Notes, template.ID, template.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "is_active"}).AddRow(now, now, true))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM WorkoutTemplateExercises WHERE template_id = $1`)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplateExercises`)).
+		WithArgs(1, 5, 1, 5, 5, 120.0, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+	mock.ExpectCommit()
+
+	err = repo.UpdateTemplate(ctx, template)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, template.Exercises[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := NewWorkoutTemplateRepository(sqlxDB)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE WorkoutTemplates
+	SET is_active = FALSE, updated_at = NOW()`)).
+		WithArgs(1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.DeleteTemplate(context.Background(), 3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkoutTemplateRepositoryNegative(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repository := NewWorkoutTemplateRepository(sqlxDB)
+
+	t.Run("CreateTemplate rollback on exercise insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplates`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(1, time.Now(), time.Now(), true))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplateExercises`)).
+			WillReturnError(errors.New("insert error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateTemplate(context.Background(), &models.WorkoutTemplate{
+			Exercises: []models.WorkoutTemplateExercise{{ExerciseID: 1, TargetSets: 1}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateTemplateFromWorkout rollback on copy error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO WorkoutTemplates`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(1, time.Now(), time.Now(), true))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO WorkoutTemplateExercises`)).
+			WillReturnError(errors.New("copy error"))
+		mock.ExpectRollback()
+
+		err := repository.CreateTemplateFromWorkout(context.Background(), &models.WorkoutTemplate{}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("GetTemplateByUserID error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, name`)).
+			WillReturnError(errors.New("get error"))
+
+		_, err := repository.GetTemplateByUserID(context.Background(), 1, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateTemplate rollback on update error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE WorkoutTemplates`)).
+			WillReturnError(errors.New("update error"))
+		mock.ExpectRollback()
+
+		err := repository.UpdateTemplate(context.Background(), &models.WorkoutTemplate{})
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteTemplate error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE WorkoutTemplates`)).
+			WillReturnError(errors.New("delete error"))
+
+		_, err := repository.DeleteTemplate(context.Background(), 1, 1)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,48 @@
+package security
+
+import (
+	"backend/internal/config"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// InitAuthLogger returns the logger for failed logins. Lines go to stdout
+// and, when AUTH_LOG_FILE is set, to that file for the fail2ban jail.
+// The ip attribute must stay first, the filter in fail2ban/filter.d relies
+// on it.
+func InitAuthLogger(envs *config.Envs) *slog.Logger {
+	var w io.Writer = os.Stdout
+
+	if envs.AuthLogFile != "" {
+		file, err := os.OpenFile(envs.AuthLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			log.Println("Failed to open auth log file, using stdout:", err)
+		} else {
+			w = io.MultiWriter(os.Stdout, file)
+		}
+	}
+
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// UTC keeps the date parseable by fail2ban regardless of the
+			// container timezone.
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				a.Value = slog.StringValue(a.Value.Time().UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+			}
+			return a
+		},
+	}))
+}
+
+func logLoginFailed(logger *slog.Logger, ip, email, reason string, failures int64, lockout time.Duration) {
+	logger.Warn("login failed",
+		slog.String("ip", ip),
+		slog.String("email", email),
+		slog.String("reason", reason),
+		slog.Int64("failures", failures),
+		slog.Duration("lockout", lockout),
+	)
+}
@@ -0,0 +1,168 @@
+package security
+
+import (
+	"backend/internal/config"
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Failed logins are counted per account and per IP within loginFailureWindow.
+// Once a counter reaches its threshold the key is locked for
+// loginLockoutBase, doubled with every further failure up to maxLoginLockout.
+// The IP threshold is higher since many users can share one address.
+const (
+	loginFailureWindow = 15 * time.Minute
+	loginLockoutBase   = time.Minute
+	maxLoginLockout    = time.Hour
+
+	accountFailureThreshold = 5
+	ipFailureThreshold      = 20
+
+	loginFailuresKeyFmt = "login_failures:%s:%s"
+	loginLockKeyFmt     = "login_lock:%s:%s"
+)
+
+// Reasons written to the auth log.
+const (
+	ReasonUnknownEmail    = "unknown_email"
+	ReasonInvalidPassword = "invalid_password"
+	ReasonInvalidCode     = "invalid_2fa_code"
+	ReasonLocked          = "locked"
+)
+
+type LoginThrottler struct {
+	redis  *redis.Client
+	logger *slog.Logger
+}
+
+func NewLoginThrottler(redis *redis.Client, logger *slog.Logger) *LoginThrottler {
+	return &LoginThrottler{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+func InitLoginThrottler(redis *redis.Client, envs *config.Envs) *LoginThrottler {
+	return NewLoginThrottler(redis, InitAuthLogger(envs))
+}
+
+// Check returns how long the account or the IP is still locked, 0 when the
+// login may proceed. Attempts while locked are logged too, so fail2ban sees
+// clients that ignore the lockout.
+func (t *LoginThrottler) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	var lockout time.Duration
+	for _, key := range []string{lockKey("email", normalizeEmail(email)), lockKey("ip", ip)} {
+		ttl, err := t.redis.PTTL(ctx, key).Result()
+		if err != nil {
+			log.Println("Failed to get login lock:", err)
+			return 0, err
+		}
+		lockout = max(lockout, ttl)
+	}
+
+	if lockout > 0 {
+		logLoginFailed(t.logger, ip, normalizeEmail(email), ReasonLocked, 0, lockout)
+	}
+
+	return lockout, nil
+}
+
+// Fail records a failed attempt and returns the lockout it caused, 0 when
+// the thresholds are not reached yet.
+func (t *LoginThrottler) Fail(ctx context.Context, email, ip, reason string) (time.Duration, error) {
+	email = normalizeEmail(email)
+
+	accountFailures, accountLockout, err := t.fail(ctx, "email", email, accountFailureThreshold)
+	if err != nil {
+		return 0, err
+	}
+
+	_, ipLockout, err := t.fail(ctx, "ip", ip, ipFailureThreshold)
+	if err != nil {
+		return 0, err
+	}
+
+	lockout := max(accountLockout, ipLockout)
+	logLoginFailed(t.logger, ip, email, reason, accountFailures, lockout)
+
+	return lockout, nil
+}
+
+// Reset clears the account counter after a successful login. The IP counter
+// is kept, otherwise a valid account of the attacker would reset it.
+func (t *LoginThrottler) Reset(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	if err := t.redis.Del(ctx, failuresKey("email", email), lockKey("email", email)).Err(); err != nil {
+		log.Println("Failed to reset login failures:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (t *LoginThrottler) fail(ctx context.Context, scope, value string, threshold int64) (int64, time.Duration, error) {
+	key := failuresKey(scope, value)
+
+	failures, err := t.redis.Incr(ctx, key).Result()
+	if err != nil {
+		log.Println("Failed to count login failure:", err)
+		return 0, 0, err
+	}
+
+	if failures == 1 {
+		if err := t.redis.Expire(ctx, key, loginFailureWindow).Err(); err != nil {
+			log.Println("Failed to set login failures expiration:", err)
+			return 0, 0, err
+		}
+	}
+
+	lockout := lockoutDuration(failures, threshold)
+	if lockout == 0 {
+		return failures, 0, nil
+	}
+
+	// The counter has to outlive the lock, so the next failure after it
+	// expires doubles the lockout instead of starting over.
+	if err := t.redis.Expire(ctx, key, lockout+loginFailureWindow).Err(); err != nil {
+		log.Println("Failed to set login failures expiration:", err)
+		return 0, 0, err
+	}
+
+	if err := t.redis.Set(ctx, lockKey(scope, value), failures, lockout).Err(); err != nil {
+		log.Println("Failed to lock login:", err)
+		return 0, 0, err
+	}
+
+	return failures, lockout, nil
+}
+
+func lockoutDuration(failures, threshold int64) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+
+	lockout := loginLockoutBase
+	for i := threshold; i < failures && lockout < maxLoginLockout; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, maxLoginLockout)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func failuresKey(scope, value string) string {
+	return fmt.Sprintf(loginFailuresKeyFmt, scope, value)
+}
+
+func lockKey(scope, value string) string {
+	return fmt.Sprintf(loginLockKeyFmt, scope, value)
+}
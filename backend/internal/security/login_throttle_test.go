@@ -0,0 +1,32 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		failures int64
+		expected time.Duration
+	}{
+		{1, 0},
+		{4, 0},
+		{5, time.Minute},
+		{6, 2 * time.Minute},
+		{8, 8 * time.Minute},
+		{10, 32 * time.Minute},
+		{11, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, lockoutDuration(test.failures, accountFailureThreshold), test.failures)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "user@example.com", normalizeEmail(" User@Example.COM "))
+}
@@ -25,6 +25,14 @@ func SetupRoutes(handlers *handlers.Handlers, appmiddlewares *appmiddlewares.App
 
 		r.Post("/register", handlers.AuthHandler.Register)
 		r.Post("/login", handlers.AuthHandler.Login)
+		r.Post("/login/2fa", handlers.AuthHandler.LoginTwoFactor)
+		r.Route("/auth", func(r chi.Router) {
+			r.Post("/refresh", handlers.AuthHandler.Refresh)
+			r.Post("/verify-email/request", handlers.AuthHandler.RequestEmailVerification)
+			r.Post("/verify-email/confirm", handlers.AuthHandler.VerifyEmail)
+			r.Post("/password-reset/request", handlers.AuthHandler.RequestPasswordReset)
+			r.Post("/password-reset/confirm", handlers.AuthHandler.ResetPassword)
+		})
 		r.Get("/swagger/*", httpSwagger.WrapHandler)
 
 		r.Route("/oauth/fatsecret", func(r chi.Router) {
@@ -35,6 +43,7 @@ func SetupRoutes(handlers *handlers.Handlers, appmiddlewares *appmiddlewares.App
 			r.Use(appmiddlewares.AppAuthMiddlreware.AuthMiddleware())
 
 			r.Post("/logout", handlers.AuthHandler.Logout)
+			r.Post("/logout/all", handlers.AuthHandler.LogoutAll)
 
 			r.Get("/connect/fatsecret", handlers.FatSecretAuthHandler.ConnectFatSecret)
 
@@ -42,25 +51,12 @@ func SetupRoutes(handlers *handlers.Handlers, appmiddlewares *appmiddlewares.App
 				r.Get("/{date}", handlers.NutritionHandler.GetDailyNutrition)
 			})
 
-			r.Route("/exercises", func(r chi.Router) {
-				r.Get("/{id}", handlers.ExerciseHandler.GetExercise)
-				r.Get("/", handlers.ExerciseHandler.GetExercises)
-
-				r.Group(func(r chi.Router) {
-					r.Use(appmiddlewares.AppRoleMiddleware.RoleMiddleware("admin", "moderator"))
-
-					r.Post("/", handlers.ExerciseHandler.CreateExercise)
-					r.Put("/{id}", handlers.ExerciseHandler.UpdateExercise)
-					r.Delete("/{id}", handlers.ExerciseHandler.DeleteExercise)
-				})
-			})
-
 			r.Route("/categories", func(r chi.Router) {
 				r.Get("/{id}", handlers.CategoryHandler.GetCategory)
 				r.Get("/", handlers.CategoryHandler.GetCategories)
 
 				r.Group(func(r chi.Router) {
-					r.Use(appmiddlewares.AppRoleMiddleware.RoleMiddleware("admin"))
+					r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("categories:write"))
 
 					r.Post("/", handlers.CategoryHandler.CreateCategory)
 					r.Put("/{id}", handlers.CategoryHandler.UpdateCategory)
@@ -68,34 +64,196 @@ func SetupRoutes(handlers *handlers.Handlers, appmiddlewares *appmiddlewares.App
 				})
 			})
 
-			r.Route("/users", func(r chi.Router) {
-				r.Get("/me", handlers.UserHandler.GetCurrentUser)
-				r.Post("/{id}/roles", handlers.UserHandler.AddRoleToUser)
-				r.Get("/{id}/roles", handlers.UserHandler.GetUserRoles)
+			r.Route("/invitations", func(r chi.Router) {
+				r.Post("/{id}/accept", handlers.ProgramHandler.AcceptInvitation)
+				r.Delete("/{id}", handlers.ProgramHandler.DeclineInvitation)
+				r.Get("/", handlers.ProgramHandler.GetInvitations)
 			})
 
-			r.Route("/workouts", func(r chi.Router) {
-				r.Get("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.GetExerciseByWorkoutID)
-				r.Put("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.UpdateExerciseInWorkout)
-				r.Delete("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.DeleteExerciseByWorkoutID)
+			r.Route("/trainers", func(r chi.Router) {
+				r.Delete("/{trainerID}", handlers.ProgramHandler.LeaveTrainer)
+			})
 
-				r.Post("/{id}/exercises", handlers.WorkoutExerciseHandler.AddExerciseToWorkout)
-				r.Get("/{id}/exercises", handlers.WorkoutExerciseHandler.GetExercisesByWorkoutID)
+			r.Route("/trainer", func(r chi.Router) {
+				r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("clients:manage"))
 
-				r.Get("/{id}", handlers.WorkoutHandler.GetWorkoutByUserID)
-				r.Put("/{id}", handlers.WorkoutHandler.UpdateWorkoutByUserID)
-				r.Delete("/{id}", handlers.WorkoutHandler.DeleteWorkoutByUserID)
+				r.Get("/clients/{clientID}/assignments", handlers.ProgramHandler.GetClientAssignments)
+				r.Get("/clients/{clientID}/training-time", handlers.AnalyticsHandler.GetClientTrainingTime)
+				r.Delete("/clients/{clientID}", handlers.ProgramHandler.RemoveClient)
+				r.Post("/clients", handlers.ProgramHandler.AddClient)
+				r.Get("/clients", handlers.ProgramHandler.GetClients)
 
-				r.Post("/", handlers.WorkoutHandler.CreateWorkout)
-				r.Get("/", handlers.WorkoutHandler.GetWorkoutsByUserID)
+				r.Post("/programs/{id}/assign", handlers.ProgramHandler.AssignProgram)
+				r.Get("/programs/{id}", handlers.ProgramHandler.GetProgram)
+				r.Put("/programs/{id}", handlers.ProgramHandler.UpdateProgram)
+				r.Delete("/programs/{id}", handlers.ProgramHandler.DeleteProgram)
+				r.Post("/programs", handlers.ProgramHandler.CreateProgram)
+				r.Get("/programs", handlers.ProgramHandler.GetPrograms)
 
+				r.Get("/assignments/{id}/schedule", handlers.ProgramHandler.GetClientSchedule)
+				r.Delete("/assignments/{id}", handlers.ProgramHandler.CancelAssignment)
 			})
 
-			r.Route("/foods", func(r chi.Router) {
-				r.Get("/{date}", handlers.FoodHandler.GetFood)
-				r.Post("/", handlers.FoodHandler.AddFood)
+			r.Route("/admin", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("roles:manage"))
+
+					r.Put("/roles/{id}/permissions", handlers.RoleHandler.SetRolePermissions)
+					r.Post("/roles", handlers.RoleHandler.CreateRole)
+					r.Get("/roles", handlers.RoleHandler.GetRoles)
+					r.Get("/permissions", handlers.RoleHandler.GetPermissions)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("users:manage"))
+
+					r.Post("/users/{id}/deactivate", handlers.AdminHandler.DeactivateUser)
+					r.Post("/users/{id}/reactivate", handlers.AdminHandler.ReactivateUser)
+					r.Post("/users/{id}/password-reset", handlers.AdminHandler.ForcePasswordReset)
+					r.Get("/users/{id}", handlers.AdminHandler.GetUser)
+					r.Get("/users", handlers.AdminHandler.SearchUsers)
+				})
 			})
 		})
+
+		// The routes below also accept API tokens with the scope of the resource.
+		r.Route("/users", func(r chi.Router) {
+			r.With(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("records")).Get("/me/records", handlers.PersonalRecordHandler.GetMyRecords)
+
+			r.Group(func(r chi.Router) {
+				r.Use(appmiddlewares.AppAuthMiddlreware.AuthMiddleware())
+
+				r.Get("/me/sessions", handlers.AuthHandler.GetSessions)
+				r.Delete("/me/sessions/{id}", handlers.AuthHandler.RevokeSession)
+				r.Get("/me/2fa", handlers.TwoFactorHandler.GetStatus)
+				r.Post("/me/2fa/setup", handlers.TwoFactorHandler.Setup)
+				r.Post("/me/2fa/enable", handlers.TwoFactorHandler.Enable)
+				r.Post("/me/2fa/disable", handlers.TwoFactorHandler.Disable)
+				r.Post("/me/2fa/recovery-codes", handlers.TwoFactorHandler.RegenerateRecoveryCodes)
+				r.Delete("/me/api-tokens/{id}", handlers.APITokenHandler.RevokeToken)
+				r.Post("/me/api-tokens", handlers.APITokenHandler.CreateToken)
+				r.Get("/me/api-tokens", handlers.APITokenHandler.GetTokens)
+				r.Get("/me/roles", handlers.UserHandler.GetMyRoles)
+				r.Get("/me/permissions", handlers.RoleHandler.GetMyPermissions)
+				r.Get("/me/export/{id}/download", handlers.DataExportHandler.DownloadExport)
+				r.Post("/me/export", handlers.DataExportHandler.RequestExport)
+				r.Get("/me/export", handlers.DataExportHandler.GetExport)
+				r.Post("/me/password", handlers.AccountHandler.ChangePassword)
+				r.Get("/me", handlers.UserHandler.GetCurrentUser)
+				r.Patch("/me", handlers.AccountHandler.UpdateProfile)
+				r.Delete("/me", handlers.AccountHandler.DeleteAccount)
+
+				r.Group(func(r chi.Router) {
+					r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("roles:manage"))
+
+					r.Delete("/{id}/roles/{roleID}", handlers.UserHandler.RemoveRoleFromUser)
+					r.Post("/{id}/roles", handlers.UserHandler.AddRoleToUser)
+					r.Get("/{id}/roles", handlers.UserHandler.GetUserRoles)
+				})
+			})
+		})
+
+		r.Route("/exercises", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("exercises"))
+
+			r.Get("/{id}/records", handlers.PersonalRecordHandler.GetExerciseRecords)
+			r.Get("/{id}", handlers.ExerciseHandler.GetExercise)
+			r.Get("/", handlers.ExerciseHandler.GetExercises)
+
+			r.Group(func(r chi.Router) {
+				r.Use(appmiddlewares.AppPermissionMiddleware.RequirePermission("exercises:write"))
+
+				r.Post("/", handlers.ExerciseHandler.CreateExercise)
+				r.Put("/{id}", handlers.ExerciseHandler.UpdateExercise)
+				r.Delete("/{id}", handlers.ExerciseHandler.DeleteExercise)
+			})
+		})
+
+		r.With(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("exercises")).Get("/muscle-groups", handlers.ExerciseHandler.GetMuscleGroups)
+
+		r.Route("/templates", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("templates"))
+
+			r.Post("/from-workout/{workoutID}", handlers.WorkoutTemplateHandler.CreateTemplateFromWorkout)
+
+			r.Get("/{id}", handlers.WorkoutTemplateHandler.GetTemplate)
+			r.Put("/{id}", handlers.WorkoutTemplateHandler.UpdateTemplate)
+			r.Delete("/{id}", handlers.WorkoutTemplateHandler.DeleteTemplate)
+
+			r.Post("/", handlers.WorkoutTemplateHandler.CreateTemplate)
+			r.Get("/", handlers.WorkoutTemplateHandler.GetTemplates)
+		})
+
+		r.Route("/programs", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("programs"))
+
+			r.Get("/{id}/schedule", handlers.ProgramHandler.GetMySchedule)
+			r.Get("/", handlers.ProgramHandler.GetMyPrograms)
+		})
+
+		r.Route("/workouts", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("workouts"))
+
+			r.Post("/from-template/{templateID}", handlers.WorkoutHandler.CreateWorkoutFromTemplate)
+
+			r.Post("/{id}/start", handlers.WorkoutSessionHandler.StartSession)
+			r.Post("/{id}/finish", handlers.WorkoutSessionHandler.FinishSession)
+			r.Get("/{id}/live", handlers.WorkoutSessionHandler.LiveSession)
+
+			r.Get("/{id}/exercises/{workoutExerciseID}/sets/{setID}", handlers.WorkoutSetHandler.GetSet)
+			r.Put("/{id}/exercises/{workoutExerciseID}/sets/{setID}", handlers.WorkoutSetHandler.UpdateSet)
+			r.Delete("/{id}/exercises/{workoutExerciseID}/sets/{setID}", handlers.WorkoutSetHandler.DeleteSet)
+
+			r.Post("/{id}/exercises/{workoutExerciseID}/sets", handlers.WorkoutSetHandler.CreateSet)
+			r.Get("/{id}/exercises/{workoutExerciseID}/sets", handlers.WorkoutSetHandler.GetSets)
+
+			r.Get("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.GetExerciseByWorkoutID)
+			r.Put("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.UpdateExerciseInWorkout)
+			r.Delete("/{id}/exercises/{workoutExerciseID}", handlers.WorkoutExerciseHandler.DeleteExerciseByWorkoutID)
+
+			r.Post("/{id}/exercises", handlers.WorkoutExerciseHandler.AddExerciseToWorkout)
+			r.Get("/{id}/exercises", handlers.WorkoutExerciseHandler.GetExercisesByWorkoutID)
+
+			r.Get("/{id}", handlers.WorkoutHandler.GetWorkoutByUserID)
+			r.Put("/{id}", handlers.WorkoutHandler.UpdateWorkoutByUserID)
+			r.Delete("/{id}", handlers.WorkoutHandler.DeleteWorkoutByUserID)
+
+			r.Post("/", handlers.WorkoutHandler.CreateWorkout)
+			r.Get("/", handlers.WorkoutHandler.GetWorkoutsByUserID)
+		})
+
+		r.Route("/analytics", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("analytics"))
+
+			r.Get("/exercises/{id}/progression", handlers.AnalyticsHandler.GetExerciseProgression)
+			r.Get("/muscle-volume", handlers.AnalyticsHandler.GetMuscleVolume)
+			r.Get("/training-time", handlers.AnalyticsHandler.GetTrainingTime)
+		})
+
+		r.Route("/foods", func(r chi.Router) {
+			r.Use(appmiddlewares.AppAuthMiddlreware.APIAuthMiddleware("foods"))
+
+			r.Get("/search", handlers.FoodHandler.SearchFoods)
+
+			r.Get("/custom/{id}", handlers.CustomFoodHandler.GetCustomFood)
+			r.Put("/custom/{id}", handlers.CustomFoodHandler.UpdateCustomFood)
+			r.Delete("/custom/{id}", handlers.CustomFoodHandler.DeleteCustomFood)
+			r.Post("/custom", handlers.CustomFoodHandler.CreateCustomFood)
+			r.Get("/custom", handlers.CustomFoodHandler.GetCustomFoods)
+
+			r.Get("/recipes/{id}", handlers.RecipeHandler.GetRecipe)
+			r.Put("/recipes/{id}", handlers.RecipeHandler.UpdateRecipe)
+			r.Delete("/recipes/{id}", handlers.RecipeHandler.DeleteRecipe)
+			r.Post("/recipes", handlers.RecipeHandler.CreateRecipe)
+			r.Get("/recipes", handlers.RecipeHandler.GetRecipes)
+
+			r.Post("/meals/copy", handlers.FoodHandler.CopyMeal)
+
+			r.Get("/{date}", handlers.FoodHandler.GetFood)
+			r.Put("/{id}", handlers.FoodHandler.UpdateFood)
+			r.Delete("/{id}", handlers.FoodHandler.DeleteFood)
+			r.Post("/", handlers.FoodHandler.AddFood)
+		})
 	})
 
 	return r
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// AccountPurgeGracePeriod is the time a deleted account can still be
+	// restored by an administrator before its data is purged.
+	AccountPurgeGracePeriod = 30 * 24 * time.Hour
+
+	accountPurgeInterval  = time.Hour
+	accountPurgeBatchSize = 100
+	maxUsernameLength     = 100
+	maxEmailLength        = 255
+)
+
+type AccountService struct {
+	accountRepo *repository.AccountRepository
+	userRepo    *repository.UserRepository
+	authService *AuthService
+}
+
+func NewAccountService(
+	accountRepo *repository.AccountRepository,
+	userRepo *repository.UserRepository,
+	authService *AuthService,
+) *AccountService {
+	return &AccountService{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		authService: authService,
+	}
+}
+
+// UpdateProfile changes the username and the email of current user. A new
+// email is unverified until the link sent to it is opened.
+func (s *AccountService) UpdateProfile(ctx context.Context, req *models.UpdateProfileRequest) (*models.UserResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Username == nil && req.Email == nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Nothing to update",
+		}
+	}
+
+	var username, email *string
+	if req.Username != nil {
+		value := strings.TrimSpace(*req.Username)
+		if value == "" || len(value) > maxUsernameLength {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Username is required and must be at most 100 characters",
+			}
+		}
+		username = &value
+	}
+	if req.Email != nil {
+		value := strings.TrimSpace(*req.Email)
+		if !strings.Contains(value, "@") || len(value) > maxEmailLength {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid email",
+			}
+		}
+		email = &value
+
+		// The email receives password reset links, so taking over a session
+		// must not be enough to change it.
+		if value != user.Email {
+			if req.CurrentPassword == "" {
+				return nil, &apperrors.AppError{
+					Code:    http.StatusBadRequest,
+					Message: "Current password is required to change the email",
+				}
+			}
+
+			if err := s.checkPassword(req.CurrentPassword, user); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	updated, err := s.accountRepo.UpdateProfile(ctx, user.ID, username, email)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation {
+			if strings.Contains(pgErr.Constraint, "username") {
+				return nil, &apperrors.AppError{
+					Code:    http.StatusConflict,
+					Message: "Username already exists",
+				}
+			} else if strings.Contains(pgErr.Constraint, "email") {
+				return nil, &apperrors.AppError{
+					Code:    http.StatusConflict,
+					Message: "Email already exists",
+				}
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to update profile")
+	}
+
+	if updated.Email != user.Email {
+		// The email is changed already, the user can ask for another link.
+		if err := s.authService.sendEmailVerification(ctx, updated); err != nil {
+			log.Println("Failed to send email verification:", err)
+		}
+	}
+
+	return &models.UserResponse{
+		ID:        updated.ID,
+		Username:  updated.Username,
+		Email:     updated.Email,
+		CreatedAt: updated.CreatedAt,
+	}, nil
+}
+
+// ChangePassword sets the new password and logs the user out on every
+// device, the current session included.
+func (s *AccountService) ChangePassword(ctx context.Context, req *models.ChangePasswordRequest) error {
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Current and new passwords are required",
+		}
+	}
+
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPassword(req.CurrentPassword, user); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		log.Println("Failed to hash password:", err)
+		return &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to hash password",
+		}
+	}
+
+	updated, err := s.accountRepo.UpdatePassword(ctx, user.ID, hashedPassword)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to change password")
+	}
+	if updated == 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "User not found",
+		}
+	}
+	s.authService.DropTokenVersion(ctx, user.ID)
+
+	return nil
+}
+
+// DeleteAccount deactivates current user and logs them out everywhere. The
+// workouts, foods and FatSecret credentials are purged by RunPurgeWorker
+// after AccountPurgeGracePeriod.
+func (s *AccountService) DeleteAccount(ctx context.Context, req *models.DeleteAccountRequest) (*models.DeleteAccountResponse, error) {
+	if req.Password == "" {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Password is required",
+		}
+	}
+
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPassword(req.Password, user); err != nil {
+		return nil, err
+	}
+
+	purgeAt := time.Now().Add(AccountPurgeGracePeriod)
+	updated, err := s.accountRepo.ScheduleDeletion(ctx, user.ID, purgeAt)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to delete account")
+	}
+	if updated == 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "User not found",
+		}
+	}
+	s.authService.DropTokenVersion(ctx, user.ID)
+
+	return &models.DeleteAccountResponse{PurgeAt: purgeAt}, nil
+}
+
+// PurgeDeletedAccounts purges the accounts whose grace period is over and
+// returns how many of them were purged.
+func (s *AccountService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		userIDs, err := s.accountRepo.GetAccountsToPurge(ctx, accountPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		batchPurged := 0
+		for _, userID := range userIDs {
+			ok, err := s.accountRepo.PurgeAccount(ctx, userID)
+			if err != nil {
+				return purged, err
+			}
+			if ok {
+				batchPurged++
+			}
+		}
+		purged += batchPurged
+
+		// Accounts locked by another instance are left to it.
+		if len(userIDs) < accountPurgeBatchSize || batchPurged == 0 {
+			return purged, nil
+		}
+	}
+}
+
+// RunPurgeWorker purges deleted accounts every accountPurgeInterval until
+// ctx is done.
+func (s *AccountService) RunPurgeWorker(ctx context.Context) {
+	ticker := time.NewTicker(accountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.PurgeDeletedAccounts(ctx)
+		if err != nil {
+			log.Println("Failed to purge deleted accounts:", err)
+		} else if purged > 0 {
+			log.Println("Purged deleted accounts:", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AccountService) currentUser(ctx context.Context) (*models.User, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get user")
+	}
+
+	return user, nil
+}
+
+func (s *AccountService) checkPassword(password string, user *models.User) error {
+	if err := utils.CheckPassword(password, user.PasswordHash); err != nil {
+		return &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "Invalid password",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateProfileEmailRequiresPassword(t *testing.T) {
+	passwordHash, err := utils.HashPassword("secret123")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		wantCode int
+	}{
+		{"missing password", "", http.StatusBadRequest},
+		{"wrong password", "wrong", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			service := NewAccountService(repository.NewAccountRepository(sqlxDB), repository.NewUserRepository(sqlxDB), nil)
+
+			now := time.Now()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, username, password_hash, email, created_at, updated_at`)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash", "email", "created_at", "updated_at"}).
+					AddRow(2, "alice", passwordHash, "alice@example.com", now, now))
+
+			email := "mallory@example.com"
+			ctx := context.WithValue(context.Background(), "user_id", 2)
+			_, err = service.UpdateProfile(ctx, &models.UpdateProfileRequest{Email: &email, CurrentPassword: tt.password})
+
+			var appErr *apperrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+)
+
+type AdminService struct {
+	userRepo    *repository.UserRepository
+	sessionRepo *repository.SessionRepository
+	authService *AuthService
+}
+
+func NewAdminService(
+	userRepo *repository.UserRepository,
+	sessionRepo *repository.SessionRepository,
+	authService *AuthService,
+) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		authService: authService,
+	}
+}
+
+func (s *AdminService) SearchUsers(ctx context.Context, filter *models.UserFilter) (*models.AdminUserListResponse, error) {
+	users, total, err := s.userRepo.SearchUsers(ctx, filter)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get users")
+	}
+
+	return &models.AdminUserListResponse{
+		Users: *users,
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}, nil
+}
+
+func (s *AdminService) GetUser(ctx context.Context, userID int) (*models.AdminUserResponse, error) {
+	user, err := s.userRepo.GetAdminUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get user")
+	}
+
+	return user, nil
+}
+
+// DeactivateUser blocks the login and logs the user out on every device. API
+// tokens of inactive users are rejected as well.
+func (s *AdminService) DeactivateUser(ctx context.Context, userID int) (*models.AdminUserResponse, error) {
+	adminID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if adminID == userID {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "You can't deactivate yourself",
+		}
+	}
+
+	if err := s.setUserActive(ctx, userID, false); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to revoke sessions")
+	}
+	s.authService.DropTokenVersion(ctx, userID)
+
+	return s.GetUser(ctx, userID)
+}
+
+// ReactivateUser also restores an account deleted by its owner unless its
+// data is purged already.
+func (s *AdminService) ReactivateUser(ctx context.Context, userID int) (*models.AdminUserResponse, error) {
+	if err := s.setUserActive(ctx, userID, true); err != nil {
+		return nil, err
+	}
+
+	return s.GetUser(ctx, userID)
+}
+
+// ForcePasswordReset logs the user out everywhere and blocks the login until
+// the password is reset with the link sent by email.
+func (s *AdminService) ForcePasswordReset(ctx context.Context, userID int) (*models.AdminUserResponse, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.RequirePasswordReset(ctx, userID); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to require password reset")
+	}
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to revoke sessions")
+	}
+	s.authService.DropTokenVersion(ctx, userID)
+
+	if err := s.authService.RequestPasswordReset(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	return s.GetUser(ctx, userID)
+}
+
+func (s *AdminService) setUserActive(ctx context.Context, userID int, active bool) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsActive == active {
+		message := "User is already active"
+		if !active {
+			message = "User is already deactivated"
+		}
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: message,
+		}
+	}
+
+	updated, err := s.userRepo.SetUserActive(ctx, userID, active)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to update user")
+	}
+
+	// Purged accounts have no data left to restore.
+	if updated == 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Account is deleted",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"time"
+)
+
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+	exerciseRepo  *repository.ExerciseRepository
+	programRepo   *repository.ProgramRepository
+}
+
+func NewAnalyticsService(
+	analyticsRepo *repository.AnalyticsRepository,
+	exerciseRepo *repository.ExerciseRepository,
+	programRepo *repository.ProgramRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		exerciseRepo:  exerciseRepo,
+		programRepo:   programRepo,
+	}
+}
+
+func (s *AnalyticsService) GetExerciseProgression(ctx context.Context, exerciseID int, filter *models.ProgressionFilter) ([]models.ProgressionPoint, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if exercise, err := s.exerciseRepo.GetExercise(ctx, exerciseID); exercise == nil || err != nil {
+		log.Println("Exercise not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Exercise not found",
+		}
+	}
+
+	sets, err := s.analyticsRepo.GetProgressionSets(ctx, userID, exerciseID, filter)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get progression")
+	}
+
+	return buildProgression(*sets, filter), nil
+}
+
+func (s *AnalyticsService) GetMuscleVolume(ctx context.Context, filter *models.DateRangeFilter) ([]models.MuscleVolumeWeek, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rows, err := s.analyticsRepo.GetMuscleVolume(ctx, userID, filter)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get muscle volume")
+	}
+
+	return buildMuscleVolume(*rows), nil
+}
+
+func (s *AnalyticsService) GetTrainingTime(ctx context.Context, filter *models.DateRangeFilter) (int, []models.TrainingTimeWeek, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return 0, nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	weeks, err := s.getTrainingTime(ctx, userID, filter)
+	return userID, weeks, err
+}
+
+// GetClientTrainingTime lets a trainer see the weekly training time of one of
+// their clients.
+func (s *AnalyticsService) GetClientTrainingTime(ctx context.Context, clientID int, filter *models.DateRangeFilter) ([]models.TrainingTimeWeek, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	isClient, err := s.programRepo.IsTrainerClient(ctx, trainerID, clientID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to check client")
+	}
+
+	if !isClient {
+		log.Println("User is not a client of trainer")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "User is not your client",
+		}
+	}
+
+	return s.getTrainingTime(ctx, clientID, filter)
+}
+
+func (s *AnalyticsService) getTrainingTime(ctx context.Context, userID int, filter *models.DateRangeFilter) ([]models.TrainingTimeWeek, error) {
+	weeks, err := s.analyticsRepo.GetTrainingTime(ctx, userID, filter)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get training time")
+	}
+
+	for i := range *weeks {
+		week := &(*weeks)[i]
+		if week.Workouts > 0 {
+			week.AverageSeconds = week.TotalSeconds / week.Workouts
+		}
+	}
+
+	return *weeks, nil
+}
+
+func buildProgression(sets []models.ProgressionSet, filter *models.ProgressionFilter) []models.ProgressionPoint {
+	var points []models.ProgressionPoint
+	lastWorkoutID := 0
+
+	for _, set := range sets {
+		date := bucketDate(set.WorkoutDate, filter.Bucket)
+		if len(points) == 0 || !points[len(points)-1].Date.Equal(date) {
+			points = append(points, models.ProgressionPoint{Date: date})
+		}
+
+		point := &points[len(points)-1]
+		if set.WorkoutID != lastWorkoutID {
+			point.Workouts++
+			lastWorkoutID = set.WorkoutID
+		}
+
+		if oneRepMax := utils.EstimateOneRepMaxWith(filter.Formula, set.Weight, set.Reps); oneRepMax > point.EstimatedOneRepMax {
+			point.EstimatedOneRepMax = oneRepMax
+		}
+
+		if set.Weight > point.TopSetWeight || (set.Weight == point.TopSetWeight && set.Reps > point.TopSetReps) {
+			point.TopSetWeight = set.Weight
+			point.TopSetReps = set.Reps
+		}
+
+		point.TotalVolume += set.Weight * float64(set.Reps*set.Sets)
+	}
+
+	for i := range points {
+		points[i].TotalVolume = math.Round(points[i].TotalVolume*100) / 100
+	}
+
+	return points
+}
+
+// buildMuscleVolume merges primary and secondary rows of the same week and
+// muscle group, rows are expected to be ordered by week.
+func buildMuscleVolume(rows []models.MuscleVolumeRow) []models.MuscleVolumeWeek {
+	var weeks []models.MuscleVolumeWeek
+	var index map[int]int
+
+	for _, row := range rows {
+		if len(weeks) == 0 || !weeks[len(weeks)-1].WeekStart.Equal(row.WeekStart) {
+			weeks = append(weeks, models.MuscleVolumeWeek{WeekStart: row.WeekStart})
+			index = make(map[int]int)
+		}
+
+		week := &weeks[len(weeks)-1]
+		i, ok := index[row.MuscleGroupID]
+		if !ok {
+			i = len(week.MuscleGroups)
+			index[row.MuscleGroupID] = i
+			week.MuscleGroups = append(week.MuscleGroups, models.MuscleGroupVolume{
+				MuscleGroupID:   row.MuscleGroupID,
+				MuscleGroupName: row.MuscleGroupName,
+			})
+		}
+
+		volume := &week.MuscleGroups[i]
+		if row.IsPrimary {
+			volume.Sets += row.Sets
+			volume.Tonnage += row.Tonnage
+		} else {
+			volume.SecondarySets += row.Sets
+			volume.SecondaryTonnage += row.Tonnage
+		}
+	}
+
+	return weeks
+}
+
+// bucketDate returns the first day of the bucket, weeks start on Monday.
+func bucketDate(date time.Time, bucket string) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch bucket {
+	case models.BucketWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case models.BucketMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"backend/internal/models"
+	"backend/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketDate(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		bucket string
+		want   time.Time
+	}{
+		{"day drops the time", time.Date(2024, 1, 10, 18, 30, 0, 0, time.UTC), models.BucketDay, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{"day keeps the local date", time.Date(2024, 3, 1, 1, 0, 0, 0, moscow), models.BucketDay, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"week of a monday", time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), models.BucketWeek, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"week of a sunday starts on monday", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), models.BucketWeek, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"week across the new year", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), models.BucketWeek, time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+		{"month end of a leap february", time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC), models.BucketMonth, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"month end of december", time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), models.BucketMonth, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"month start", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), models.BucketMonth, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bucketDate(tt.date, tt.bucket))
+		})
+	}
+}
+
+func TestBuildProgression(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	wednesday := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	nextMonday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	sets := []models.ProgressionSet{
+		{WorkoutID: 1, WorkoutDate: monday, Weight: 100, Reps: 5, Sets: 3},
+		{WorkoutID: 1, WorkoutDate: monday, Weight: 90, Reps: 8, Sets: 1},
+		{WorkoutID: 2, WorkoutDate: wednesday, Weight: 100, Reps: 6, Sets: 1},
+		{WorkoutID: 3, WorkoutDate: nextMonday, Weight: 105, Reps: 3, Sets: 2},
+	}
+
+	tests := []struct {
+		name   string
+		filter models.ProgressionFilter
+		want   []models.ProgressionPoint
+	}{
+		{
+			name:   "daily points",
+			filter: models.ProgressionFilter{Formula: utils.FormulaEpley, Bucket: models.BucketDay},
+			want: []models.ProgressionPoint{
+				{Date: monday, Workouts: 1, EstimatedOneRepMax: 116.67, TopSetWeight: 100, TopSetReps: 5, TotalVolume: 2220},
+				{Date: wednesday, Workouts: 1, EstimatedOneRepMax: 120, TopSetWeight: 100, TopSetReps: 6, TotalVolume: 600},
+				{Date: nextMonday, Workouts: 1, EstimatedOneRepMax: 115.5, TopSetWeight: 105, TopSetReps: 3, TotalVolume: 630},
+			},
+		},
+		{
+			name:   "weekly points merge workouts",
+			filter: models.ProgressionFilter{Formula: utils.FormulaEpley, Bucket: models.BucketWeek},
+			want: []models.ProgressionPoint{
+				{Date: monday, Workouts: 2, EstimatedOneRepMax: 120, TopSetWeight: 100, TopSetReps: 6, TotalVolume: 2820},
+				{Date: nextMonday, Workouts: 1, EstimatedOneRepMax: 115.5, TopSetWeight: 105, TopSetReps: 3, TotalVolume: 630},
+			},
+		},
+		{
+			name:   "monthly point with brzycki",
+			filter: models.ProgressionFilter{Formula: utils.FormulaBrzycki, Bucket: models.BucketMonth},
+			want: []models.ProgressionPoint{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Workouts: 3, EstimatedOneRepMax: 116.13, TopSetWeight: 105, TopSetReps: 3, TotalVolume: 3450},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildProgression(sets, &tt.filter))
+		})
+	}
+}
+
+func TestBuildProgressionNoSets(t *testing.T) {
+	assert.Empty(t, buildProgression(nil, &models.ProgressionFilter{Bucket: models.BucketWeek}))
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/auth"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// APITokenPrefix tells API tokens apart from other secrets, e.g. for
+	// secret scanners.
+	APITokenPrefix = "wt_"
+
+	apiTokenPrefixLength    = 8
+	defaultAPITokenLifetime = 30
+	maxAPITokenLifetime     = 365
+	maxAPITokenNameLength   = 100
+)
+
+type APITokenService struct {
+	apiTokenRepo *repository.APITokenRepository
+}
+
+func NewAPITokenService(apiTokenRepo *repository.APITokenRepository) *APITokenService {
+	return &APITokenService{apiTokenRepo: apiTokenRepo}
+}
+
+func (s *APITokenService) CreateToken(ctx context.Context, req *models.APITokenRequest) (*models.CreatedAPITokenResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxAPITokenNameLength {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Name is required and must be at most 100 characters",
+		}
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "At least one scope is required",
+		}
+	}
+
+	scopes := []string{}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(models.APITokenScopes, scope) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Unknown scope: " + scope,
+			}
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = defaultAPITokenLifetime
+	}
+	if days < 1 || days > maxAPITokenLifetime {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Expiration must be between 1 and 365 days",
+		}
+	}
+
+	secret, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Println("Failed to generate api token:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to generate token",
+		}
+	}
+	token := APITokenPrefix + secret
+
+	apiToken := &models.APIToken{
+		UserID:      userID,
+		Name:        name,
+		TokenPrefix: token[:apiTokenPrefixLength],
+		TokenHash:   auth.HashToken(token),
+		Scopes:      scopes,
+		ExpiresAt:   time.Now().AddDate(0, 0, days),
+	}
+
+	if err := s.apiTokenRepo.CreateToken(ctx, apiToken); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to create API token")
+	}
+
+	return &models.CreatedAPITokenResponse{
+		APITokenResponse: newAPITokenResponse(apiToken),
+		Token:            token,
+	}, nil
+}
+
+func (s *APITokenService) GetTokens(ctx context.Context) ([]models.APITokenResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	tokens, err := s.apiTokenRepo.GetActiveTokens(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get API tokens")
+	}
+
+	response := []models.APITokenResponse{}
+	for _, token := range *tokens {
+		response = append(response, newAPITokenResponse(&token))
+	}
+
+	return response, nil
+}
+
+func (s *APITokenService) RevokeToken(ctx context.Context, tokenID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	revoked, err := s.apiTokenRepo.RevokeToken(ctx, userID, tokenID)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to revoke API token")
+	}
+
+	if revoked == 0 {
+		log.Println("API token not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "API token not found",
+		}
+	}
+
+	return nil
+}
+
+// Authenticate returns the active token for the Authorization header value.
+func (s *APITokenService) Authenticate(ctx context.Context, token string) (*models.APIToken, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid API token",
+		}
+	}
+
+	apiToken, err := s.apiTokenRepo.UseToken(ctx, auth.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid API token",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to verify token")
+	}
+
+	return apiToken, nil
+}
+
+func newAPITokenResponse(token *models.APIToken) models.APITokenResponse {
+	return models.APITokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
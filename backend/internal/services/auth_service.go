@@ -3,28 +3,68 @@ package services
 import (
 	"backend/internal/apperrors"
 	"backend/internal/auth"
+	"backend/internal/mail"
 	"backend/internal/models"
 	"backend/internal/repository"
+	"backend/internal/security"
 	"backend/internal/utils"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	emailVerificationTTL = 24 * time.Hour
+	passwordResetTTL     = time.Hour
+
+	tokenVersionTTL    = 5 * time.Minute
+	revokedTokenKeyFmt = "revoked_token:%s"
+	tokenVersionKeyFmt = "token_version:%d"
+
+	loginChallengeTTL         = 5 * time.Minute
+	loginChallengeKeyFmt      = "login_challenge:%s"
+	maxLoginChallengeAttempts = 5
 )
 
 type AuthService struct {
-	userRepo   *repository.UserRepository
-	jwtManager *auth.JWTManager
+	userRepo         *repository.UserRepository
+	sessionRepo      *repository.SessionRepository
+	userTokenRepo    *repository.UserTokenRepository
+	twoFactorService *TwoFactorService
+	jwtManager       *auth.JWTManager
+	redis            *redis.Client
+	mailer           *mail.Mailer
+	loginThrottler   *security.LoginThrottler
 }
 
-func NewAuthService(userRepo *repository.UserRepository, jwtManager *auth.JWTManager) *AuthService {
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	sessionRepo *repository.SessionRepository,
+	userTokenRepo *repository.UserTokenRepository,
+	twoFactorService *TwoFactorService,
+	jwtManager *auth.JWTManager,
+	redis *redis.Client,
+	mailer *mail.Mailer,
+	loginThrottler *security.LoginThrottler,
+) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		userTokenRepo:    userTokenRepo,
+		twoFactorService: twoFactorService,
+		jwtManager:       jwtManager,
+		redis:            redis,
+		mailer:           mailer,
+		loginThrottler:   loginThrottler,
 	}
 }
 
@@ -86,32 +126,42 @@ func (s *AuthService) Register(ctx context.Context, req *models.UserRegisterRequ
 		}
 	}
 
+	// The account exists already, the user can ask for another email.
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		log.Println("Failed to send email verification:", err)
+	}
+
 	return user, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req *models.UserAuthRequest) (string, *models.User, error) {
+// Login checks the password. Users with 2FA enabled get a challenge instead
+// of tokens, the login is completed by LoginTwoFactor.
+func (s *AuthService) Login(ctx context.Context, req *models.UserAuthRequest, client *models.SessionClient) (*models.AuthTokens, *models.User, *models.LoginChallenge, error) {
+	if err := s.checkLoginThrottle(ctx, req.Email, client.IPAddress); err != nil {
+		return nil, nil, nil, err
+	}
 
 	user, err := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
-			return "", nil, &apperrors.AppError{
+			return nil, nil, nil, s.loginFailed(ctx, req.Email, client.IPAddress, security.ReasonUnknownEmail, &apperrors.AppError{
 				Code:    http.StatusUnauthorized,
 				Message: "Invalid email or password",
-			}
+			})
 		case errors.Is(err, context.DeadlineExceeded):
-			return "", nil, &apperrors.AppError{
+			return nil, nil, nil, &apperrors.AppError{
 				Code:    http.StatusGatewayTimeout,
 				Message: "Request timeout",
 			}
 		case errors.Is(err, context.Canceled):
-			return "", nil, &apperrors.AppError{
+			return nil, nil, nil, &apperrors.AppError{
 				Code:    http.StatusBadRequest,
 				Message: "Request cancelled",
 			}
 		default:
 			log.Println("Unhandled error:", err)
-			return "", nil, &apperrors.AppError{
+			return nil, nil, nil, &apperrors.AppError{
 				Code:    http.StatusInternalServerError,
 				Message: "Internal server error",
 			}
@@ -119,19 +169,666 @@ func (s *AuthService) Login(ctx context.Context, req *models.UserAuthRequest) (s
 	}
 
 	if err := utils.CheckPassword(req.Password, user.PasswordHash); err != nil {
-		return "", nil, &apperrors.AppError{
+		return nil, nil, nil, s.loginFailed(ctx, req.Email, client.IPAddress, security.ReasonInvalidPassword, &apperrors.AppError{
 			Code:    http.StatusUnauthorized,
 			Message: "Invalid email or password",
+		})
+	}
+
+	if !user.IsActive {
+		return nil, nil, nil, &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "Account is deactivated",
+		}
+	}
+
+	if user.PasswordResetRequired {
+		return nil, nil, nil, &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "Password reset required",
+		}
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return nil, nil, nil, &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "Email is not verified",
+		}
+	}
+
+	enabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, nil, nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	if enabled {
+		challenge, err := s.createLoginChallenge(ctx, user)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return nil, user, challenge, nil
+	}
+
+	// With 2FA the counter is reset only after the code, otherwise the
+	// password would unlock new attempts at guessing the code.
+	if err := s.loginThrottler.Reset(ctx, req.Email); err != nil {
+		log.Println("Failed to reset login throttle:", err)
+	}
+
+	tokens, err := s.startSession(ctx, user, client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return tokens, user, nil, nil
+}
+
+// LoginTwoFactor completes the login with a TOTP or a recovery code. The
+// challenge is dropped after too many wrong codes.
+func (s *AuthService) LoginTwoFactor(ctx context.Context, req *models.TwoFactorLoginRequest, client *models.SessionClient) (*models.AuthTokens, *models.User, error) {
+	if req.Challenge == "" || req.Code == "" {
+		return nil, nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Challenge and code are required",
+		}
+	}
+
+	key := loginChallengeKey(req.Challenge)
+
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		log.Println("Failed to count login challenge attempts:", err)
+		return nil, nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	challenge, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		log.Println("Failed to get login challenge:", err)
+		return nil, nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	userID, err := strconv.Atoi(challenge["user_id"])
+	if err != nil {
+		// HIncrBy created the key if the challenge had expired.
+		s.redis.Del(ctx, key)
+		return nil, nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid or expired challenge",
+		}
+	}
+
+	email := challenge["email"]
+	if err := s.checkLoginThrottle(ctx, email, client.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
+	if attempts > maxLoginChallengeAttempts {
+		s.redis.Del(ctx, key)
+		return nil, nil, &apperrors.AppError{
+			Code:    http.StatusTooManyRequests,
+			Message: "Too many attempts",
+		}
+	}
+
+	valid, err := s.twoFactorService.VerifyLoginCode(ctx, userID, req.Code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid or expired challenge",
+			}
+		}
+		return nil, nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	if !valid {
+		return nil, nil, s.loginFailed(ctx, email, client.IPAddress, security.ReasonInvalidCode, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid code",
+		})
+	}
+
+	if err := s.redis.Del(ctx, key).Err(); err != nil {
+		log.Println("Failed to delete login challenge:", err)
+	}
+
+	if err := s.loginThrottler.Reset(ctx, email); err != nil {
+		log.Println("Failed to reset login throttle:", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid or expired challenge",
+			}
+		}
+		return nil, nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	tokens, err := s.startSession(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+// Refresh rotates the refresh token. Presenting a token that was already
+// rotated means it leaked, so the whole session is revoked.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	token, err := s.sessionRepo.GetRefreshToken(ctx, auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid refresh token",
+			}
 		}
+		return nil, apperrors.Wrap(err, "Failed to refresh token")
 	}
 
-	token, err := s.jwtManager.Generate(user)
+	if token.RevokedAt != nil || !token.ExpiresAt.After(time.Now()) {
+		log.Println("Session expired or revoked")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Session expired",
+		}
+	}
+
+	if token.UsedAt != nil {
+		return nil, s.revokeReusedSession(ctx, token)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid refresh token",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to refresh token")
+	}
+
+	if user.TokenVersion, err = s.tokenVersion(ctx, user.ID); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to refresh token")
+	}
+
+	newRefreshToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Println("Failed to generate refresh token:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to generate token",
+		}
+	}
+
+	rotated, err := s.sessionRepo.RotateRefreshToken(ctx, token, tokenHash, time.Now().Add(auth.RefreshTokenTTL))
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to refresh token")
+	}
+
+	if rotated == 0 {
+		return nil, s.revokeReusedSession(ctx, token)
+	}
+
+	accessToken, err := s.jwtManager.Generate(user, token.SessionID)
 	if err != nil {
-		return "", nil, &apperrors.AppError{
+		return nil, &apperrors.AppError{
 			Code:    http.StatusInternalServerError,
 			Message: "Failed to generate token",
 		}
 	}
 
-	return token, user, nil
+	return &models.AuthTokens{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
+}
+
+// VerifyClaims rejects tokens that were revoked by logout or issued before
+// the last "log out everywhere" of the user.
+func (s *AuthService) VerifyClaims(ctx context.Context, claims *models.Claims) error {
+	revoked, err := s.redis.Exists(ctx, revokedTokenKey(claims.ID)).Result()
+	if err != nil {
+		log.Println("Failed to check revoked token:", err)
+		return apperrors.Wrap(err, "Failed to verify token")
+	}
+
+	if revoked > 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Token revoked",
+		}
+	}
+
+	version, err := s.tokenVersion(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &apperrors.AppError{
+				Code:    http.StatusUnauthorized,
+				Message: "Token revoked",
+			}
+		}
+		return apperrors.Wrap(err, "Failed to verify token")
+	}
+
+	if claims.TokenVersion < version {
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Token revoked",
+		}
+	}
+
+	return nil
+}
+
+// Logout revokes the access token and the session it was issued for.
+func (s *AuthService) Logout(ctx context.Context) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	tokenID, _ := ctx.Value("token_id").(string)
+	expiresAt, _ := ctx.Value("token_expires_at").(time.Time)
+	if ttl := time.Until(expiresAt); tokenID != "" && ttl > 0 {
+		if err := s.redis.Set(ctx, revokedTokenKey(tokenID), 1, ttl).Err(); err != nil {
+			log.Println("Failed to revoke token:", err)
+			return apperrors.Wrap(err, "Failed to logout")
+		}
+	}
+
+	sessionID, _ := ctx.Value("session_id").(int)
+	if sessionID == 0 {
+		return nil
+	}
+
+	if _, err := s.sessionRepo.RevokeSession(ctx, userID, sessionID); err != nil {
+		return apperrors.Wrap(err, "Failed to logout")
+	}
+
+	return nil
+}
+
+// LogoutAll bumps the token version of the user, so every access token issued
+// so far is rejected, and revokes all sessions.
+func (s *AuthService) LogoutAll(ctx context.Context) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	_, err := s.userRepo.IncrementTokenVersion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return apperrors.Wrap(err, "Failed to logout")
+	}
+
+	s.DropTokenVersion(ctx, userID)
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, userID); err != nil {
+		return apperrors.Wrap(err, "Failed to logout")
+	}
+
+	return nil
+}
+
+func (s *AuthService) GetSessions(ctx context.Context) ([]models.SessionResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	sessions, err := s.sessionRepo.GetActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get sessions")
+	}
+
+	currentID, _ := ctx.Value("session_id").(int)
+
+	response := []models.SessionResponse{}
+	for _, session := range *sessions {
+		response = append(response, models.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentID,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *AuthService) RevokeSession(ctx context.Context, sessionID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	revoked, err := s.sessionRepo.RevokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to revoke session")
+	}
+
+	if revoked == 0 {
+		log.Println("Session not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Session not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *AuthService) revokeReusedSession(ctx context.Context, token *models.RefreshToken) error {
+	log.Println("Refresh token reuse detected, revoking session:", token.SessionID)
+
+	if _, err := s.sessionRepo.RevokeSession(ctx, token.UserID, token.SessionID); err != nil {
+		return apperrors.Wrap(err, "Failed to refresh token")
+	}
+
+	return &apperrors.AppError{
+		Code:    http.StatusUnauthorized,
+		Message: "Refresh token reuse detected",
+	}
+}
+
+// RequestEmailVerification sends a new verification email. Unknown and
+// already verified emails are ignored, so the response does not reveal
+// which emails are registered.
+func (s *AuthService) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apperrors.Wrap(err, "Failed to send email")
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		return apperrors.Wrap(err, "Failed to send email")
+	}
+
+	return nil
+}
+
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	if _, err := s.userTokenRepo.VerifyEmail(ctx, auth.HashToken(token)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid or expired token",
+			}
+		}
+		return apperrors.Wrap(err, "Failed to verify email")
+	}
+
+	return nil
+}
+
+// RequestPasswordReset sends a password reset email, unknown emails are
+// ignored like in RequestEmailVerification.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apperrors.Wrap(err, "Failed to send email")
+	}
+
+	token, err := s.createUserToken(ctx, user.ID, models.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to send email")
+	}
+
+	if err := s.mailer.SendPasswordReset(ctx, user.Email, token); err != nil {
+		return apperrors.Wrap(err, "Failed to send email")
+	}
+
+	return nil
+}
+
+// ResetPassword sets the new password and logs the user out on every device.
+func (s *AuthService) ResetPassword(ctx context.Context, req *models.PasswordResetRequest) error {
+	if req.Token == "" || req.Password == "" {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Token and password are required",
+		}
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		log.Println("Failed to hash password:", err)
+		return &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to hash password",
+		}
+	}
+
+	userID, err := s.userTokenRepo.ResetPassword(ctx, auth.HashToken(req.Token), hashedPassword)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid or expired token",
+			}
+		}
+		return apperrors.Wrap(err, "Failed to reset password")
+	}
+
+	s.DropTokenVersion(ctx, userID)
+
+	return nil
+}
+
+func (s *AuthService) sendEmailVerification(ctx context.Context, user *models.User) error {
+	token, err := s.createUserToken(ctx, user.ID, models.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.SendEmailVerification(ctx, user.Email, token)
+}
+
+func (s *AuthService) createUserToken(ctx context.Context, userID int, purpose string, ttl time.Duration) (string, error) {
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Println("Failed to generate user token:", err)
+		return "", err
+	}
+
+	userToken := &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := s.userTokenRepo.CreateToken(ctx, userToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *AuthService) createLoginChallenge(ctx context.Context, user *models.User) (*models.LoginChallenge, error) {
+	challenge, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Println("Failed to generate login challenge:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to generate token",
+		}
+	}
+
+	key := loginChallengeKey(challenge)
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user_id", user.ID, "email", user.Email, "attempts", 0)
+		pipe.Expire(ctx, key, loginChallengeTTL)
+		return nil
+	})
+	if err != nil {
+		log.Println("Failed to store login challenge:", err)
+		return nil, apperrors.Wrap(err, "Failed to login user")
+	}
+
+	return &models.LoginChallenge{
+		TwoFactorRequired: true,
+		Challenge:         challenge,
+		ExpiresAt:         time.Now().Add(loginChallengeTTL),
+	}, nil
+}
+
+// checkLoginThrottle rejects the attempt while the account or the IP is
+// locked after too many failures.
+func (s *AuthService) checkLoginThrottle(ctx context.Context, email, ip string) error {
+	lockout, err := s.loginThrottler.Check(ctx, email, ip)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to login user")
+	}
+
+	if lockout > 0 {
+		return &apperrors.AppError{
+			Code:       http.StatusTooManyRequests,
+			Message:    "Too many login attempts",
+			RetryAfter: lockout,
+		}
+	}
+
+	return nil
+}
+
+// loginFailed counts the failed attempt and returns appErr, or a lockout
+// error when this attempt locked the account or the IP.
+func (s *AuthService) loginFailed(ctx context.Context, email, ip, reason string, appErr *apperrors.AppError) error {
+	lockout, err := s.loginThrottler.Fail(ctx, email, ip, reason)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to login user")
+	}
+
+	if lockout > 0 {
+		return &apperrors.AppError{
+			Code:       http.StatusTooManyRequests,
+			Message:    "Too many login attempts",
+			RetryAfter: lockout,
+		}
+	}
+
+	return appErr
+}
+
+// startSession creates a session for the device and issues its first tokens.
+func (s *AuthService) startSession(ctx context.Context, user *models.User, client *models.SessionClient) (*models.AuthTokens, error) {
+	version, err := s.tokenVersion(ctx, user.ID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to create session")
+	}
+	user.TokenVersion = version
+
+	refreshToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Println("Failed to generate refresh token:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to generate token",
+		}
+	}
+
+	session := &models.UserSession{
+		UserID:    user.ID,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+		ExpiresAt: time.Now().Add(auth.RefreshTokenTTL),
+	}
+
+	if err := s.sessionRepo.CreateSession(ctx, session, tokenHash); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to create session")
+	}
+
+	accessToken, err := s.jwtManager.Generate(user, session.ID)
+	if err != nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to generate token",
+		}
+	}
+
+	return &models.AuthTokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// tokenVersion reads the version from Redis and falls back to the database,
+// the database stays the source of truth.
+func (s *AuthService) tokenVersion(ctx context.Context, userID int) (int, error) {
+	version, err := s.redis.Get(ctx, tokenVersionKey(userID)).Int()
+	if err == nil {
+		return version, nil
+	}
+
+	if !errors.Is(err, redis.Nil) {
+		log.Println("Failed to get cached token version:", err)
+	}
+
+	version, err = s.userRepo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// SetNX does not overwrite a version cached in the meantime, the short TTL
+	// bounds how long a version read before a concurrent bump can be served.
+	if err := s.redis.SetNX(ctx, tokenVersionKey(userID), version, tokenVersionTTL).Err(); err != nil {
+		log.Println("Failed to cache token version:", err)
+	}
+
+	return version, nil
+}
+
+// DropTokenVersion removes the cached token version after it was bumped in
+// the database, so the next request reads the new version.
+func (s *AuthService) DropTokenVersion(ctx context.Context, userID int) {
+	if err := s.redis.Del(ctx, tokenVersionKey(userID)).Err(); err != nil {
+		log.Println("Failed to delete cached token version:", err)
+	}
+}
+
+func loginChallengeKey(challenge string) string {
+	return fmt.Sprintf(loginChallengeKeyFmt, auth.HashToken(challenge))
+}
+
+func revokedTokenKey(tokenID string) string {
+	return fmt.Sprintf(revokedTokenKeyFmt, tokenID)
+}
+
+func tokenVersionKey(userID int) string {
+	return fmt.Sprintf(tokenVersionKeyFmt, userID)
 }
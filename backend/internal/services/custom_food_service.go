@@ -0,0 +1,222 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type CustomFoodService struct {
+	customFoodRepo *repository.CustomFoodRepository
+}
+
+func NewCustomFoodService(customFoodRepo *repository.CustomFoodRepository) *CustomFoodService {
+	return &CustomFoodService{customFoodRepo: customFoodRepo}
+}
+
+func (s *CustomFoodService) CreateCustomFood(ctx context.Context, req *models.CustomFoodRequest) (*models.CustomFood, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if err := validateCustomFoodRequest(req); err != nil {
+		return nil, err
+	}
+
+	food := newCustomFood(userID, req)
+
+	if err := s.customFoodRepo.CreateCustomFood(ctx, food); err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to create custom food")
+	}
+
+	return food, nil
+}
+
+func (s *CustomFoodService) GetCustomFoods(ctx context.Context) ([]models.CustomFood, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	foods, err := s.customFoodRepo.GetCustomFoodsByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to get custom foods")
+	}
+
+	if len(foods) == 0 {
+		log.Println("Custom foods not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Custom foods not found",
+		}
+	}
+
+	return foods, nil
+}
+
+func (s *CustomFoodService) GetCustomFood(ctx context.Context, foodID int) (*models.CustomFood, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	food, err := s.customFoodRepo.GetCustomFoodByUserID(ctx, userID, foodID)
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to get custom food")
+	}
+
+	return food, nil
+}
+
+func (s *CustomFoodService) UpdateCustomFood(ctx context.Context, foodID int, req *models.CustomFoodRequest) (*models.CustomFood, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if err := validateCustomFoodRequest(req); err != nil {
+		return nil, err
+	}
+
+	food := newCustomFood(userID, req)
+	food.ID = foodID
+
+	if err := s.customFoodRepo.UpdateCustomFood(ctx, food); err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to update custom food")
+	}
+
+	return food, nil
+}
+
+func (s *CustomFoodService) DeleteCustomFood(ctx context.Context, foodID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.customFoodRepo.DeleteCustomFood(ctx, userID, foodID)
+	if err != nil {
+		return apperrors.WrapNotFound(err, "Custom food not found", "Failed to delete custom food")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Custom food not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Custom food not found",
+		}
+	}
+
+	return nil
+}
+func validateCustomFoodRequest(req *models.CustomFoodRequest) error {
+	name := strings.TrimSpace(req.Name)
+
+	switch {
+	case name == "" || len(name) > 100:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Name is required and must be at most 100 characters",
+		}
+
+	case req.Per100g == nil && req.PerServing == nil:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Nutrition per 100g or per serving is required",
+		}
+
+	case !validNutrients(req.Per100g) || !validNutrients(req.PerServing):
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Nutrients must not be negative",
+		}
+
+	case req.ServingQty < 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Serving quantity must be greater than zero",
+		}
+
+	case req.ServingWeightGrams != nil && *req.ServingWeightGrams <= 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Serving weight must be greater than zero",
+		}
+
+	case req.PerServing != nil && strings.TrimSpace(req.ServingUnit) == "":
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Serving unit is required for nutrition per serving",
+		}
+
+	case len(req.Brand) > 100 || len(req.Barcode) > 50 || len(req.ServingUnit) > 20:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Brand, barcode or serving unit is too long",
+		}
+	}
+
+	return nil
+}
+
+func validNutrients(n *models.Nutrients) bool {
+	return n == nil || (n.Calories >= 0 && n.Protein >= 0 && n.Carbs >= 0 && n.Fat >= 0)
+}
+
+// newCustomFood fills the missing nutrition per 100g or per serving when the
+// serving weight is known, so the food can be logged in both units.
+func newCustomFood(userID int, req *models.CustomFoodRequest) *models.CustomFood {
+	food := &models.CustomFood{
+		UserID:             userID,
+		Name:               strings.TrimSpace(req.Name),
+		Brand:              strings.TrimSpace(req.Brand),
+		Barcode:            strings.TrimSpace(req.Barcode),
+		ServingQty:         req.ServingQty,
+		ServingUnit:        strings.TrimSpace(req.ServingUnit),
+		ServingWeightGrams: req.ServingWeightGrams,
+		Per100g:            req.Per100g,
+		PerServing:         req.PerServing,
+	}
+
+	if food.ServingQty == 0 {
+		food.ServingQty = 1
+	}
+
+	if weight := food.ServingWeightGrams; weight != nil {
+		if food.Per100g == nil {
+			per100g := scaleNutrients(*food.PerServing, 100 / *weight)
+			food.Per100g = &per100g
+		}
+		if food.PerServing == nil {
+			perServing := scaleNutrients(*food.Per100g, *weight/100)
+			food.PerServing = &perServing
+		}
+	}
+
+	return food
+}
@@ -0,0 +1,461 @@
+package services
+
+import (
+	"archive/zip"
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// dataExportTimeout bounds the archive generation, a pending export older
+	// than that is considered failed.
+	dataExportTimeout         = 10 * time.Minute
+	dataExportTTL             = 24 * time.Hour
+	dataExportCleanupInterval = time.Hour
+	dataExportURLFormat       = "/api/v1/users/me/export/%d/download"
+)
+
+type DataExportService struct {
+	exportRepo *repository.DataExportRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewDataExportService(exportRepo *repository.DataExportRepository, userRepo *repository.UserRepository) *DataExportService {
+	return &DataExportService{
+		exportRepo: exportRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// RequestExport starts generating the archive in the background, the result
+// is polled with GetExport. The previous export of the user is replaced.
+func (s *DataExportService) RequestExport(ctx context.Context) (*models.DataExportResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	latest, err := s.exportRepo.GetLatestExport(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.Wrap(err, "Failed to get export")
+	}
+	if err == nil && exportStatus(latest) == models.DataExportStatusPending {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Export is already in progress",
+		}
+	}
+
+	export := &models.DataExport{
+		UserID: userID,
+		Status: models.DataExportStatusPending,
+	}
+	if err := s.exportRepo.CreateExport(ctx, export); err != nil {
+		// Only one pending export per user is allowed by a unique index, a
+		// concurrent request that passed the check above ends up here.
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Export is already in progress",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to create export")
+	}
+
+	// The request context is cancelled once the response is sent.
+	go s.generate(export.ID, userID)
+
+	return newDataExportResponse(export), nil
+}
+
+// RunCleanupWorker frees the archives of expired exports every
+// dataExportCleanupInterval until ctx is done.
+func (s *DataExportService) RunCleanupWorker(ctx context.Context) {
+	ticker := time.NewTicker(dataExportCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.exportRepo.DeleteExpiredArchives(ctx)
+		if err != nil {
+			log.Println("Failed to delete expired export archives:", err)
+		} else if deleted > 0 {
+			log.Println("Deleted expired export archives:", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DataExportService) GetExport(ctx context.Context) (*models.DataExportResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	export, err := s.exportRepo.GetLatestExport(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Export not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get export")
+	}
+
+	return newDataExportResponse(export), nil
+}
+
+func (s *DataExportService) GetArchive(ctx context.Context, exportID int) ([]byte, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	archive, err := s.exportRepo.GetArchive(ctx, userID, exportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Export not found or expired",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get export")
+	}
+
+	return archive, nil
+}
+
+func (s *DataExportService) generate(exportID, userID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), dataExportTimeout)
+	defer cancel()
+
+	archive, err := s.buildArchive(ctx, userID)
+	if err != nil {
+		log.Println("Failed to build export archive:", err)
+		if err := s.exportRepo.FailExport(ctx, exportID); err != nil {
+			log.Println("Failed to save export status:", err)
+		}
+		return
+	}
+
+	if err := s.exportRepo.CompleteExport(ctx, exportID, archive, time.Now().Add(dataExportTTL)); err != nil {
+		log.Println("Failed to save export archive:", err)
+	}
+}
+
+// buildArchive writes every dataset of the user as a JSON and a CSV file.
+func (s *DataExportService) buildArchive(ctx context.Context, userID int) ([]byte, error) {
+	profile, err := s.exportRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRoles, err := s.userRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := []models.Role{}
+	if userRoles != nil {
+		roles = append(roles, *userRoles...)
+	}
+
+	workouts, err := s.exportRepo.GetWorkouts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := s.exportRepo.GetWorkoutExercises(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sets, err := s.exportRepo.GetWorkoutSets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	foods, err := s.exportRepo.GetFoods(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	integrations, err := s.exportRepo.GetIntegrations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name   string
+		data   any
+		header []string
+		rows   [][]string
+	}{
+		{"profile", profile, profileHeader, profileRows(profile)},
+		{"roles", roles, roleHeader, roleRows(roles)},
+		{"workouts", workouts, workoutHeader, workoutRows(workouts)},
+		{"workout_exercises", exercises, workoutExerciseHeader, workoutExerciseRows(exercises)},
+		{"workout_sets", sets, workoutSetHeader, workoutSetRows(sets)},
+		{"foods", foods, foodHeader, foodRows(foods)},
+		{"integrations", integrations, integrationHeader, integrationRows(integrations)},
+	}
+
+	for _, file := range files {
+		if err := writeJSONFile(zw, file.name+".json", file.data); err != nil {
+			return nil, err
+		}
+		if err := writeCSVFile(zw, file.name+".csv", file.header, file.rows); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// exportStatus reports a pending export that outlived dataExportTimeout, e.g.
+// because the server restarted, as failed.
+func exportStatus(export *models.DataExport) string {
+	if export.Status == models.DataExportStatusPending && time.Since(export.CreatedAt) > dataExportTimeout {
+		return models.DataExportStatusFailed
+	}
+
+	return export.Status
+}
+
+func newDataExportResponse(export *models.DataExport) *models.DataExportResponse {
+	response := &models.DataExportResponse{
+		ID:          export.ID,
+		Status:      exportStatus(export),
+		ExpiresAt:   export.ExpiresAt,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+	}
+
+	if response.Status == models.DataExportStatusReady && export.ExpiresAt != nil && export.ExpiresAt.After(time.Now()) {
+		response.DownloadURL = fmt.Sprintf(dataExportURLFormat, export.ID)
+	}
+
+	return response
+}
+
+func writeJSONFile(zw *zip.Writer, name string, data any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func writeCSVFile(zw *zip.Writer, name string, header []string, rows [][]string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+var (
+	profileHeader         = []string{"id", "username", "email", "email_verified_at", "two_factor_enabled", "created_at", "updated_at"}
+	roleHeader            = []string{"id", "name", "description"}
+	workoutHeader         = []string{"id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}
+	workoutExerciseHeader = []string{"id", "workout_id", "exercise_id", "exercise_name", "duration_seconds", "distance_meters", "heart_rate_avg", "started_at", "ended_at", "notes", "created_at"}
+	workoutSetHeader      = []string{"id", "workout_exercise_id", "set_number", "set_type", "reps", "weight", "rpe", "duration_seconds", "distance_meters", "heart_rate_avg", "created_at"}
+	foodHeader            = []string{"id", "date", "meal_type", "meal_name", "time", "name", "quantity", "unit", "weight_grams", "calories", "protein", "carbohydrate", "fat", "is_active"}
+	integrationHeader     = []string{"provider", "connected_at", "updated_at"}
+)
+
+func profileRows(p *models.ExportProfile) [][]string {
+	return [][]string{{
+		strconv.Itoa(p.ID),
+		p.Username,
+		p.Email,
+		formatOptionalTime(p.EmailVerifiedAt),
+		strconv.FormatBool(p.TwoFactorEnabled),
+		formatTime(p.CreatedAt),
+		formatTime(p.UpdatedAt),
+	}}
+}
+
+func roleRows(roles []models.Role) [][]string {
+	rows := [][]string{}
+	for _, r := range roles {
+		rows = append(rows, []string{strconv.Itoa(r.ID), r.Name, r.Description})
+	}
+	return rows
+}
+
+func workoutRows(workouts []models.Workout) [][]string {
+	rows := [][]string{}
+	for _, w := range workouts {
+		rows = append(rows, []string{
+			strconv.Itoa(w.ID),
+			w.Date.Format(time.DateOnly),
+			w.Notes,
+			formatOptionalTime(w.StartedAt),
+			formatOptionalTime(w.EndedAt),
+			formatOptionalInt(w.TotalSets),
+			formatOptionalFloat(w.TotalVolume),
+			formatTime(w.CreatedAt),
+			formatTime(w.UpdatedAt),
+			strconv.FormatBool(w.IsActive),
+		})
+	}
+	return rows
+}
+
+func workoutExerciseRows(exercises []models.ExportWorkoutExercise) [][]string {
+	rows := [][]string{}
+	for _, e := range exercises {
+		rows = append(rows, []string{
+			strconv.Itoa(e.ID),
+			strconv.Itoa(e.WorkoutID),
+			strconv.Itoa(e.ExerciseID),
+			e.ExerciseName,
+			formatOptionalInt(e.DurationSeconds),
+			formatOptionalFloat(e.DistanceMeters),
+			formatOptionalInt(e.HeartRateAvg),
+			formatOptionalTime(e.StartedAt),
+			formatOptionalTime(e.EndedAt),
+			e.Notes,
+			formatTime(e.CreatedAt),
+		})
+	}
+	return rows
+}
+
+func workoutSetRows(sets []models.ExportWorkoutSet) [][]string {
+	rows := [][]string{}
+	for _, s := range sets {
+		rows = append(rows, []string{
+			strconv.Itoa(s.ID),
+			strconv.Itoa(s.WorkoutExerciseID),
+			strconv.Itoa(s.SetNumber),
+			s.SetType,
+			formatOptionalInt(s.Reps),
+			formatFloat(s.Weight),
+			formatOptionalFloat(s.RPE),
+			formatOptionalInt(s.DurationSeconds),
+			formatOptionalFloat(s.DistanceMeters),
+			formatOptionalInt(s.HeartRateAvg),
+			formatTime(s.CreatedAt),
+		})
+	}
+	return rows
+}
+
+func foodRows(foods []models.ExportFood) [][]string {
+	rows := [][]string{}
+	for _, f := range foods {
+		rows = append(rows, []string{
+			strconv.Itoa(f.ID),
+			formatTime(f.Date),
+			f.MealType,
+			f.MealName,
+			formatOptionalString(f.EatenAt),
+			f.Name,
+			formatFloat(f.Quantity),
+			f.Unit,
+			formatFloat(f.WeightGrams),
+			formatFloat(f.Calories),
+			formatFloat(f.Protein),
+			formatFloat(f.Carbs),
+			formatFloat(f.Fat),
+			strconv.FormatBool(f.IsActive),
+		})
+	}
+	return rows
+}
+
+func integrationRows(integrations []models.ExportIntegration) [][]string {
+	rows := [][]string{}
+	for _, i := range integrations {
+		rows = append(rows, []string{i.Provider, formatTime(i.ConnectedAt), formatTime(i.UpdatedAt)})
+	}
+	return rows
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatOptionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+func formatOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatOptionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
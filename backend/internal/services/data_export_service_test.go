@@ -0,0 +1,43 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/repository"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestExportConcurrentPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewDataExportService(repository.NewDataExportRepository(sqlx.NewDb(db, "sqlmock")), nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM DataExports`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "expires_at", "created_at", "completed_at"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM DataExports`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO DataExports`)).
+		WithArgs(2, "pending").
+		WillReturnError(&pq.Error{Code: apperrors.PgErrUniqueViolation, Constraint: "unique_pending_data_export"})
+	mock.ExpectRollback()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err = service.RequestExport(ctx)
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,6 +16,14 @@ import (
 
 const exerciseCacheKey = "exercises"
 
+var allowedTrackingTypes = map[string]bool{
+	models.TrackingTypeWeightReps:       true,
+	models.TrackingTypeRepsOnly:         true,
+	models.TrackingTypeDuration:         true,
+	models.TrackingTypeDistanceDuration: true,
+	models.TrackingTypeBodyweightPlus:   true,
+}
+
 type ExerciseService struct {
 	exerciseRepo *repository.ExerciseRepository
 	categoryRepo *repository.CategoryRepository
@@ -32,6 +40,10 @@ func NewExerciseService(exerciseRepo *repository.ExerciseRepository, categoryRep
 
 func (s *ExerciseService) CreateExercise(ctx context.Context, req *models.ExerciseRequest) (*models.Exercise, error) {
 
+	if err := validateExerciseRequest(req); err != nil {
+		return nil, err
+	}
+
 	if category, err := s.categoryRepo.GetCategory(ctx, req.CategoryID); category == nil || err != nil {
 		return nil, &apperrors.AppError{
 			Code:    http.StatusBadRequest,
@@ -40,9 +52,10 @@ func (s *ExerciseService) CreateExercise(ctx context.Context, req *models.Exerci
 	}
 
 	exercise := &models.Exercise{
-		Name:        req.Name,
-		Description: req.Description,
-		CategoryID:  req.CategoryID,
+		Name:         req.Name,
+		Description:  req.Description,
+		CategoryID:   req.CategoryID,
+		TrackingType: req.TrackingType,
 	}
 
 	err := s.exerciseRepo.CreateExercise(ctx, exercise)
@@ -86,6 +99,10 @@ func (s *ExerciseService) CreateExercise(ctx context.Context, req *models.Exerci
 		}
 	}
 
+	if err := s.setMuscleGroups(ctx, exercise, req); err != nil {
+		return nil, err
+	}
+
 	return exercise, nil
 }
 
@@ -123,6 +140,8 @@ func (s *ExerciseService) GetExercises(ctx context.Context, filter *models.Exerc
 		}
 	}
 
+	s.attachMuscleGroups(ctx, *exercises)
+
 	return exercises, total, nil
 }
 
@@ -161,11 +180,18 @@ func (s *ExerciseService) GetExercise(ctx context.Context, id int) (*models.Exer
 		}
 	}
 
-	return exercise, nil
+	exercises := []models.Exercise{*exercise}
+	s.attachMuscleGroups(ctx, exercises)
+
+	return &exercises[0], nil
 }
 
 func (s *ExerciseService) UpdateExercise(ctx context.Context, id int, req *models.ExerciseRequest) (*models.Exercise, error) {
 
+	if err := validateExerciseRequest(req); err != nil {
+		return nil, err
+	}
+
 	if category, err := s.categoryRepo.GetCategory(ctx, req.CategoryID); category == nil || err != nil {
 		return nil, &apperrors.AppError{
 			Code:    http.StatusBadRequest,
@@ -174,10 +200,11 @@ func (s *ExerciseService) UpdateExercise(ctx context.Context, id int, req *model
 	}
 
 	exercise := &models.Exercise{
-		ID:          id,
-		Name:        req.Name,
-		Description: req.Description,
-		CategoryID:  req.CategoryID,
+		ID:           id,
+		Name:         req.Name,
+		Description:  req.Description,
+		CategoryID:   req.CategoryID,
+		TrackingType: req.TrackingType,
 	}
 
 	err := s.exerciseRepo.UpdateExercise(ctx, exercise)
@@ -228,6 +255,10 @@ func (s *ExerciseService) UpdateExercise(ctx context.Context, id int, req *model
 		}
 	}
 
+	if err := s.setMuscleGroups(ctx, exercise, req); err != nil {
+		return nil, err
+	}
+
 	return exercise, nil
 }
 
@@ -267,3 +298,125 @@ func (s *ExerciseService) DeleteExercise(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (s *ExerciseService) GetMuscleGroups(ctx context.Context) (*[]models.MuscleGroup, error) {
+	muscleGroups, err := s.exerciseRepo.GetMuscleGroups(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get muscle groups",
+			}
+		}
+	}
+
+	if muscleGroups == nil || len(*muscleGroups) == 0 {
+		log.Println("Muscle groups not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Muscle groups not found",
+		}
+	}
+
+	return muscleGroups, nil
+}
+
+// setMuscleGroups replaces muscle groups of the exercise, request without
+// muscle group ids keeps the current ones.
+func (s *ExerciseService) setMuscleGroups(ctx context.Context, exercise *models.Exercise, req *models.ExerciseRequest) error {
+	if req.PrimaryMuscleGroupIDs != nil || req.SecondaryMuscleGroupIDs != nil {
+		err := s.exerciseRepo.SetExerciseMuscleGroups(ctx, exercise.ID, req.PrimaryMuscleGroupIDs, req.SecondaryMuscleGroupIDs)
+		if err != nil {
+			var pgErr *pq.Error
+			if errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation {
+				log.Println("Foreign key violation:", pgErr)
+				return &apperrors.AppError{
+					Code:    http.StatusBadRequest,
+					Message: "Incorrect muscle group id",
+				}
+			}
+
+			log.Println("Unhandled error:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to save exercise muscle groups",
+			}
+		}
+	}
+
+	exercises := []models.Exercise{*exercise}
+	s.attachMuscleGroups(ctx, exercises)
+	*exercise = exercises[0]
+
+	return nil
+}
+
+func (s *ExerciseService) attachMuscleGroups(ctx context.Context, exercises []models.Exercise) {
+	if len(exercises) == 0 {
+		return
+	}
+
+	index := make(map[int]int, len(exercises))
+	ids := make([]int, 0, len(exercises))
+	for i, exercise := range exercises {
+		index[exercise.ID] = i
+		ids = append(ids, exercise.ID)
+	}
+
+	muscleGroups, err := s.exerciseRepo.GetExerciseMuscleGroups(ctx, ids)
+	if err != nil {
+		log.Println("Failed to get exercise muscle groups:", err)
+		return
+	}
+
+	for _, muscleGroup := range *muscleGroups {
+		exercise := &exercises[index[muscleGroup.ExerciseID]]
+		if muscleGroup.IsPrimary {
+			exercise.PrimaryMuscles = append(exercise.PrimaryMuscles, muscleGroup.MuscleGroup)
+		} else {
+			exercise.SecondaryMuscles = append(exercise.SecondaryMuscles, muscleGroup.MuscleGroup)
+		}
+	}
+}
+
+func validateExerciseRequest(req *models.ExerciseRequest) error {
+	if req.TrackingType == "" {
+		req.TrackingType = models.TrackingTypeWeightReps
+	}
+
+	if !allowedTrackingTypes[req.TrackingType] {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid tracking type",
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, id := range append(append([]int{}, req.PrimaryMuscleGroupIDs...), req.SecondaryMuscleGroupIDs...) {
+		if id < 1 || seen[id] {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Incorrect muscle group id",
+			}
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -6,25 +6,41 @@ import (
 	"backend/internal/models"
 	"backend/internal/repository"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 )
 
+const foodSearchLimit = 20
+
 type FoodService struct {
-	nutritionixClient *clients.NutritionixClient
+	nutritionProvider clients.NutritionProvider
 	foodRepo          *repository.FoodRepository
+	customFoodRepo    *repository.CustomFoodRepository
+	recipeRepo        *repository.RecipeRepository
 }
 
-func NewFoodService(nutritionixClient *clients.NutritionixClient, foodRepo *repository.FoodRepository) *FoodService {
+func NewFoodService(nutritionProvider clients.NutritionProvider, foodRepo *repository.FoodRepository, customFoodRepo *repository.CustomFoodRepository, recipeRepo *repository.RecipeRepository) *FoodService {
 	return &FoodService{
-		nutritionixClient: nutritionixClient,
+		nutritionProvider: nutritionProvider,
 		foodRepo:          foodRepo,
+		customFoodRepo:    customFoodRepo,
+		recipeRepo:        recipeRepo,
 	}
 }
 
+// AddFood logs the items with CustomFoodID or RecipeID from the personal
+// library and looks up the others with the nutrition provider, consecutive
+// provider items share one query. The result follows the order of the
+// request. Entries keep a copy of the nutrients, so later changes of a custom
+// food or recipe do not affect them. All items are logged into the meal of
+// the request.
 func (s *FoodService) AddFood(ctx context.Context, req *models.FoodRequest) (*[]models.Food, error) {
 	userID, ok := ctx.Value("user_id").(int)
 	if !ok {
@@ -42,32 +58,111 @@ func (s *FoodService) AddFood(ctx context.Context, req *models.FoodRequest) (*[]
 		}
 	}
 
-	foodData, err := s.nutritionixClient.GetNutritionData(buildNutritionixQuery(req.Items))
+	if len(req.Items) == 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Items are required",
+		}
+	}
+
+	mealType, mealName, err := normalizeMeal(req.MealType, req.MealName)
 	if err != nil {
-		log.Println("Nutritionix client error:", err)
+		return nil, err
+	}
+
+	if mealType == models.MealUnspecified {
 		return nil, &apperrors.AppError{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
+			Code:    http.StatusBadRequest,
+			Message: "Meal type must be breakfast, lunch, dinner, snack or custom",
+		}
+	}
+
+	eatenAt, err := parseEatenAt(req.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		foods         []models.Food
+		providerItems []models.FoodRequestItem
+	)
+
+	// Consecutive provider items are parsed in one query, flushing them
+	// before a personal item keeps the entries in the order of the request.
+	flushProviderItems := func() error {
+		if len(providerItems) == 0 {
+			return nil
+		}
+
+		foodData, err := s.nutritionProvider.Parse(ctx, buildNutritionixQuery(providerItems))
+		if err != nil {
+			return nutritionProviderError(err)
 		}
+
+		for _, f := range foodData {
+			food := models.Food{
+				Name:        f.Name,
+				Quantity:    f.ServingQty,
+				WeightGrams: f.ServingWeightGrams,
+				Uint:        f.ServingUnit,
+				Calories:    f.Calories,
+				Protein:     f.Protein,
+				Carbs:       f.Carbs,
+				Fat:         f.Fat,
+			}
+
+			foods = append(foods, food)
+		}
+
+		providerItems = nil
+		return nil
 	}
 
-	var foods []models.Food
+	for _, item := range req.Items {
+		var (
+			food *models.Food
+			err  error
+		)
+
+		switch {
+		case item.CustomFoodID != 0 && item.RecipeID != 0:
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Item must refer to either a custom food or a recipe",
+			}
+
+		case item.CustomFoodID != 0:
+			food, err = s.customFoodEntry(ctx, userID, item)
+
+		case item.RecipeID != 0:
+			food, err = s.recipeEntry(ctx, userID, item)
+
+		default:
+			providerItems = append(providerItems, item)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
 
-	for _, f := range foodData.Foods {
-		food := models.Food{
-			UserID:      userID,
-			Date:        parsedDate,
-			Name:        f.FoodName,
-			Quantity:    f.ServingQty,
-			WeightGrams: f.ServingWeightGrams,
-			Uint:        f.ServingUint,
-			Calories:    f.Calories,
-			Protein:     f.Protein,
-			Carbs:       f.Carbs,
-			Fat:         f.Fat,
+		if err := flushProviderItems(); err != nil {
+			return nil, err
 		}
 
-		foods = append(foods, food)
+		foods = append(foods, *food)
+	}
+
+	if err := flushProviderItems(); err != nil {
+		return nil, err
+	}
+
+	for i := range foods {
+		foods[i].UserID = userID
+		foods[i].Date = parsedDate
+		foods[i].MealType = mealType
+		foods[i].MealName = mealName
+		foods[i].EatenAt = eatenAt
 	}
 
 	if err := s.foodRepo.CreateFood(ctx, &foods); err != nil {
@@ -81,7 +176,137 @@ func (s *FoodService) AddFood(ctx context.Context, req *models.FoodRequest) (*[]
 	return &foods, nil
 }
 
-func (s *FoodService) GetFoodByDate(ctx context.Context, date string) (*[]models.Food, error) {
+// SearchFoods returns the matching foods of the personal library first and
+// the foods of the nutrition provider after them. The personal foods are
+// still returned when the provider fails.
+func (s *FoodService) SearchFoods(ctx context.Context, query string) ([]models.FoodSearchItem, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Query is required",
+		}
+	}
+
+	customFoods, err := s.customFoodRepo.SearchCustomFoods(ctx, userID, query, foodSearchLimit)
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to search foods")
+	}
+
+	items := make([]models.FoodSearchItem, 0, len(customFoods))
+	for _, food := range customFoods {
+		items = append(items, newCustomFoodSearchItem(food))
+	}
+
+	providerFoods, err := s.nutritionProvider.Search(ctx, query)
+	if err != nil && !errors.Is(err, clients.ErrFoodNotFound) {
+		log.Println("Nutrition provider error:", err)
+		if len(items) == 0 {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to search foods",
+			}
+		}
+	}
+
+	for _, food := range providerFoods {
+		items = append(items, models.FoodSearchItem{
+			Source:        models.FoodSourceProvider,
+			NutritionFood: food,
+		})
+	}
+
+	if len(items) == 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Foods not found",
+		}
+	}
+
+	return items, nil
+}
+
+// customFoodEntry computes the nutrients of an item from the personal
+// library, the quantity is a weight for "g", "kg" and "ml" and in servings
+// otherwise. Like the nutrition provider, a millilitre counts as a gram.
+func (s *FoodService) customFoodEntry(ctx context.Context, userID int, item models.FoodRequestItem) (*models.Food, error) {
+	if item.CustomFoodID < 0 || item.Quantity <= 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Custom food id and quantity must be greater than zero",
+		}
+	}
+
+	customFood, err := s.customFoodRepo.GetCustomFoodByUserID(ctx, userID, item.CustomFoodID)
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to get custom food")
+	}
+
+	unit := strings.TrimSpace(item.Unit)
+	food := &models.Food{
+		Name:     customFood.Name,
+		Quantity: item.Quantity,
+	}
+
+	switch {
+	case unit == "g" || unit == "kg" || unit == "ml":
+		if customFood.Per100g == nil {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Custom food has no nutrition per 100g",
+			}
+		}
+
+		grams := item.Quantity
+		if unit == "kg" {
+			grams *= 1000
+		}
+
+		food.Uint = unit
+		food.WeightGrams = grams
+		setFoodNutrients(food, scaleNutrients(*customFood.Per100g, grams/100))
+
+	case unit == "" || unit == "serving" || strings.EqualFold(unit, customFood.ServingUnit):
+		if customFood.PerServing == nil {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Custom food has no nutrition per serving",
+			}
+		}
+
+		servings := item.Quantity / customFood.ServingQty
+		food.Uint = customFood.ServingUnit
+		if food.Uint == "" {
+			food.Uint = "serving"
+		}
+		if customFood.ServingWeightGrams != nil {
+			food.WeightGrams = roundNutrient(servings * *customFood.ServingWeightGrams)
+		}
+		setFoodNutrients(food, scaleNutrients(*customFood.PerServing, servings))
+
+	default:
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Unit must be g, kg, ml or the serving unit of the custom food",
+		}
+	}
+
+	return food, nil
+}
+
+// GetFoodByDate groups the entries of the day by meal, breakfast, lunch,
+// dinner and snack come first and custom meals after them in the order they
+// were eaten. A day without entries has no meals and zero totals.
+func (s *FoodService) GetFoodByDate(ctx context.Context, date string) (*models.FoodDay, error) {
 	userID, ok := ctx.Value("user_id").(int)
 	if !ok {
 		return nil, &apperrors.AppError{
@@ -108,10 +333,127 @@ func (s *FoodService) GetFoodByDate(ctx context.Context, date string) (*[]models
 		}
 	}
 
-	if foods == nil || len(*foods) == 0 {
+	if foods == nil {
+		foods = &[]models.Food{}
+	}
+
+	return groupFoodsByMeal(userID, parsedDate, *foods), nil
+}
+
+// UpdateFood changes the quantity of a diary entry, the weight and nutrients
+// are rescaled in proportion.
+func (s *FoodService) UpdateFood(ctx context.Context, foodID int, req *models.UpdateFoodRequest) (*models.Food, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
 		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if req.Quantity <= 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Quantity must be greater than zero",
+		}
+	}
+
+	food, err := s.foodRepo.UpdateFoodQuantity(ctx, userID, foodID, req.Quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Entries logged without a quantity cannot be rescaled, they are
+		// reported apart from the ones that do not exist.
+		if current, getErr := s.foodRepo.GetFood(ctx, userID, foodID); getErr == nil && current.Quantity <= 0 {
+			log.Println("Food has no quantity to rescale")
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Food has no quantity to rescale, delete it and log it again",
+			}
+		}
+	}
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Food not found", "Failed to update food")
+	}
+
+	return food, nil
+}
+
+func (s *FoodService) DeleteFood(ctx context.Context, foodID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.foodRepo.DeleteFood(ctx, userID, foodID)
+	if err != nil {
+		return apperrors.WrapNotFound(err, "Food not found", "Failed to delete food")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Food not found")
+		return &apperrors.AppError{
 			Code:    http.StatusNotFound,
-			Message: "Foods not found",
+			Message: "Food not found",
+		}
+	}
+
+	return nil
+}
+
+// CopyMeal logs the entries of a meal again on another date, with the same
+// nutrients and times.
+func (s *FoodService) CopyMeal(ctx context.Context, req *models.CopyMealRequest) ([]models.Food, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	fromDate, err := time.Parse("2006-01-02", req.FromDate)
+	if err != nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid from_date format",
+		}
+	}
+
+	toDate, err := time.Parse("2006-01-02", req.ToDate)
+	if err != nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid to_date format",
+		}
+	}
+
+	if fromDate.Equal(toDate) {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Meal cannot be copied to the same date",
+		}
+	}
+
+	mealType, mealName, err := normalizeMeal(req.MealType, req.MealName)
+	if err != nil {
+		return nil, err
+	}
+
+	foods, err := s.foodRepo.CopyMeal(ctx, userID, fromDate, mealType, mealName, toDate)
+	if err != nil {
+		return nil, apperrors.WrapNotFound(err, "Food not found", "Failed to copy meal")
+	}
+
+	if len(foods) == 0 {
+		log.Println("Meal not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Meal not found",
 		}
 	}
 
@@ -126,3 +468,214 @@ func buildNutritionixQuery(items []models.FoodRequestItem) string {
 
 	return strings.Join(parts, " and ")
 }
+
+// recipeEntry computes the nutrients of the eaten servings of a recipe.
+func (s *FoodService) recipeEntry(ctx context.Context, userID int, item models.FoodRequestItem) (*models.Food, error) {
+	if item.RecipeID < 0 || item.Quantity <= 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Recipe id and quantity must be greater than zero",
+		}
+	}
+
+	if unit := strings.TrimSpace(item.Unit); unit != "" && unit != "serving" && unit != "servings" {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Unit of a recipe must be serving",
+		}
+	}
+
+	recipe, err := s.recipeRepo.GetRecipeByUserID(ctx, userID, item.RecipeID)
+	if err != nil {
+		return nil, recipeError(err, "Failed to get recipe")
+	}
+
+	ratio := item.Quantity / recipe.Servings
+	food := &models.Food{
+		Name:        recipe.Name,
+		Quantity:    item.Quantity,
+		Uint:        "serving",
+		WeightGrams: roundNutrient(recipe.TotalWeightGrams * ratio),
+	}
+	setFoodNutrients(food, scaleNutrients(recipe.Total, ratio))
+
+	return food, nil
+}
+
+// nutritionProviderError reports foods unknown to the provider as not found
+// and hides the other provider errors from the client.
+func nutritionProviderError(err error) error {
+	log.Println("Nutrition provider error:", err)
+	if errors.Is(err, clients.ErrFoodNotFound) {
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Food not found",
+		}
+	}
+	return &apperrors.AppError{
+		Code:    http.StatusInternalServerError,
+		Message: "Internal server error",
+	}
+}
+
+// newCustomFoodSearchItem describes a custom food by its serving, or by 100g
+// when the nutrition per serving is unknown.
+func newCustomFoodSearchItem(food models.CustomFood) models.FoodSearchItem {
+	item := models.FoodSearchItem{
+		Source:       models.FoodSourceCustom,
+		CustomFoodID: food.ID,
+		NutritionFood: models.NutritionFood{
+			Name:    food.Name,
+			Brand:   food.Brand,
+			Barcode: food.Barcode,
+		},
+	}
+
+	nutrients := food.PerServing
+	if nutrients != nil {
+		item.ServingQty = food.ServingQty
+		item.ServingUnit = food.ServingUnit
+		if food.ServingWeightGrams != nil {
+			item.ServingWeightGrams = *food.ServingWeightGrams
+		}
+	} else {
+		nutrients = food.Per100g
+		item.ServingQty = 100
+		item.ServingUnit = "g"
+		item.ServingWeightGrams = 100
+	}
+
+	item.Calories = nutrients.Calories
+	item.Protein = nutrients.Protein
+	item.Carbs = nutrients.Carbs
+	item.Fat = nutrients.Fat
+
+	return item
+}
+
+// mealOrder is the position of the meal types in a day, custom meals and
+// entries without a meal go last.
+var mealOrder = map[string]int{
+	models.MealBreakfast:   0,
+	models.MealLunch:       1,
+	models.MealDinner:      2,
+	models.MealSnack:       3,
+	models.MealCustom:      4,
+	models.MealUnspecified: 5,
+}
+
+// normalizeMeal defaults the meal type to snack and keeps the name only for
+// custom meals. Unspecified is accepted so old entries can still be copied.
+func normalizeMeal(mealType, mealName string) (string, string, error) {
+	mealType = strings.ToLower(strings.TrimSpace(mealType))
+	if mealType == "" {
+		mealType = models.MealSnack
+	}
+
+	if _, ok := mealOrder[mealType]; !ok {
+		return "", "", &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Meal type must be breakfast, lunch, dinner, snack or custom",
+		}
+	}
+
+	if mealType != models.MealCustom {
+		return mealType, "", nil
+	}
+
+	mealName = strings.TrimSpace(mealName)
+	if mealName == "" || len(mealName) > 50 {
+		return "", "", &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Name of a custom meal is required and must be at most 50 characters",
+		}
+	}
+
+	return mealType, mealName, nil
+}
+
+// parseEatenAt returns nil for an empty time, otherwise the time as HH:MM.
+func parseEatenAt(value string) (*string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid time format. Use HH:MM",
+		}
+	}
+
+	eatenAt := t.Format("15:04")
+	return &eatenAt, nil
+}
+
+// groupFoodsByMeal keeps the order of the entries within a meal and sums the
+// nutrients of each meal and of the day.
+func groupFoodsByMeal(userID int, date time.Time, foods []models.Food) *models.FoodDay {
+	day := &models.FoodDay{
+		UserID: userID,
+		Date:   date,
+		Meals:  []models.Meal{},
+	}
+
+	meals := map[[2]string]int{}
+	for _, food := range foods {
+		key := [2]string{food.MealType, food.MealName}
+		i, ok := meals[key]
+		if !ok {
+			i = len(day.Meals)
+			meals[key] = i
+			day.Meals = append(day.Meals, models.Meal{
+				MealType: food.MealType,
+				MealName: food.MealName,
+			})
+		}
+
+		meal := &day.Meals[i]
+		meal.Foods = append(meal.Foods, food)
+		addFoodNutrients(&meal.Total, food)
+		addFoodNutrients(&day.Total, food)
+	}
+
+	sort.SliceStable(day.Meals, func(i, j int) bool {
+		return mealOrder[day.Meals[i].MealType] < mealOrder[day.Meals[j].MealType]
+	})
+
+	for i := range day.Meals {
+		day.Meals[i].Total = scaleNutrients(day.Meals[i].Total, 1)
+	}
+	day.Total = scaleNutrients(day.Total, 1)
+
+	return day
+}
+
+func addFoodNutrients(total *models.Nutrients, food models.Food) {
+	total.Calories += food.Calories
+	total.Protein += food.Protein
+	total.Carbs += food.Carbs
+	total.Fat += food.Fat
+}
+
+func setFoodNutrients(food *models.Food, n models.Nutrients) {
+	food.Calories = n.Calories
+	food.Protein = n.Protein
+	food.Carbs = n.Carbs
+	food.Fat = n.Fat
+}
+
+func scaleNutrients(n models.Nutrients, ratio float64) models.Nutrients {
+	return models.Nutrients{
+		Calories: roundNutrient(n.Calories * ratio),
+		Protein:  roundNutrient(n.Protein * ratio),
+		Carbs:    roundNutrient(n.Carbs * ratio),
+		Fat:      roundNutrient(n.Fat * ratio),
+	}
+}
+
+func roundNutrient(value float64) float64 {
+	return math.Round(value*100) / 100
+}
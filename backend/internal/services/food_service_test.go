@@ -0,0 +1,428 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/clients"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	service := NewFoodService(provider, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	eatenAt := "11:00"
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods`))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "rice", 200.0, "g", 200.0, 260.0, 5.4, 56.4, 0.6, "custom", "Brunch", &eatenAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "egg", 2.0, "large", 100.0, 143.0, 12.6, 0.8, 9.6, "custom", "Brunch", &eatenAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	foods, err := service.AddFood(ctx, &models.FoodRequest{
+		Date:     "2024-05-01",
+		MealType: "custom",
+		MealName: " Brunch ",
+		Time:     "11:00",
+		Items: []models.FoodRequestItem{
+			{ProductName: "rice", Quantity: 200, Unit: "g"},
+			{ProductName: "eggs", Quantity: 2},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, *foods, 2)
+	assert.Equal(t, 2, (*foods)[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddFoodNotFound(t *testing.T) {
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	service := NewFoodService(provider, nil, nil, nil)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err = service.AddFood(ctx, &models.FoodRequest{
+		Date:  "2024-05-01",
+		Items: []models.FoodRequestItem{{ProductName: "dragon fruit", Quantity: 1}},
+	})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestAddFoodCustomFood(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	service := NewFoodService(nil, repository.NewFoodRepository(sqlxDB), repository.NewCustomFoodRepository(sqlxDB), nil)
+
+	now := time.Now()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods`)).
+		WithArgs(2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "brand", "barcode", "serving_qty", "serving_unit",
+			"serving_weight_grams", "calories_100g", "protein_100g", "carbs_100g", "fat_100g",
+			"calories_serving", "protein_serving", "carbs_serving", "fat_serving", "created_at", "updated_at", "is_active"}).
+			AddRow(5, 2, "Protein bar", "", "", 1.0, "bar", 40.0, 500.0, 50.0, 45.0, 15.0, 200.0, 20.0, 18.0, 6.0, now, now, true))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods`))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "Protein bar", 1.5, "bar", 60.0, 300.0, 30.0, 27.0, 9.0, "snack", "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	foods, err := service.AddFood(ctx, &models.FoodRequest{
+		Date:  "2024-05-01",
+		Items: []models.FoodRequestItem{{CustomFoodID: 5, Quantity: 1.5}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, *foods, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddFoodKeepsRequestOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	service := NewFoodService(provider, repository.NewFoodRepository(sqlxDB), repository.NewCustomFoodRepository(sqlxDB), nil)
+
+	now := time.Now()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods`)).
+		WithArgs(2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "brand", "barcode", "serving_qty", "serving_unit",
+			"serving_weight_grams", "calories_100g", "protein_100g", "carbs_100g", "fat_100g",
+			"calories_serving", "protein_serving", "carbs_serving", "fat_serving", "created_at", "updated_at", "is_active"}).
+			AddRow(5, 2, "Protein bar", "", "", 1.0, "bar", 40.0, 500.0, 50.0, 45.0, 15.0, 200.0, 20.0, 18.0, 6.0, now, now, true))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods`))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "rice", 200.0, "g", 200.0, 260.0, 5.4, 56.4, 0.6, "snack", "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "Protein bar", 0.1, "kg", 100.0, 500.0, 50.0, 45.0, 15.0, "snack", "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "egg", 2.0, "large", 100.0, 143.0, 12.6, 0.8, 9.6, "snack", "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	foods, err := service.AddFood(ctx, &models.FoodRequest{
+		Date: "2024-05-01",
+		Items: []models.FoodRequestItem{
+			{ProductName: "rice", Quantity: 200, Unit: "g"},
+			{CustomFoodID: 5, Quantity: 0.1, Unit: "kg"},
+			{ProductName: "eggs", Quantity: 2},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rice", "Protein bar", "egg"}, []string{(*foods)[0].Name, (*foods)[1].Name, (*foods)[2].Name})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchFoods(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	service := NewFoodService(provider, nil, repository.NewCustomFoodRepository(sqlx.NewDb(db, "sqlmock")), nil)
+
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM CustomFoods`)).
+		WithArgs(2, "rice", foodSearchLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "brand", "barcode", "serving_qty", "serving_unit",
+			"serving_weight_grams", "calories_100g", "protein_100g", "carbs_100g", "fat_100g",
+			"calories_serving", "protein_serving", "carbs_serving", "fat_serving", "created_at", "updated_at", "is_active"}).
+			AddRow(7, 2, "Rice pilaf", "", "", 1.0, "", nil, 150.0, 4.0, 25.0, 4.0, nil, nil, nil, nil, now, now, true))
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	items, err := service.SearchFoods(ctx, "rice")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, models.FoodSourceCustom, items[0].Source)
+	assert.Equal(t, 7, items[0].CustomFoodID)
+	assert.Equal(t, 100.0, items[0].ServingWeightGrams)
+	assert.Equal(t, models.FoodSourceProvider, items[1].Source)
+	assert.Equal(t, "fake-1", items[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFoodByDateGroupsByMeal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewFoodService(nil, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods`)).
+		WithArgs(2, date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams",
+			"calories", "protein", "carbs", "fat", "meal_type", "meal_name", "eaten_at"}).
+			AddRow(1, 2, date, "oatmeal", 1.0, "cup", 234.0, 158.0, 6.0, 27.0, 3.2, "breakfast", "", "08:00").
+			AddRow(2, 2, date, "banana", 1.0, "pcs", 120.0, 105.0, 1.3, 27.0, 0.4, "custom", "Pre-workout", "10:30").
+			AddRow(3, 2, date, "rice", 200.0, "g", 200.0, 260.0, 5.4, 56.4, 0.6, "lunch", "", "13:00").
+			AddRow(4, 2, date, "egg", 2.0, "large", 100.0, 143.0, 12.6, 0.8, 9.6, "lunch", "", "13:00").
+			AddRow(5, 2, date, "apple", 1.0, "pcs", 180.0, 95.0, 0.5, 25.0, 0.3, "snack", "", nil).
+			AddRow(6, 2, date, "bread", 1.0, "slice", 30.0, 80.0, 3.0, 15.0, 1.0, "unspecified", "", nil))
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	day, err := service.GetFoodByDate(ctx, "2024-05-01")
+	assert.NoError(t, err)
+	assert.Len(t, day.Meals, 5)
+
+	var meals []string
+	for _, meal := range day.Meals {
+		meals = append(meals, meal.MealType+meal.MealName)
+	}
+	assert.Equal(t, []string{"breakfast", "lunch", "snack", "customPre-workout", "unspecified"}, meals)
+
+	assert.Len(t, day.Meals[1].Foods, 2)
+	assert.Equal(t, models.Nutrients{Calories: 403, Protein: 18, Carbs: 57.2, Fat: 10.2}, day.Meals[1].Total)
+	assert.Equal(t, models.Nutrients{Calories: 841, Protein: 28.8, Carbs: 151.2, Fat: 15.1}, day.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyMeal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewFoodService(nil, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	fromDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, fromDate, "breakfast", "", toDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams",
+			"calories", "protein", "carbs", "fat", "meal_type", "meal_name", "eaten_at"}))
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err = service.CopyMeal(ctx, &models.CopyMealRequest{
+		FromDate: "2024-05-01",
+		ToDate:   "2024-05-02",
+		MealType: "Breakfast",
+		MealName: "ignored",
+	})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddFoodInvalidMeal(t *testing.T) {
+	service := NewFoodService(nil, nil, nil, nil)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	tests := []models.FoodRequest{
+		{Date: "2024-05-01", MealType: "brunch"},
+		{Date: "2024-05-01", MealType: "custom"},
+		{Date: "2024-05-01", MealType: "dinner", Time: "25:00"},
+		{Date: "2024-05-01", MealType: "unspecified"},
+	}
+
+	for _, req := range tests {
+		req.Items = []models.FoodRequestItem{{ProductName: "rice", Quantity: 200, Unit: "g"}}
+		_, err := service.AddFood(ctx, &req)
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	}
+}
+
+func TestGetFoodByDateEmptyDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewFoodService(nil, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods`)).
+		WithArgs(2, date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams",
+			"calories", "protein", "carbs", "fat", "meal_type", "meal_name", "eaten_at"}))
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	day, err := service.GetFoodByDate(ctx, "2024-05-01")
+	assert.NoError(t, err)
+	assert.NotNil(t, day.Meals)
+	assert.Empty(t, day.Meals)
+	assert.Equal(t, models.Nutrients{}, day.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyMealSameDate(t *testing.T) {
+	service := NewFoodService(nil, nil, nil, nil)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err := service.CopyMeal(ctx, &models.CopyMealRequest{
+		FromDate: "2024-05-01",
+		ToDate:   "2024-05-01",
+		MealType: "lunch",
+	})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+var foodRowColumns = []string{"id", "user_id", "date", "name", "quantity", "unit", "weight_grams", "calories", "protein", "carbs", "fat",
+	"meal_type", "meal_name", "eaten_at"}
+
+func TestUpdateFood(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		quantity float64
+		expect   func(mock sqlmock.Sqlmock)
+		wantCode int
+	}{
+		{
+			name:     "rescales the entry",
+			quantity: 3,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Foods`)).
+					WithArgs(3.0, 7, 2).
+					WillReturnRows(sqlmock.NewRows(foodRowColumns).
+						AddRow(7, 2, date, "egg", 3, "large", 150, 214.5, 18.9, 1.2, 14.4, "breakfast", "", nil))
+			},
+		},
+		{
+			name:     "quantity must be positive",
+			quantity: 0,
+			expect:   func(mock sqlmock.Sqlmock) {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "entry without quantity",
+			quantity: 2,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Foods`)).
+					WithArgs(2.0, 7, 2).
+					WillReturnRows(sqlmock.NewRows(foodRowColumns))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods`)).
+					WithArgs(7, 2).
+					WillReturnRows(sqlmock.NewRows(foodRowColumns).
+						AddRow(7, 2, date, "egg", 0, "large", 0, 143, 12.6, 0.8, 9.6, "unspecified", "", nil))
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "missing entry",
+			quantity: 2,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Foods`)).
+					WithArgs(2.0, 7, 2).
+					WillReturnRows(sqlmock.NewRows(foodRowColumns))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM Foods`)).
+					WithArgs(7, 2).
+					WillReturnRows(sqlmock.NewRows(foodRowColumns))
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			service := NewFoodService(nil, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+			tt.expect(mock)
+
+			ctx := context.WithValue(context.Background(), "user_id", 2)
+			food, err := service.UpdateFood(ctx, 7, &models.UpdateFoodRequest{Quantity: tt.quantity})
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+				assert.EqualValues(t, 3, food.Quantity)
+			} else {
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteFood(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantCode     int
+	}{
+		{"deletes the entry", 1, 0},
+		{"missing entry", 0, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			service := NewFoodService(nil, repository.NewFoodRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE Foods`)).
+				WithArgs(7, 2).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			ctx := context.WithValue(context.Background(), "user_id", 2)
+			err = service.DeleteFood(ctx, 7)
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+			} else {
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
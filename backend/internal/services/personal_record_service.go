@@ -0,0 +1,226 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+type PersonalRecordService struct {
+	recordRepo          *repository.PersonalRecordRepository
+	exerciseRepo        *repository.ExerciseRepository
+	workoutExerciseRepo *repository.WorkoutExerciseRepository
+}
+
+func NewPersonalRecordService(
+	recordRepo *repository.PersonalRecordRepository,
+	exerciseRepo *repository.ExerciseRepository,
+	workoutExerciseRepo *repository.WorkoutExerciseRepository,
+) *PersonalRecordService {
+	return &PersonalRecordService{
+		recordRepo:          recordRepo,
+		exerciseRepo:        exerciseRepo,
+		workoutExerciseRepo: workoutExerciseRepo,
+	}
+}
+
+func (s *PersonalRecordService) GetMyRecords(ctx context.Context) (*[]models.PersonalRecord, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	records, err := s.recordRepo.GetCurrentRecords(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get records")
+	}
+
+	if records == nil || len(*records) == 0 {
+		log.Println("Records not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Records not found",
+		}
+	}
+
+	return records, nil
+}
+
+func (s *PersonalRecordService) GetExerciseRecords(ctx context.Context, exerciseID int) (*[]models.PersonalRecord, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if exercise, err := s.exerciseRepo.GetExercise(ctx, exerciseID); exercise == nil || err != nil {
+		log.Println("Exercise not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Exercise not found",
+		}
+	}
+
+	records, err := s.recordRepo.GetRecordsByExerciseID(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get records")
+	}
+
+	if records == nil || len(*records) == 0 {
+		log.Println("Records not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Records not found",
+		}
+	}
+
+	return records, nil
+}
+
+// UpdateRecords recalculates records of the exercise and returns records
+// that were set by the given workout exercise for the first time. Callers run
+// it after their write is committed and only log the error: records are
+// rebuilt from all sets, so the next change of the exercise repairs them.
+func (s *PersonalRecordService) UpdateRecords(ctx context.Context, userID, exerciseID, workoutExerciseID int) ([]models.PersonalRecord, error) {
+	records, err := s.recalculateRecords(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to update personal records")
+	}
+
+	var newRecords []models.PersonalRecord
+	for _, record := range records {
+		if record.WorkoutExerciseID == workoutExerciseID {
+			newRecords = append(newRecords, record)
+		}
+	}
+
+	return newRecords, nil
+}
+
+func (s *PersonalRecordService) UpdateWorkoutRecords(ctx context.Context, userID, workoutID int) error {
+	workoutExercises, err := s.workoutExerciseRepo.GetExercisesByWorkoutID(ctx, workoutID)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to update personal records")
+	}
+
+	recalculated := make(map[int]bool)
+	for _, workoutExercise := range *workoutExercises {
+		if recalculated[workoutExercise.ExerciseID] {
+			continue
+		}
+		recalculated[workoutExercise.ExerciseID] = true
+
+		if _, err := s.recalculateRecords(ctx, userID, workoutExercise.ExerciseID); err != nil {
+			return apperrors.Wrap(err, "Failed to update personal records")
+		}
+	}
+
+	return nil
+}
+
+func (s *PersonalRecordService) recalculateRecords(ctx context.Context, userID, exerciseID int) ([]models.PersonalRecord, error) {
+	previous, err := s.recordRepo.GetRecordsByExerciseID(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	sets, err := s.recordRepo.GetRecordSets(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := computePersonalRecords(*sets)
+	for i := range records {
+		records[i].UserID = userID
+		records[i].ExerciseID = exerciseID
+	}
+
+	if err := s.recordRepo.ReplaceRecords(ctx, userID, exerciseID, &records); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, record := range *previous {
+		if record.IsCurrent {
+			known[personalRecordKey(record)] = true
+		}
+	}
+
+	var newRecords []models.PersonalRecord
+	for _, record := range records {
+		if record.IsCurrent && !known[personalRecordKey(record)] {
+			newRecords = append(newRecords, record)
+		}
+	}
+
+	return newRecords, nil
+}
+
+// computePersonalRecords walks sets in chronological order and keeps every
+// improvement as history; the last improvement of each record is current.
+func computePersonalRecords(sets []models.RecordSet) []models.PersonalRecord {
+	var records []models.PersonalRecord
+	current := make(map[string]int)
+
+	improve := func(key string, record models.PersonalRecord) {
+		if i, ok := current[key]; ok && records[i].Value >= record.Value {
+			return
+		}
+		current[key] = len(records)
+		records = append(records, record)
+	}
+
+	var volume float64
+	for i, set := range sets {
+		newRecord := func(recordType string, value float64) models.PersonalRecord {
+			return models.PersonalRecord{
+				RecordType:        recordType,
+				Value:             value,
+				Weight:            set.Weight,
+				Reps:              set.Reps,
+				WorkoutID:         set.WorkoutID,
+				WorkoutExerciseID: set.WorkoutExerciseID,
+				AchievedAt:        set.WorkoutDate,
+			}
+		}
+
+		if set.Weight > 0 {
+			improve(models.RecordTypeMaxWeight, newRecord(models.RecordTypeMaxWeight, set.Weight))
+			improve(models.RecordTypeEstimated1RM, newRecord(models.RecordTypeEstimated1RM, utils.EstimateOneRepMax(set.Weight, set.Reps)))
+		}
+		improve(fmt.Sprintf("%s:%.1f", models.RecordTypeRepsAtWeight, set.Weight), newRecord(models.RecordTypeRepsAtWeight, float64(set.Reps)))
+
+		volume += set.Weight * float64(set.Reps)
+		if i == len(sets)-1 || sets[i+1].WorkoutID != set.WorkoutID {
+			if volume > 0 {
+				record := newRecord(models.RecordTypeMaxVolume, volume)
+				record.Weight = 0
+				record.Reps = 0
+				improve(models.RecordTypeMaxVolume, record)
+			}
+			volume = 0
+		}
+	}
+
+	for _, i := range current {
+		records[i].IsCurrent = true
+	}
+
+	return records
+}
+
+func personalRecordKey(record models.PersonalRecord) string {
+	return fmt.Sprintf("%s:%.1f:%.2f:%d", record.RecordType, record.Weight, record.Value, record.WorkoutExerciseID)
+}
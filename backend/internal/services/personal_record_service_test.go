@@ -0,0 +1,153 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePersonalRecords(t *testing.T) {
+	day1 := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		sets []models.RecordSet
+		want []string
+	}{
+		{
+			name: "no sets",
+			sets: nil,
+			want: nil,
+		},
+		{
+			name: "single set sets every record",
+			sets: []models.RecordSet{
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 100, Reps: 5},
+			},
+			want: []string{
+				"max_weight 100.0 100.00 current",
+				"estimated_1rm 100.0 116.67 current",
+				"reps_at_weight 100.0 5.00 current",
+				"max_volume 0.0 500.00 current",
+			},
+		},
+		{
+			name: "heavier set moves old records to history",
+			sets: []models.RecordSet{
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 100, Reps: 5},
+				{WorkoutID: 2, WorkoutDate: day2, WorkoutExerciseID: 20, Weight: 110, Reps: 3},
+			},
+			want: []string{
+				"max_weight 100.0 100.00 history",
+				"estimated_1rm 100.0 116.67 history",
+				"reps_at_weight 100.0 5.00 current",
+				"max_volume 0.0 500.00 current",
+				"max_weight 110.0 110.00 current",
+				"estimated_1rm 110.0 121.00 current",
+				"reps_at_weight 110.0 3.00 current",
+			},
+		},
+		{
+			name: "equal result does not set a new record",
+			sets: []models.RecordSet{
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 60, Reps: 1},
+				{WorkoutID: 2, WorkoutDate: day2, WorkoutExerciseID: 20, Weight: 60, Reps: 1},
+			},
+			want: []string{
+				"max_weight 60.0 60.00 current",
+				"estimated_1rm 60.0 60.00 current",
+				"reps_at_weight 60.0 1.00 current",
+				"max_volume 0.0 60.00 current",
+			},
+		},
+		{
+			name: "bodyweight sets only track reps",
+			sets: []models.RecordSet{
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 0, Reps: 12},
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 0, Reps: 15},
+			},
+			want: []string{
+				"reps_at_weight 0.0 12.00 history",
+				"reps_at_weight 0.0 15.00 current",
+			},
+		},
+		{
+			name: "volume adds up sets of one workout",
+			sets: []models.RecordSet{
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 50, Reps: 10},
+				{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 50, Reps: 10},
+				{WorkoutID: 2, WorkoutDate: day2, WorkoutExerciseID: 20, Weight: 50, Reps: 8},
+			},
+			want: []string{
+				"max_weight 50.0 50.00 current",
+				"estimated_1rm 50.0 66.67 current",
+				"reps_at_weight 50.0 10.00 current",
+				"max_volume 0.0 1000.00 current",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, record := range computePersonalRecords(tt.sets) {
+				state := "history"
+				if record.IsCurrent {
+					state = "current"
+				}
+				got = append(got, fmt.Sprintf("%s %.1f %.2f %s", record.RecordType, record.Weight, record.Value, state))
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComputePersonalRecordsAchievedAt(t *testing.T) {
+	day1 := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	records := computePersonalRecords([]models.RecordSet{
+		{WorkoutID: 1, WorkoutDate: day1, WorkoutExerciseID: 10, Weight: 80, Reps: 5},
+		{WorkoutID: 2, WorkoutDate: day2, WorkoutExerciseID: 20, Weight: 90, Reps: 5},
+	})
+
+	for _, record := range records {
+		if record.RecordType == models.RecordTypeMaxWeight && record.IsCurrent {
+			assert.Equal(t, day2, record.AchievedAt)
+			assert.Equal(t, 2, record.WorkoutID)
+			assert.Equal(t, 20, record.WorkoutExerciseID)
+		}
+	}
+}
+
+func TestUpdateRecordsReturnsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewPersonalRecordService(repository.NewPersonalRecordRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM PersonalRecords pr`)).
+		WithArgs(2, 4).
+		WillReturnError(context.DeadlineExceeded)
+
+	records, err := service.UpdateRecords(context.Background(), 2, 4, 10)
+	assert.Nil(t, records)
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusGatewayTimeout, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,649 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type ProgramService struct {
+	programRepo  *repository.ProgramRepository
+	templateRepo *repository.WorkoutTemplateRepository
+	userRepo     *repository.UserRepository
+}
+
+func NewProgramService(
+	programRepo *repository.ProgramRepository,
+	templateRepo *repository.WorkoutTemplateRepository,
+	userRepo *repository.UserRepository,
+) *ProgramService {
+	return &ProgramService{
+		programRepo:  programRepo,
+		templateRepo: templateRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// AddClient invites the user with the email. The trainer can assign programs
+// and read the data of the client only after the client accepts it.
+func (s *ProgramService) AddClient(ctx context.Context, req *models.TrainerClientRequest) (*models.TrainerClient, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	client, err := s.userRepo.GetUserByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, programError(err, "User not found", "Failed to add client")
+	}
+
+	if client.ID == trainerID {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Trainer cannot be own client",
+		}
+	}
+
+	trainerClient := &models.TrainerClient{
+		TrainerID: trainerID,
+		ClientID:  client.ID,
+		Username:  client.Username,
+		Email:     client.Email,
+	}
+
+	if err := s.programRepo.AddClient(ctx, trainerClient); err != nil {
+		return nil, programError(err, "User not found", "Failed to add client")
+	}
+
+	return trainerClient, nil
+}
+
+func (s *ProgramService) GetClients(ctx context.Context) (*[]models.TrainerClient, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	clients, err := s.programRepo.GetClients(ctx, trainerID)
+	if err != nil {
+		return nil, programError(err, "Clients not found", "Failed to get clients")
+	}
+
+	if clients == nil || len(*clients) == 0 {
+		log.Println("Clients not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Clients not found",
+		}
+	}
+
+	return clients, nil
+}
+
+func (s *ProgramService) RemoveClient(ctx context.Context, clientID int) error {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.RemoveClient(ctx, trainerID, clientID)
+	if err != nil {
+		return programError(err, "Client not found", "Failed to remove client")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Client not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Client not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) GetInvitations(ctx context.Context) ([]models.TrainerInvitation, error) {
+	clientID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	invitations, err := s.programRepo.GetInvitations(ctx, clientID)
+	if err != nil {
+		return nil, programError(err, "Invitations not found", "Failed to get invitations")
+	}
+
+	return invitations, nil
+}
+
+func (s *ProgramService) AcceptInvitation(ctx context.Context, invitationID int) error {
+	clientID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.AcceptInvitation(ctx, clientID, invitationID)
+	if err != nil {
+		return programError(err, "Invitation not found", "Failed to accept invitation")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Invitation not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Invitation not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) DeclineInvitation(ctx context.Context, invitationID int) error {
+	clientID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.DeclineInvitation(ctx, clientID, invitationID)
+	if err != nil {
+		return programError(err, "Invitation not found", "Failed to decline invitation")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Invitation not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Invitation not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) LeaveTrainer(ctx context.Context, trainerID int) error {
+	clientID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.LeaveTrainer(ctx, clientID, trainerID)
+	if err != nil {
+		return programError(err, "Trainer not found", "Failed to leave trainer")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Trainer not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Trainer not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) CreateProgram(ctx context.Context, req *models.ProgramRequest) (*models.Program, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	program, err := s.newProgram(ctx, trainerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.programRepo.CreateProgram(ctx, program); err != nil {
+		return nil, programError(err, "Program not found", "Failed to create program")
+	}
+
+	return program, nil
+}
+
+func (s *ProgramService) GetPrograms(ctx context.Context) (*[]models.Program, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	programs, err := s.programRepo.GetProgramsByTrainerID(ctx, trainerID)
+	if err != nil {
+		return nil, programError(err, "Programs not found", "Failed to get programs")
+	}
+
+	if programs == nil || len(*programs) == 0 {
+		log.Println("Programs not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Programs not found",
+		}
+	}
+
+	return programs, nil
+}
+
+func (s *ProgramService) GetProgram(ctx context.Context, programID int) (*models.Program, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	program, err := s.programRepo.GetProgramByTrainerID(ctx, trainerID, programID)
+	if err != nil {
+		return nil, programError(err, "Program not found", "Failed to get program")
+	}
+
+	return program, nil
+}
+
+func (s *ProgramService) UpdateProgram(ctx context.Context, programID int, req *models.ProgramRequest) (*models.Program, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	program, err := s.newProgram(ctx, trainerID, req)
+	if err != nil {
+		return nil, err
+	}
+	program.ID = programID
+
+	if err := s.programRepo.UpdateProgram(ctx, program); err != nil {
+		return nil, programError(err, "Program not found", "Failed to update program")
+	}
+
+	return program, nil
+}
+
+func (s *ProgramService) DeleteProgram(ctx context.Context, programID int) error {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.DeleteProgram(ctx, trainerID, programID)
+	if err != nil {
+		return programError(err, "Program not found", "Failed to delete program")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Program not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Program not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) AssignProgram(ctx context.Context, programID int, req *models.ProgramAssignmentRequest) (*models.ProgramAssignment, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if req.StartDate.IsZero() {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Start date is required",
+		}
+	}
+
+	if err := s.checkClient(ctx, trainerID, req.ClientID); err != nil {
+		return nil, err
+	}
+
+	program, err := s.programRepo.GetProgramByTrainerID(ctx, trainerID, programID)
+	if err != nil {
+		return nil, programError(err, "Program not found", "Failed to assign program")
+	}
+
+	assignment := &models.ProgramAssignment{
+		ProgramID:   program.ID,
+		ProgramName: program.Name,
+		TrainerID:   trainerID,
+		ClientID:    req.ClientID,
+		StartDate:   req.StartDate,
+	}
+
+	if err := s.programRepo.AssignProgram(ctx, assignment, program); err != nil {
+		return nil, programError(err, "Program not found", "Failed to assign program")
+	}
+
+	return assignment, nil
+}
+
+func (s *ProgramService) GetClientAssignments(ctx context.Context, clientID int) (*[]models.ProgramAssignment, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if err := s.checkClient(ctx, trainerID, clientID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.programRepo.GetAssignmentsByTrainerID(ctx, trainerID, clientID)
+	if err != nil {
+		return nil, programError(err, "Assignments not found", "Failed to get assignments")
+	}
+
+	if assignments == nil || len(*assignments) == 0 {
+		log.Println("Assignments not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Assignments not found",
+		}
+	}
+
+	return assignments, nil
+}
+
+func (s *ProgramService) GetClientSchedule(ctx context.Context, assignmentID int) (*models.ProgramAssignment, error) {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	assignment, err := s.programRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, programError(err, "Assignment not found", "Failed to get schedule")
+	}
+
+	if assignment.TrainerID != trainerID {
+		log.Println("Assignment belongs to another trainer")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Assignment not found",
+		}
+	}
+
+	if err := s.checkClient(ctx, trainerID, assignment.ClientID); err != nil {
+		return nil, err
+	}
+
+	return s.attachSchedule(ctx, assignment)
+}
+
+func (s *ProgramService) CancelAssignment(ctx context.Context, assignmentID int) error {
+	trainerID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.programRepo.CancelAssignment(ctx, trainerID, assignmentID)
+	if err != nil {
+		return programError(err, "Assignment not found", "Failed to cancel assignment")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Assignment not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Assignment not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) GetMyAssignments(ctx context.Context) (*[]models.ProgramAssignment, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	assignments, err := s.programRepo.GetAssignmentsByClientID(ctx, userID)
+	if err != nil {
+		return nil, programError(err, "Programs not found", "Failed to get programs")
+	}
+
+	if assignments == nil || len(*assignments) == 0 {
+		log.Println("Programs not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Programs not found",
+		}
+	}
+
+	return assignments, nil
+}
+
+func (s *ProgramService) GetMySchedule(ctx context.Context, assignmentID int) (*models.ProgramAssignment, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	assignment, err := s.programRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, programError(err, "Program not found", "Failed to get schedule")
+	}
+
+	if assignment.ClientID != userID {
+		log.Println("Assignment belongs to another user")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Program not found",
+		}
+	}
+
+	return s.attachSchedule(ctx, assignment)
+}
+
+func (s *ProgramService) attachSchedule(ctx context.Context, assignment *models.ProgramAssignment) (*models.ProgramAssignment, error) {
+	scheduledWorkouts, err := s.programRepo.GetScheduledWorkouts(ctx, assignment.ID)
+	if err != nil {
+		return nil, programError(err, "Schedule not found", "Failed to get schedule")
+	}
+
+	if scheduledWorkouts != nil {
+		assignment.ScheduledWorkouts = *scheduledWorkouts
+	}
+
+	return assignment, nil
+}
+
+func (s *ProgramService) checkClient(ctx context.Context, trainerID, clientID int) error {
+	isClient, err := s.programRepo.IsTrainerClient(ctx, trainerID, clientID)
+	if err != nil {
+		return programError(err, "Client not found", "Failed to check client")
+	}
+
+	if !isClient {
+		log.Println("User is not a client of trainer")
+		return &apperrors.AppError{
+			Code:    http.StatusForbidden,
+			Message: "User is not your client",
+		}
+	}
+
+	return nil
+}
+
+func (s *ProgramService) newProgram(ctx context.Context, trainerID int, req *models.ProgramRequest) (*models.Program, error) {
+	if err := validateProgramRequest(req); err != nil {
+		return nil, err
+	}
+
+	program := &models.Program{
+		TrainerID:   trainerID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Weeks:       req.Weeks,
+	}
+
+	templateNames := make(map[int]string)
+	for _, workout := range req.Workouts {
+		if _, ok := templateNames[workout.TemplateID]; !ok {
+			template, err := s.templateRepo.GetTemplateByUserID(ctx, trainerID, workout.TemplateID)
+			if template == nil || err != nil {
+				log.Println("Template not found:", err)
+				return nil, &apperrors.AppError{
+					Code:    http.StatusBadRequest,
+					Message: "Incorrect template id",
+				}
+			}
+			templateNames[workout.TemplateID] = template.Name
+		}
+
+		program.Workouts = append(program.Workouts, models.ProgramWorkout{
+			TemplateID:   workout.TemplateID,
+			TemplateName: templateNames[workout.TemplateID],
+			Week:         workout.Week,
+			Day:          workout.Day,
+			Notes:        workout.Notes,
+		})
+	}
+
+	return program, nil
+}
+
+func validateProgramRequest(req *models.ProgramRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Program name is required",
+		}
+	}
+
+	if req.Weeks < 1 {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Program must last at least one week",
+		}
+	}
+
+	if len(req.Workouts) == 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Program must contain at least one workout",
+		}
+	}
+
+	for _, workout := range req.Workouts {
+		switch {
+		case workout.TemplateID < 1:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Incorrect template id",
+			}
+
+		case workout.Week < 1 || workout.Week > req.Weeks:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Workout week is out of program range",
+			}
+
+		case workout.Day < 1 || workout.Day > 7:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Workout day must be between 1 and 7",
+			}
+		}
+	}
+
+	return nil
+}
+
+func programError(err error, notFoundMessage, message string) error {
+	var pgErr *pq.Error
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation:
+		log.Println("Unique violation:", pgErr)
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Record already exists",
+		}
+
+	case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation:
+		log.Println("Foreign key violation:", pgErr)
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Incorrect reference id",
+		}
+
+	default:
+		return apperrors.WrapNotFound(err, notFoundMessage, message)
+	}
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/clients"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// maxIngredientNameLength matches the RecipeIngredients.name column.
+const maxIngredientNameLength = 100
+
+type RecipeService struct {
+	recipeRepo        *repository.RecipeRepository
+	customFoodRepo    *repository.CustomFoodRepository
+	nutritionProvider clients.NutritionProvider
+}
+
+func NewRecipeService(recipeRepo *repository.RecipeRepository, customFoodRepo *repository.CustomFoodRepository, nutritionProvider clients.NutritionProvider) *RecipeService {
+	return &RecipeService{
+		recipeRepo:        recipeRepo,
+		customFoodRepo:    customFoodRepo,
+		nutritionProvider: nutritionProvider,
+	}
+}
+
+func (s *RecipeService) CreateRecipe(ctx context.Context, req *models.RecipeRequest) (*models.Recipe, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	recipe, err := s.newRecipe(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recipeRepo.CreateRecipe(ctx, recipe); err != nil {
+		return nil, recipeError(err, "Failed to create recipe")
+	}
+
+	setRecipeNutrition(recipe)
+
+	return recipe, nil
+}
+
+func (s *RecipeService) GetRecipes(ctx context.Context) ([]models.Recipe, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	recipes, err := s.recipeRepo.GetRecipesByUserID(ctx, userID)
+	if err != nil {
+		return nil, recipeError(err, "Failed to get recipes")
+	}
+
+	if len(recipes) == 0 {
+		log.Println("Recipes not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Recipes not found",
+		}
+	}
+
+	for i := range recipes {
+		setRecipeNutrition(&recipes[i])
+	}
+
+	return recipes, nil
+}
+
+func (s *RecipeService) GetRecipe(ctx context.Context, recipeID int) (*models.Recipe, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	recipe, err := s.recipeRepo.GetRecipeByUserID(ctx, userID, recipeID)
+	if err != nil {
+		return nil, recipeError(err, "Failed to get recipe")
+	}
+
+	setRecipeNutrition(recipe)
+
+	return recipe, nil
+}
+
+// UpdateRecipe recomputes the ingredients from the current foods. Entries
+// already logged from the recipe keep their nutrients.
+func (s *RecipeService) UpdateRecipe(ctx context.Context, recipeID int, req *models.RecipeRequest) (*models.Recipe, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	recipe, err := s.newRecipe(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	recipe.ID = recipeID
+
+	if err := s.recipeRepo.UpdateRecipe(ctx, recipe); err != nil {
+		return nil, recipeError(err, "Failed to update recipe")
+	}
+
+	setRecipeNutrition(recipe)
+
+	return recipe, nil
+}
+
+func (s *RecipeService) DeleteRecipe(ctx context.Context, recipeID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.recipeRepo.DeleteRecipe(ctx, userID, recipeID)
+	if err != nil {
+		return recipeError(err, "Failed to delete recipe")
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Recipe not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Recipe not found",
+		}
+	}
+
+	return nil
+}
+
+func (s *RecipeService) newRecipe(ctx context.Context, userID int, req *models.RecipeRequest) (*models.Recipe, error) {
+	if err := validateRecipeRequest(req); err != nil {
+		return nil, err
+	}
+
+	recipe := &models.Recipe{
+		UserID:   userID,
+		Name:     strings.TrimSpace(req.Name),
+		Servings: req.Servings,
+		Notes:    req.Notes,
+	}
+
+	for _, ingredientReq := range req.Ingredients {
+		ingredient, err := s.newRecipeIngredient(ctx, userID, ingredientReq)
+		if err != nil {
+			return nil, err
+		}
+
+		recipe.Ingredients = append(recipe.Ingredients, *ingredient)
+	}
+
+	return recipe, nil
+}
+
+// newRecipeIngredient copies the nutrients of the ingredient weight from the
+// custom food or the nutrition provider.
+func (s *RecipeService) newRecipeIngredient(ctx context.Context, userID int, req models.RecipeIngredientRequest) (*models.RecipeIngredient, error) {
+	ingredient := &models.RecipeIngredient{WeightGrams: req.WeightGrams}
+
+	switch {
+	case req.CustomFoodID != 0:
+		customFood, err := s.customFoodRepo.GetCustomFoodByUserID(ctx, userID, req.CustomFoodID)
+		if err != nil {
+			return nil, apperrors.WrapNotFound(err, "Custom food not found", "Failed to get custom food")
+		}
+
+		if customFood.Per100g == nil {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Custom food has no nutrition per 100g",
+			}
+		}
+
+		ingredient.CustomFoodID = &customFood.ID
+		ingredient.Name = customFood.Name
+		ingredient.Nutrients = scaleNutrients(*customFood.Per100g, req.WeightGrams/100)
+
+	case req.ProviderFoodID != "":
+		food, err := s.nutritionProvider.GetFood(ctx, req.ProviderFoodID)
+		if err != nil {
+			return nil, nutritionProviderError(err)
+		}
+
+		if food.ServingWeightGrams <= 0 {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Weight of the provider food is unknown",
+			}
+		}
+
+		ingredient.ProviderFoodID = food.ID
+		ingredient.Name = truncateIngredientName(food.Name)
+		ingredient.Nutrients = scaleNutrients(providerNutrients(*food), req.WeightGrams/food.ServingWeightGrams)
+
+	default:
+		foods, err := s.nutritionProvider.Parse(ctx, fmt.Sprintf("%gg %s", req.WeightGrams, strings.TrimSpace(req.ProductName)))
+		if err != nil {
+			return nil, nutritionProviderError(err)
+		}
+
+		// The weight is sent with the first product only, so a name that the
+		// provider splits into several products cannot be weighed correctly.
+		if len(foods) != 1 {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Ingredient must be a single product",
+			}
+		}
+
+		ingredient.ProviderFoodID = foods[0].ID
+		ingredient.Name = truncateIngredientName(foods[0].Name)
+		ingredient.Nutrients = scaleNutrients(providerNutrients(foods[0]), 1)
+	}
+
+	return ingredient, nil
+}
+
+func recipeError(err error, message string) error {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation {
+		log.Println("Foreign key violation:", pgErr)
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Incorrect custom food id",
+		}
+	}
+
+	return apperrors.WrapNotFound(err, "Recipe not found", message)
+}
+
+func validateRecipeRequest(req *models.RecipeRequest) error {
+	name := strings.TrimSpace(req.Name)
+
+	switch {
+	case name == "" || len(name) > 100:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Name is required and must be at most 100 characters",
+		}
+
+	case req.Servings <= 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Servings must be greater than zero",
+		}
+
+	case len(req.Ingredients) == 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Recipe must contain at least one ingredient",
+		}
+	}
+
+	for _, ingredient := range req.Ingredients {
+		sources := 0
+		if ingredient.CustomFoodID != 0 {
+			sources++
+		}
+		if ingredient.ProviderFoodID != "" {
+			sources++
+		}
+		if strings.TrimSpace(ingredient.ProductName) != "" {
+			sources++
+		}
+
+		switch {
+		case sources != 1 || ingredient.CustomFoodID < 0:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Ingredient must have one of custom_food_id, provider_food_id or product_name",
+			}
+
+		case ingredient.WeightGrams <= 0:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Ingredient weight must be greater than zero",
+			}
+		}
+	}
+
+	return nil
+}
+
+func setRecipeNutrition(recipe *models.Recipe) {
+	recipe.TotalWeightGrams = roundNutrient(recipe.TotalWeightGrams)
+	recipe.Total = scaleNutrients(recipe.Total, 1)
+	recipe.PerServing = scaleNutrients(recipe.Total, 1/recipe.Servings)
+}
+
+func providerNutrients(food models.NutritionFood) models.Nutrients {
+	return models.Nutrients{
+		Calories: food.Calories,
+		Protein:  food.Protein,
+		Carbs:    food.Carbs,
+		Fat:      food.Fat,
+	}
+}
+
+// truncateIngredientName keeps provider names within the name column.
+func truncateIngredientName(name string) string {
+	if runes := []rune(name); len(runes) > maxIngredientNameLength {
+		return strings.TrimSpace(string(runes[:maxIngredientNameLength]))
+	}
+
+	return name
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/clients"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRecipe(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	service := NewRecipeService(repository.NewRecipeRepository(sqlxDB), repository.NewCustomFoodRepository(sqlxDB), provider)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Recipes`)).
+		WithArgs(2, "Rice with eggs", 2.0, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "is_active"}).AddRow(3, now, now, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO RecipeIngredients`)).
+		WithArgs(3, 1, nil, "fake-1", "rice", 300.0, 390.0, 8.1, 84.6, 0.9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO RecipeIngredients`)).
+		WithArgs(3, 2, nil, "fake-2", "egg", 100.0, 143.0, 12.6, 0.8, 9.6).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	mock.ExpectCommit()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	recipe, err := service.CreateRecipe(ctx, &models.RecipeRequest{
+		Name:     "Rice with eggs",
+		Servings: 2,
+		Ingredients: []models.RecipeIngredientRequest{
+			{ProviderFoodID: "fake-1", WeightGrams: 300},
+			{ProductName: "egg", WeightGrams: 100},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 400.0, recipe.TotalWeightGrams)
+	assert.Equal(t, models.Nutrients{Calories: 266.5, Protein: 10.35, Carbs: 42.7, Fat: 5.25}, recipe.PerServing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRecipeSeveralProductsInOneIngredient(t *testing.T) {
+	provider, err := clients.NewFakeNutritionProvider("")
+	assert.NoError(t, err)
+
+	service := NewRecipeService(nil, nil, provider)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err = service.CreateRecipe(ctx, &models.RecipeRequest{
+		Name:        "Rice with eggs",
+		Servings:    2,
+		Ingredients: []models.RecipeIngredientRequest{{ProductName: "rice and egg", WeightGrams: 300}},
+	})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+type longNameProvider struct {
+	clients.NutritionProvider
+}
+
+func (p longNameProvider) GetFood(ctx context.Context, id string) (*models.NutritionFood, error) {
+	return &models.NutritionFood{ID: id, Name: strings.Repeat("я", 120), ServingWeightGrams: 100, Calories: 50}, nil
+}
+
+func TestNewRecipeIngredientTruncatesProviderName(t *testing.T) {
+	service := NewRecipeService(nil, nil, longNameProvider{})
+
+	ingredient, err := service.newRecipeIngredient(context.Background(), 2, models.RecipeIngredientRequest{ProviderFoodID: "long-1", WeightGrams: 200})
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("я", 100), ingredient.Name)
+	assert.Equal(t, 100.0, ingredient.Nutrients.Calories)
+}
+
+func TestAddFoodRecipeServings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	service := NewFoodService(nil, repository.NewFoodRepository(sqlxDB), nil, repository.NewRecipeRepository(sqlxDB))
+
+	now := time.Now()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM Recipes`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "servings", "notes", "created_at", "updated_at", "is_active"}).
+			AddRow(3, 2, "Rice with eggs", 2.0, "", now, now, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM RecipeIngredients`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipe_id", "position", "custom_food_id", "provider_food_id", "name",
+			"weight_grams", "calories", "protein", "carbs", "fat"}).
+			AddRow(10, 3, 1, nil, "fake-1", "rice", 300.0, 390.0, 8.1, 84.6, 0.9).
+			AddRow(11, 3, 2, nil, "fake-2", "egg", 100.0, 143.0, 12.6, 0.8, 9.6))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO Foods`))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO Foods`)).
+		WithArgs(2, date, "Rice with eggs", 1.5, "serving", 300.0, 399.75, 15.52, 64.05, 7.88, "snack", "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	foods, err := service.AddFood(ctx, &models.FoodRequest{
+		Date:  "2024-05-01",
+		Items: []models.FoodRequestItem{{RecipeID: 3, Quantity: 1.5, Unit: "servings"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, *foods, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	userPermissionsCacheKey = "user_permissions:%d"
+	userPermissionsCacheTTL = 10 * time.Minute
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+type RoleService struct {
+	roleRepo *repository.RoleRepository
+	redis    *redis.Client
+}
+
+func NewRoleService(roleRepo *repository.RoleRepository, redis *redis.Client) *RoleService {
+	return &RoleService{
+		roleRepo: roleRepo,
+		redis:    redis,
+	}
+}
+
+func (s *RoleService) GetRoles(ctx context.Context) (*[]models.Role, error) {
+	roles, err := s.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get roles")
+	}
+
+	return roles, nil
+}
+
+func (s *RoleService) CreateRole(ctx context.Context, req *models.RoleRequest) (*models.Role, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(name) {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Role name must be 2-50 characters of latin letters, digits, '-' or '_'",
+		}
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: []string{},
+	}
+
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Role already exists",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to create role")
+	}
+
+	return role, nil
+}
+
+func (s *RoleService) GetPermissions(ctx context.Context) (*[]models.Permission, error) {
+	permissions, err := s.roleRepo.GetPermissions(ctx)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get permissions")
+	}
+
+	return permissions, nil
+}
+
+// SetRolePermissions replaces the permissions of the role and drops the
+// cached permissions of every user having it.
+func (s *RoleService) SetRolePermissions(ctx context.Context, roleID int, req *models.RolePermissionsRequest) (*models.Role, error) {
+	role, err := s.roleRepo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Role not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get role")
+	}
+
+	known, err := s.roleRepo.GetPermissions(ctx)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get permissions")
+	}
+
+	permissions := []string{}
+	for _, name := range req.Permissions {
+		if !slices.ContainsFunc(*known, func(p models.Permission) bool { return p.Name == name }) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Unknown permission: " + name,
+			}
+		}
+		if !slices.Contains(permissions, name) {
+			permissions = append(permissions, name)
+		}
+	}
+
+	if err := s.roleRepo.SetRolePermissions(ctx, roleID, permissions); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to set role permissions")
+	}
+
+	userIDs, err := s.roleRepo.GetRoleUserIDs(ctx, roleID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get role users")
+	}
+	s.InvalidateUserPermissions(ctx, userIDs...)
+
+	slices.Sort(permissions)
+	role.Permissions = permissions
+	return role, nil
+}
+
+// GetUserPermissions returns the permissions granted to the user by all of
+// their roles. The result is cached in Redis until a role changes.
+func (s *RoleService) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	key := fmt.Sprintf(userPermissionsCacheKey, userID)
+
+	val, err := s.redis.Get(ctx, key).Result()
+	if err == nil {
+		var permissions []string
+		if err := json.Unmarshal([]byte(val), &permissions); err == nil {
+			return permissions, nil
+		}
+		log.Println("error deserializing data from cache:", err)
+	} else if err != redis.Nil {
+		log.Println("error getting data from Redis:", err)
+	}
+
+	permissions, err := s.roleRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to get user permissions")
+	}
+
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		log.Println("cache serialization error", err)
+	} else if err := s.redis.Set(ctx, key, data, userPermissionsCacheTTL).Err(); err != nil {
+		log.Println("Failed to cache user permissions:", err)
+	}
+
+	return permissions, nil
+}
+
+func (s *RoleService) GetCurrentUserPermissions(ctx context.Context) ([]string, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	return s.GetUserPermissions(ctx, userID)
+}
+
+func (s *RoleService) HasPermission(ctx context.Context, userID int, permission string) (bool, error) {
+	permissions, err := s.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(permissions, permission), nil
+}
+
+func (s *RoleService) InvalidateUserPermissions(ctx context.Context, userIDs ...int) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, fmt.Sprintf(userPermissionsCacheKey, userID))
+	}
+
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Println("Failed to invalidate user permissions:", err)
+	}
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"backend/internal/auth"
 	"backend/internal/clients"
+	"backend/internal/mail"
 	"backend/internal/oauth"
 	"backend/internal/repository"
+	"backend/internal/security"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -13,24 +15,66 @@ type Services struct {
 	ExerciseService        *ExerciseService
 	CategoryService        *CategoryService
 	UserService            *UserService
+	AccountService         *AccountService
+	DataExportService      *DataExportService
+	RoleService            *RoleService
+	AdminService           *AdminService
 	AuthService            *AuthService
+	TwoFactorService       *TwoFactorService
+	APITokenService        *APITokenService
 	HealthService          *HealthService
 	WorkoutSerivce         *WorkoutSerivce
 	WorkoutExerciseSerivce *WorkoutExerciseSerivce
+	WorkoutSetService      *WorkoutSetService
+	WorkoutSessionService  *WorkoutSessionService
+	WorkoutTemplateService *WorkoutTemplateService
+	ProgramService         *ProgramService
+	PersonalRecordService  *PersonalRecordService
+	AnalyticsService       *AnalyticsService
 	FoodService            *FoodService
+	CustomFoodService      *CustomFoodService
+	RecipeService          *RecipeService
 	NutritionService       *NutritionService
 }
 
-func InitServices(repos *repository.Repositories, redis *redis.Client, jwtManager *auth.JWTManager, clients *clients.Clients, oauth *oauth.Oauth) *Services {
+func InitServices(
+	repos *repository.Repositories,
+	redis *redis.Client,
+	jwtManager *auth.JWTManager,
+	clients *clients.Clients,
+	oauth *oauth.Oauth,
+	mailer *mail.Mailer,
+	loginThrottler *security.LoginThrottler,
+) *Services {
+	personalRecordService := NewPersonalRecordService(repos.PersonalRecordRepo, repos.ExerciseRepo, repos.WorkoutExerciseRepo)
+	workoutSetService := NewWorkoutSetService(repos.WorkoutRepo, repos.WorkoutExerciseRepo, repos.WorkoutSetRepo, personalRecordService)
+	twoFactorService := NewTwoFactorService(repos.TwoFactorRepo, repos.UserRepo, redis, loginThrottler)
+	roleService := NewRoleService(repos.RoleRepo, redis)
+	authService := NewAuthService(repos.UserRepo, repos.SessionRepo, repos.UserTokenRepo, twoFactorService, jwtManager, redis, mailer, loginThrottler)
+
 	return &Services{
 		ExerciseService:        NewExerciseService(repos.ExerciseRepo, repos.CategoryRepo, redis),
 		CategoryService:        NewCategoryService(repos.CategoryRepo, redis),
-		UserService:            NewUserService(repos.UserRepo, repos.RoleRepo),
-		AuthService:            NewAuthService(repos.UserRepo, jwtManager),
+		UserService:            NewUserService(repos.UserRepo, repos.RoleRepo, roleService),
+		AccountService:         NewAccountService(repos.AccountRepo, repos.UserRepo, authService),
+		DataExportService:      NewDataExportService(repos.DataExportRepo, repos.UserRepo),
+		RoleService:            roleService,
+		AdminService:           NewAdminService(repos.UserRepo, repos.SessionRepo, authService),
+		AuthService:            authService,
+		TwoFactorService:       twoFactorService,
+		APITokenService:        NewAPITokenService(repos.APITokenRepo),
 		HealthService:          NewHealthService(repos.DBHeathRepo, redis),
-		WorkoutSerivce:         NewWorkoutService(repos.WorkoutRepo),
-		WorkoutExerciseSerivce: NewWorkoutExerciseService(repos.WorkoutRepo, repos.WorkoutExerciseRepo, repos.ExerciseRepo),
-		FoodService:            NewFoodService(clients.NutritionixClient, repos.FoodRepository),
+		WorkoutSerivce:         NewWorkoutService(repos.WorkoutRepo, repos.WorkoutTemplateRepo, personalRecordService),
+		WorkoutExerciseSerivce: NewWorkoutExerciseService(repos.WorkoutRepo, repos.WorkoutExerciseRepo, repos.ExerciseRepo, repos.WorkoutSetRepo, personalRecordService),
+		WorkoutSetService:      workoutSetService,
+		WorkoutSessionService:  NewWorkoutSessionService(repos.WorkoutRepo, workoutSetService, redis),
+		WorkoutTemplateService: NewWorkoutTemplateService(repos.WorkoutTemplateRepo, repos.WorkoutRepo),
+		ProgramService:         NewProgramService(repos.ProgramRepo, repos.WorkoutTemplateRepo, repos.UserRepo),
+		PersonalRecordService:  personalRecordService,
+		AnalyticsService:       NewAnalyticsService(repos.AnalyticsRepo, repos.ExerciseRepo, repos.ProgramRepo),
+		FoodService:            NewFoodService(clients.NutritionProvider, repos.FoodRepository, repos.CustomFoodRepo, repos.RecipeRepo),
+		CustomFoodService:      NewCustomFoodService(repos.CustomFoodRepo),
+		RecipeService:          NewRecipeService(repos.RecipeRepo, repos.CustomFoodRepo, clients.NutritionProvider),
 		NutritionService:       NewNutritionService(repos.FatSecretAuthRepository, oauth.FatSecretAuthClient),
 	}
 }
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/auth"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"backend/internal/security"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	recoveryCodesCount = 10
+	usedTOTPKeyFmt     = "totp_used:%d:%d"
+)
+
+type TwoFactorService struct {
+	twoFactorRepo  *repository.TwoFactorRepository
+	userRepo       *repository.UserRepository
+	redis          *redis.Client
+	loginThrottler *security.LoginThrottler
+}
+
+func NewTwoFactorService(twoFactorRepo *repository.TwoFactorRepository, userRepo *repository.UserRepository, redis *redis.Client, loginThrottler *security.LoginThrottler) *TwoFactorService {
+	return &TwoFactorService{
+		twoFactorRepo:  twoFactorRepo,
+		userRepo:       userRepo,
+		redis:          redis,
+		loginThrottler: loginThrottler,
+	}
+}
+
+func (s *TwoFactorService) GetStatus(ctx context.Context) (*models.TwoFactorStatusResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	twoFactor, err := s.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.TwoFactorStatusResponse{
+		Enabled:   twoFactor.EnabledAt != nil,
+		EnabledAt: twoFactor.EnabledAt,
+	}
+
+	if response.Enabled {
+		if response.RecoveryCodesRemaining, err = s.twoFactorRepo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, apperrors.Wrap(err, "Failed to get two-factor status")
+		}
+	}
+
+	return response, nil
+}
+
+// Setup generates a new secret pending confirmation. Calling it again before
+// Enable replaces the secret, e.g. when the QR code was not scanned.
+func (s *TwoFactorService) Setup(ctx context.Context) (*models.TwoFactorSetupResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to setup two-factor authentication")
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		log.Println("Failed to generate TOTP secret:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to setup two-factor authentication",
+		}
+	}
+
+	updated, err := s.twoFactorRepo.SetSecret(ctx, userID, secret)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to setup two-factor authentication")
+	}
+
+	if updated == 0 {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Two-factor authentication is already enabled",
+		}
+	}
+
+	return &models.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURI: auth.TOTPURI(secret, user.Email),
+	}, nil
+}
+
+// Enable confirms the pending secret with a code from the authenticator app
+// and returns the recovery codes, they are not shown again.
+func (s *TwoFactorService) Enable(ctx context.Context, code, ip string) (*models.RecoveryCodesResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	twoFactor, err := s.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor.EnabledAt != nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Two-factor authentication is already enabled",
+		}
+	}
+
+	if twoFactor.Secret == nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Two-factor authentication setup is not started",
+		}
+	}
+
+	email, err := s.checkCodeThrottle(ctx, userID, ip, "Failed to enable two-factor authentication")
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := s.verifyTOTP(ctx, twoFactor, code)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to enable two-factor authentication")
+	}
+
+	if !valid {
+		return nil, s.codeFailed(ctx, email, ip, "Failed to enable two-factor authentication")
+	}
+
+	if err := s.loginThrottler.Reset(ctx, email); err != nil {
+		log.Println("Failed to reset login throttle:", err)
+	}
+
+	codes, hashes, err := auth.NewRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		log.Println("Failed to generate recovery codes:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to enable two-factor authentication",
+		}
+	}
+
+	if err := s.twoFactorRepo.EnableTwoFactor(ctx, userID, hashes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Two-factor authentication is already enabled",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to enable two-factor authentication")
+	}
+
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns 2FA off, the user has to prove it with a TOTP or a recovery
+// code so a stolen session alone is not enough.
+func (s *TwoFactorService) Disable(ctx context.Context, code, ip string) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	twoFactor, err := s.getTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if twoFactor.EnabledAt == nil {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Two-factor authentication is not enabled",
+		}
+	}
+
+	email, err := s.checkCodeThrottle(ctx, userID, ip, "Failed to disable two-factor authentication")
+	if err != nil {
+		return err
+	}
+
+	valid, err := s.verifyCode(ctx, twoFactor, code)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to disable two-factor authentication")
+	}
+
+	if !valid {
+		return s.codeFailed(ctx, email, ip, "Failed to disable two-factor authentication")
+	}
+
+	if err := s.loginThrottler.Reset(ctx, email); err != nil {
+		log.Println("Failed to reset login throttle:", err)
+	}
+
+	if err := s.twoFactorRepo.DisableTwoFactor(ctx, userID); err != nil {
+		return apperrors.Wrap(err, "Failed to disable two-factor authentication")
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, only a TOTP code is
+// accepted since the old recovery codes are about to be invalidated.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, code, ip string) (*models.RecoveryCodesResponse, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	twoFactor, err := s.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor.EnabledAt == nil {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Two-factor authentication is not enabled",
+		}
+	}
+
+	email, err := s.checkCodeThrottle(ctx, userID, ip, "Failed to regenerate recovery codes")
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := s.verifyTOTP(ctx, twoFactor, code)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "Failed to regenerate recovery codes")
+	}
+
+	if !valid {
+		return nil, s.codeFailed(ctx, email, ip, "Failed to regenerate recovery codes")
+	}
+
+	if err := s.loginThrottler.Reset(ctx, email); err != nil {
+		log.Println("Failed to reset login throttle:", err)
+	}
+
+	codes, hashes, err := auth.NewRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		log.Println("Failed to generate recovery codes:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to regenerate recovery codes",
+		}
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to regenerate recovery codes")
+	}
+
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// IsEnabled is used by login to decide whether a second step is needed.
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	twoFactor, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return twoFactor.EnabledAt != nil, nil
+}
+
+// VerifyLoginCode checks the second login step with a TOTP or a recovery code.
+func (s *TwoFactorService) VerifyLoginCode(ctx context.Context, userID int, code string) (bool, error) {
+	twoFactor, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if twoFactor.EnabledAt == nil {
+		return false, nil
+	}
+
+	return s.verifyCode(ctx, twoFactor, code)
+}
+
+// checkCodeThrottle rejects the code while the account or the IP is locked.
+// Codes share the login counters, otherwise a stolen session could guess the
+// code here without ever hitting the login lockout. Returns the email the
+// attempts are counted for.
+func (s *TwoFactorService) checkCodeThrottle(ctx context.Context, userID int, ip, message string) (string, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return "", apperrors.Wrap(err, message)
+	}
+
+	lockout, err := s.loginThrottler.Check(ctx, user.Email, ip)
+	if err != nil {
+		return "", apperrors.Wrap(err, message)
+	}
+
+	if lockout > 0 {
+		return "", &apperrors.AppError{
+			Code:       http.StatusTooManyRequests,
+			Message:    "Too many attempts",
+			RetryAfter: lockout,
+		}
+	}
+
+	return user.Email, nil
+}
+
+// codeFailed counts the invalid code and returns 400, or a lockout error when
+// this attempt locked the account or the IP.
+func (s *TwoFactorService) codeFailed(ctx context.Context, email, ip, message string) error {
+	lockout, err := s.loginThrottler.Fail(ctx, email, ip, security.ReasonInvalidCode)
+	if err != nil {
+		return apperrors.Wrap(err, message)
+	}
+
+	if lockout > 0 {
+		return &apperrors.AppError{
+			Code:       http.StatusTooManyRequests,
+			Message:    "Too many attempts",
+			RetryAfter: lockout,
+		}
+	}
+
+	return &apperrors.AppError{
+		Code:    http.StatusBadRequest,
+		Message: "Invalid code",
+	}
+}
+
+func (s *TwoFactorService) getTwoFactor(ctx context.Context, userID int) (*models.TwoFactor, error) {
+	twoFactor, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "User not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get two-factor status")
+	}
+
+	return twoFactor, nil
+}
+
+func (s *TwoFactorService) verifyCode(ctx context.Context, twoFactor *models.TwoFactor, code string) (bool, error) {
+	valid, err := s.verifyTOTP(ctx, twoFactor, code)
+	if err != nil || valid {
+		return valid, err
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, auth.HashRecoveryCode(code))
+	if err != nil {
+		return false, err
+	}
+
+	return used > 0, nil
+}
+
+// verifyTOTP accepts every code once, the matched time step is remembered
+// until it leaves the validation window.
+func (s *TwoFactorService) verifyTOTP(ctx context.Context, twoFactor *models.TwoFactor, code string) (bool, error) {
+	if twoFactor.Secret == nil {
+		return false, nil
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	step, ok := auth.ValidateTOTP(*twoFactor.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	key := fmt.Sprintf(usedTOTPKeyFmt, twoFactor.UserID, step)
+	fresh, err := s.redis.SetNX(ctx, key, 1, 3*auth.TOTPPeriod).Result()
+	if err != nil {
+		log.Println("Failed to store used TOTP code:", err)
+		return false, err
+	}
+
+	return fresh, nil
+}
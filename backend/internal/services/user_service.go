@@ -15,14 +15,16 @@ import (
 )
 
 type UserService struct {
-	userRepo *repository.UserRepository
-	roleRepo *repository.RoleRepository
+	userRepo    *repository.UserRepository
+	roleRepo    *repository.RoleRepository
+	roleService *RoleService
 }
 
-func NewUserService(userRepo *repository.UserRepository, roleRepo *repository.RoleRepository) *UserService {
+func NewUserService(userRepo *repository.UserRepository, roleRepo *repository.RoleRepository, roleService *RoleService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
-		roleRepo: roleRepo,
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		roleService: roleService,
 	}
 }
 
@@ -120,9 +122,29 @@ func (s *UserService) AddRoleToUser(ctx context.Context, id int, req *models.Add
 		}
 	}
 
+	s.roleService.InvalidateUserPermissions(ctx, id)
+
 	return s.userRepo.GetUserByID(ctx, id)
 }
 
+func (s *UserService) RemoveRoleFromUser(ctx context.Context, id, roleID int) error {
+	removed, err := s.userRepo.RemoveUserRole(ctx, id, roleID)
+	if err != nil {
+		return apperrors.Wrap(err, "Failed to remove role")
+	}
+
+	if removed == 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "User has no such role",
+		}
+	}
+
+	s.roleService.InvalidateUserPermissions(ctx, id)
+
+	return nil
+}
+
 func (s *UserService) GetUserRoles(ctx context.Context, userID int) (*[]models.Role, error) {
 	roles, err := s.userRepo.GetUserRoles(ctx, userID)
 
@@ -151,3 +173,16 @@ func (s *UserService) GetUserRoles(ctx context.Context, userID int) (*[]models.R
 	}
 	return roles, nil
 }
+
+func (s *UserService) GetCurrentUserRoles(ctx context.Context) (*[]models.Role, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Failed to get user id")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	return s.GetUserRoles(ctx, userID)
+}
@@ -18,17 +18,23 @@ type WorkoutExerciseSerivce struct {
 	workoutRepo         *repository.WorkoutRepository
 	workoutExerciseRepo *repository.WorkoutExerciseRepository
 	exerciseRepo        *repository.ExerciseRepository
+	workoutSetRepo      *repository.WorkoutSetRepository
+	recordService       *PersonalRecordService
 }
 
 func NewWorkoutExerciseService(
 	workoutRepo *repository.WorkoutRepository,
 	workoutExerciseRepo *repository.WorkoutExerciseRepository,
 	exerciseRepo *repository.ExerciseRepository,
+	workoutSetRepo *repository.WorkoutSetRepository,
+	recordService *PersonalRecordService,
 ) *WorkoutExerciseSerivce {
 	return &WorkoutExerciseSerivce{
 		workoutRepo:         workoutRepo,
 		workoutExerciseRepo: workoutExerciseRepo,
 		exerciseRepo:        exerciseRepo,
+		workoutSetRepo:      workoutSetRepo,
+		recordService:       recordService,
 	}
 }
 
@@ -50,7 +56,8 @@ func (s *WorkoutExerciseSerivce) AddExerciseToWorkout(ctx context.Context, worko
 		}
 	}
 
-	if exercise, err := s.exerciseRepo.GetExercise(ctx, request.ExerciseID); exercise == nil || err != nil {
+	exercise, err := s.exerciseRepo.GetExercise(ctx, request.ExerciseID)
+	if exercise == nil || err != nil {
 		log.Println("Incorrect exercise id")
 		return nil, &apperrors.AppError{
 			Code:    http.StatusBadRequest,
@@ -58,16 +65,33 @@ func (s *WorkoutExerciseSerivce) AddExerciseToWorkout(ctx context.Context, worko
 		}
 	}
 
+	if err := validateTimeRange(request.StartedAt, request.EndedAt); err != nil {
+		log.Println("Invalid exercise time range:", err)
+		return nil, err
+	}
+
+	workoutSets, setsErr := buildWorkoutSets(exercise.TrackingType, request)
+	if setsErr != nil {
+		log.Println("Invalid workout sets:", setsErr)
+		return nil, setsErr
+	}
+
 	workoutExercise := models.WorkoutExercise{
-		WorkoutID:  workoutID,
-		ExerciseID: request.ExerciseID,
-		Sets:       request.Sets,
-		Reps:       request.Reps,
-		Weight:     request.Weight,
-		Notes:      request.Notes,
+		WorkoutID:       workoutID,
+		ExerciseID:      request.ExerciseID,
+		Sets:            request.Sets,
+		Reps:            request.Reps,
+		Weight:          request.Weight,
+		DurationSeconds: request.DurationSeconds,
+		DistanceMeters:  request.DistanceMeters,
+		HeartRateAvg:    request.HeartRateAvg,
+		StartedAt:       request.StartedAt,
+		EndedAt:         request.EndedAt,
+		Notes:           request.Notes,
+		WorkoutSets:     workoutSets,
 	}
 
-	err := s.workoutExerciseRepo.AddExerciseToWorkout(ctx, &workoutExercise)
+	err = s.workoutExerciseRepo.AddExerciseToWorkout(ctx, &workoutExercise)
 
 	if err != nil {
 		var pgErr *pq.Error
@@ -108,6 +132,11 @@ func (s *WorkoutExerciseSerivce) AddExerciseToWorkout(ctx context.Context, worko
 		}
 	}
 
+	workoutExercise.NewRecords, err = s.recordService.UpdateRecords(ctx, userID, workoutExercise.ExerciseID, workoutExercise.ID)
+	if err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
 	return &workoutExercise, nil
 }
 
@@ -164,6 +193,24 @@ func (s *WorkoutExerciseSerivce) GetExercisesByWorkoutID(ctx context.Context, wo
 		}
 	}
 
+	workoutSets, err := s.workoutSetRepo.GetSetsByWorkoutID(ctx, workoutID)
+	if err != nil {
+		log.Println("Failed to get workout sets:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get workout exercises",
+		}
+	}
+
+	setsByExercise := make(map[int][]models.WorkoutSet)
+	for _, set := range *workoutSets {
+		setsByExercise[set.WorkoutExerciseID] = append(setsByExercise[set.WorkoutExerciseID], set)
+	}
+
+	for i := range *workoutExercises {
+		(*workoutExercises)[i].WorkoutSets = setsByExercise[(*workoutExercises)[i].ID]
+	}
+
 	return workoutExercises, nil
 }
 
@@ -227,6 +274,17 @@ func (s *WorkoutExerciseSerivce) GetExerciseByWorkoutID(ctx context.Context, wor
 		}
 	}
 
+	workoutSets, err := s.workoutSetRepo.GetSetsByWorkoutExerciseID(ctx, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to get workout sets:", err)
+		return nil, &apperrors.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to get workout exercises",
+		}
+	}
+
+	workoutExercise.WorkoutSets = *workoutSets
+
 	return workoutExercise, nil
 }
 
@@ -248,7 +306,8 @@ func (s *WorkoutExerciseSerivce) UpdateExerciseInWorkout(ctx context.Context, wo
 		}
 	}
 
-	if exercise, err := s.exerciseRepo.GetExercise(ctx, request.ExerciseID); exercise == nil || err != nil {
+	exercise, err := s.exerciseRepo.GetExercise(ctx, request.ExerciseID)
+	if exercise == nil || err != nil {
 		log.Println("Incorrect exercise id")
 		return nil, &apperrors.AppError{
 			Code:    http.StatusBadRequest,
@@ -256,17 +315,36 @@ func (s *WorkoutExerciseSerivce) UpdateExerciseInWorkout(ctx context.Context, wo
 		}
 	}
 
+	if err := validateTimeRange(request.StartedAt, request.EndedAt); err != nil {
+		log.Println("Invalid exercise time range:", err)
+		return nil, err
+	}
+
+	workoutSets, setsErr := buildWorkoutSets(exercise.TrackingType, request)
+	if setsErr != nil {
+		log.Println("Invalid workout sets:", setsErr)
+		return nil, setsErr
+	}
+
+	previous, _ := s.workoutExerciseRepo.GetExerciseByWorkoutID(ctx, workoutID, workoutExerciseID)
+
 	workoutExercise := models.WorkoutExercise{
-		ID:         workoutExerciseID,
-		WorkoutID:  workoutID,
-		ExerciseID: request.ExerciseID,
-		Sets:       request.Sets,
-		Reps:       request.Reps,
-		Weight:     request.Weight,
-		Notes:      request.Notes,
+		ID:              workoutExerciseID,
+		WorkoutID:       workoutID,
+		ExerciseID:      request.ExerciseID,
+		Sets:            request.Sets,
+		Reps:            request.Reps,
+		Weight:          request.Weight,
+		DurationSeconds: request.DurationSeconds,
+		DistanceMeters:  request.DistanceMeters,
+		HeartRateAvg:    request.HeartRateAvg,
+		StartedAt:       request.StartedAt,
+		EndedAt:         request.EndedAt,
+		Notes:           request.Notes,
+		WorkoutSets:     workoutSets,
 	}
 
-	err := s.workoutExerciseRepo.UpdateExerciseInWorkout(ctx, &workoutExercise)
+	err = s.workoutExerciseRepo.UpdateExerciseInWorkout(ctx, &workoutExercise)
 
 	if err != nil {
 		var pgErr *pq.Error
@@ -314,6 +392,17 @@ func (s *WorkoutExerciseSerivce) UpdateExerciseInWorkout(ctx context.Context, wo
 		}
 	}
 
+	if previous != nil && previous.ExerciseID != workoutExercise.ExerciseID {
+		if _, err := s.recordService.UpdateRecords(ctx, userID, previous.ExerciseID, workoutExerciseID); err != nil {
+			log.Println("Failed to update personal records:", err)
+		}
+	}
+
+	workoutExercise.NewRecords, err = s.recordService.UpdateRecords(ctx, userID, workoutExercise.ExerciseID, workoutExercise.ID)
+	if err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
 	return &workoutExercise, nil
 }
 
@@ -335,6 +424,8 @@ func (s *WorkoutExerciseSerivce) DeleteExerciseByWorkoutID(ctx context.Context,
 		}
 	}
 
+	previous, _ := s.workoutExerciseRepo.GetExerciseByWorkoutID(ctx, workoutID, workoutExerciseID)
+
 	rowsAffected, err := s.workoutExerciseRepo.DeleteExerciseByWorkoutID(ctx, workoutID, workoutExerciseID)
 
 	if err != nil {
@@ -370,5 +461,207 @@ func (s *WorkoutExerciseSerivce) DeleteExerciseByWorkoutID(ctx context.Context,
 		}
 	}
 
+	if previous != nil {
+		if _, err := s.recordService.UpdateRecords(ctx, userID, previous.ExerciseID, workoutExerciseID); err != nil {
+			log.Println("Failed to update personal records:", err)
+		}
+	}
+
+	return nil
+}
+
+func buildWorkoutSets(trackingType string, request *models.WorkoutExerciseRequest) ([]models.WorkoutSet, error) {
+	var workoutSets []models.WorkoutSet
+
+	if len(request.WorkoutSets) == 0 {
+		if request.Sets == 0 && request.Reps == 0 && request.Weight == 0 &&
+			request.DurationSeconds == nil && request.DistanceMeters == nil && request.HeartRateAvg == nil {
+			return nil, nil
+		}
+
+		if request.Sets < 0 {
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Sets must not be negative",
+			}
+		}
+
+		summary := &models.WorkoutSetRequest{
+			Reps:            request.Reps,
+			Weight:          request.Weight,
+			DurationSeconds: request.DurationSeconds,
+			DistanceMeters:  request.DistanceMeters,
+			HeartRateAvg:    request.HeartRateAvg,
+		}
+		if err := validateTrackingFields(trackingType, summary); err != nil {
+			return nil, err
+		}
+
+		for i := 1; i <= request.Sets; i++ {
+			workoutSets = append(workoutSets, models.WorkoutSet{
+				SetNumber:       i,
+				SetType:         models.SetTypeWorking,
+				Reps:            request.Reps,
+				Weight:          request.Weight,
+				DurationSeconds: request.DurationSeconds,
+				DistanceMeters:  request.DistanceMeters,
+				HeartRateAvg:    request.HeartRateAvg,
+			})
+		}
+
+		return workoutSets, nil
+	}
+
+	request.Sets = len(request.WorkoutSets)
+	request.Reps = 0
+	request.Weight = 0
+	request.DurationSeconds = nil
+	request.DistanceMeters = nil
+	request.HeartRateAvg = nil
+
+	var heartRateSum, heartRateCount int
+	for i := range request.WorkoutSets {
+		setRequest := &request.WorkoutSets[i]
+		if setRequest.SetNumber == 0 {
+			setRequest.SetNumber = i + 1
+		}
+
+		if err := validateWorkoutSetRequest(trackingType, setRequest); err != nil {
+			return nil, err
+		}
+
+		if setRequest.Weight > request.Weight || (setRequest.Weight == request.Weight && setRequest.Reps > request.Reps) {
+			request.Weight = setRequest.Weight
+			request.Reps = setRequest.Reps
+		}
+
+		if setRequest.DurationSeconds != nil {
+			request.DurationSeconds = addInt(request.DurationSeconds, *setRequest.DurationSeconds)
+		}
+
+		if setRequest.DistanceMeters != nil {
+			request.DistanceMeters = addFloat(request.DistanceMeters, *setRequest.DistanceMeters)
+		}
+
+		if setRequest.HeartRateAvg != nil {
+			heartRateSum += *setRequest.HeartRateAvg
+			heartRateCount++
+		}
+
+		workoutSets = append(workoutSets, *newWorkoutSet(0, setRequest))
+	}
+
+	if heartRateCount > 0 {
+		heartRateAvg := heartRateSum / heartRateCount
+		request.HeartRateAvg = &heartRateAvg
+	}
+
+	return workoutSets, nil
+}
+
+// validateTrackingFields checks that the set has the fields required by the
+// exercise tracking type and none of the fields that make no sense for it.
+func validateTrackingFields(trackingType string, req *models.WorkoutSetRequest) error {
+	switch {
+	case req.Reps < 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Reps must not be negative",
+		}
+
+	case req.Weight < 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Weight must not be negative",
+		}
+
+	case req.DurationSeconds != nil && *req.DurationSeconds < 1:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Duration must be greater than zero",
+		}
+
+	case req.DistanceMeters != nil && *req.DistanceMeters <= 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Distance must be greater than zero",
+		}
+
+	case req.HeartRateAvg != nil && (*req.HeartRateAvg < 30 || *req.HeartRateAvg > 250):
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Heart rate must be between 30 and 250",
+		}
+	}
+
+	switch trackingType {
+	case models.TrackingTypeDuration, models.TrackingTypeDistanceDuration:
+		if req.Reps != 0 {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Reps are not allowed for this exercise",
+			}
+		}
+
+		if req.DurationSeconds == nil {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Duration is required for this exercise",
+			}
+		}
+
+		if trackingType == models.TrackingTypeDistanceDuration && req.DistanceMeters == nil {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Distance is required for this exercise",
+			}
+		}
+
+		if trackingType == models.TrackingTypeDuration && req.DistanceMeters != nil {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Distance is not allowed for this exercise",
+			}
+		}
+
+	default:
+		if req.Reps < 1 {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Reps must be greater than zero",
+			}
+		}
+
+		if req.DistanceMeters != nil {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Distance is not allowed for this exercise",
+			}
+		}
+
+		if trackingType == models.TrackingTypeRepsOnly && req.Weight != 0 {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Weight is not allowed for this exercise",
+			}
+		}
+	}
+
 	return nil
 }
+
+func addInt(total *int, value int) *int {
+	sum := value
+	if total != nil {
+		sum += *total
+	}
+	return &sum
+}
+
+func addFloat(total *float64, value float64) *float64 {
+	sum := value
+	if total != nil {
+		sum += *total
+	}
+	return &sum
+}
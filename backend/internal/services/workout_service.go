@@ -9,17 +9,26 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/lib/pq"
 )
 
 type WorkoutSerivce struct {
-	workoutRepo *repository.WorkoutRepository
+	workoutRepo   *repository.WorkoutRepository
+	templateRepo  *repository.WorkoutTemplateRepository
+	recordService *PersonalRecordService
 }
 
-func NewWorkoutService(workoutRepo *repository.WorkoutRepository) *WorkoutSerivce {
+func NewWorkoutService(
+	workoutRepo *repository.WorkoutRepository,
+	templateRepo *repository.WorkoutTemplateRepository,
+	recordService *PersonalRecordService,
+) *WorkoutSerivce {
 	return &WorkoutSerivce{
-		workoutRepo: workoutRepo,
+		workoutRepo:   workoutRepo,
+		templateRepo:  templateRepo,
+		recordService: recordService,
 	}
 }
 
@@ -32,10 +41,22 @@ func (s *WorkoutSerivce) CreateWorkout(ctx context.Context, req *models.WorkoutR
 		}
 	}
 
+	if err := validateTimeRange(req.StartedAt, req.EndedAt); err != nil {
+		log.Println("Invalid workout time range:", err)
+		return nil, err
+	}
+
+	date := req.Date
+	if date.IsZero() && req.StartedAt != nil {
+		date = time.Date(req.StartedAt.Year(), req.StartedAt.Month(), req.StartedAt.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
 	workout := &models.Workout{
-		UserID: userID,
-		Date:   req.Date,
-		Notes:  req.Notes,
+		UserID:    userID,
+		Date:      date,
+		Notes:     req.Notes,
+		StartedAt: req.StartedAt,
+		EndedAt:   req.EndedAt,
 	}
 
 	err := s.workoutRepo.CreateWorkout(ctx, workout)
@@ -75,51 +96,49 @@ func (s *WorkoutSerivce) CreateWorkout(ctx context.Context, req *models.WorkoutR
 	return workout, nil
 }
 
-func (s *WorkoutSerivce) GetWorkoutsByUserID(ctx context.Context) (*[]models.Workout, error) {
+// GetWorkoutsByUserID returns an empty page rather than an error when the
+// user has no workouts or the page is past the end.
+func (s *WorkoutSerivce) GetWorkoutsByUserID(ctx context.Context, filter *models.WorkoutFilter) (*[]models.Workout, int, error) {
 	userID, ok := ctx.Value("user_id").(int)
 	if !ok {
 		log.Println("Unauthorized")
-		return nil, &apperrors.AppError{
+		return nil, 0, &apperrors.AppError{
 			Code:    http.StatusUnauthorized,
 			Message: "Unauthorized",
 		}
 	}
 
-	workouts, err := s.workoutRepo.GetWorkoutsByUserID(ctx, userID)
+	workouts, total, err := s.workoutRepo.GetWorkoutsByUserID(ctx, userID, filter)
 	if err != nil {
 		switch {
 		case errors.Is(err, context.Canceled):
 			log.Println("Request cancelled:", err)
-			return nil, &apperrors.AppError{
+			return nil, 0, &apperrors.AppError{
 				Code:    http.StatusBadRequest,
 				Message: "Request cancelled",
 			}
 
 		case errors.Is(err, context.DeadlineExceeded):
 			log.Println("Deadline exceeded:", err)
-			return nil, &apperrors.AppError{
+			return nil, 0, &apperrors.AppError{
 				Code:    http.StatusGatewayTimeout,
 				Message: "Request timeout",
 			}
 
 		default:
 			log.Println("Unhandled error:", err)
-			return nil, &apperrors.AppError{
+			return nil, 0, &apperrors.AppError{
 				Code:    http.StatusInternalServerError,
 				Message: "Failed to get workouts",
 			}
 		}
 	}
 
-	if workouts == nil || len(*workouts) == 0 {
-		log.Println("Workouts not found")
-		return nil, &apperrors.AppError{
-			Code:    http.StatusNotFound,
-			Message: "Workouts not found",
-		}
+	if workouts == nil {
+		workouts = &[]models.Workout{}
 	}
 
-	return workouts, nil
+	return workouts, total, nil
 }
 
 func (s *WorkoutSerivce) GetWorkoutByUserID(ctx context.Context, workoutID int) (*models.Workout, error) {
@@ -185,14 +204,65 @@ func (s *WorkoutSerivce) UpdateWorkoutByUserID(ctx context.Context, workoutID in
 		}
 	}
 
+	current, err := s.workoutRepo.GetWorkoutByUserID(ctx, userID, workoutID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.Is(err, sql.ErrNoRows):
+			log.Println("Workout not found:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Workout not found",
+			}
+
+		default:
+			log.Println("Failed to get workout:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get workout",
+			}
+		}
+	}
+
+	// Times that are not sent are kept, so a plain edit of the date or notes
+	// does not wipe the times recorded by a live session; an explicit null
+	// clears them.
+	startedAt, endedAt := current.StartedAt, current.EndedAt
+	if req.StartedAtSet {
+		startedAt = req.StartedAt
+	}
+	if req.EndedAtSet {
+		endedAt = req.EndedAt
+	}
+
+	if err := validateTimeRange(startedAt, endedAt); err != nil {
+		log.Println("Invalid workout time range:", err)
+		return nil, err
+	}
+
 	workout := &models.Workout{
-		ID:     workoutID,
-		UserID: userID,
-		Date:   req.Date,
-		Notes:  req.Notes,
+		ID:        workoutID,
+		UserID:    userID,
+		Date:      req.Date,
+		Notes:     req.Notes,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
 	}
 
-	err := s.workoutRepo.UpdateWorkoutByUserID(ctx, workout)
+	err = s.workoutRepo.UpdateWorkoutByUserID(ctx, workout)
 	if err != nil {
 		var pgErr *pq.Error
 		switch {
@@ -233,6 +303,10 @@ func (s *WorkoutSerivce) UpdateWorkoutByUserID(ctx context.Context, workoutID in
 		}
 	}
 
+	if err := s.recordService.UpdateWorkoutRecords(ctx, userID, workout.ID); err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
 	return workout, nil
 }
 
@@ -280,5 +354,94 @@ func (s *WorkoutSerivce) DeleteWorkoutByUserID(ctx context.Context, id int) erro
 		}
 	}
 
+	if err := s.recordService.UpdateWorkoutRecords(ctx, userID, id); err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
+	return nil
+}
+
+func (s *WorkoutSerivce) CreateWorkoutFromTemplate(ctx context.Context, templateID int, req *models.WorkoutFromTemplateRequest) (*models.Workout, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	template, err := s.templateRepo.GetTemplateByUserID(ctx, userID, templateID)
+	if template == nil || err != nil {
+		log.Println("Template not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Template not found",
+		}
+	}
+
+	notes := req.Notes
+	if notes == "" {
+		notes = template.Name
+	}
+
+	workout := &models.Workout{
+		UserID: userID,
+		Date:   req.Date,
+		Notes:  notes,
+	}
+
+	err = s.workoutRepo.CreateWorkoutFromTemplate(ctx, workout, templateID)
+	if err != nil {
+		var pgErr *pq.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation:
+			log.Println("Foreign key violation:", pgErr)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Incorrect user id",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to create workout from template",
+			}
+		}
+	}
+
+	return workout, nil
+}
+
+func validateTimeRange(startedAt, endedAt *time.Time) error {
+	switch {
+	case endedAt != nil && startedAt == nil:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Start time is required when end time is set",
+		}
+
+	case endedAt != nil && endedAt.Before(*startedAt):
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "End time must not be before start time",
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var workoutColumns = []string{"id", "user_id", "date", "notes", "started_at", "ended_at", "total_sets", "total_volume", "created_at", "updated_at", "is_active"}
+
+func TestUpdateWorkoutTimes(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	startedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	endedAt := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStarted *time.Time
+		wantEnded   *time.Time
+	}{
+		{"omitted times are kept", `{"date":"2024-05-01T00:00:00Z","notes":"legs"}`, &startedAt, &endedAt},
+		{"null clears the end time", `{"date":"2024-05-01T00:00:00Z","notes":"legs","ended_at":null}`, &startedAt, nil},
+		{"null clears both times", `{"date":"2024-05-01T00:00:00Z","notes":"legs","started_at":null,"ended_at":null}`, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			records := NewPersonalRecordService(nil, nil, repository.NewWorkoutExerciseRepository(sqlxDB))
+			service := NewWorkoutService(repository.NewWorkoutRepository(sqlxDB), nil, records)
+
+			var req models.WorkoutRequest
+			assert.NoError(t, json.Unmarshal([]byte(tt.body), &req))
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at`)).
+				WithArgs(2, 7).
+				WillReturnRows(sqlmock.NewRows(workoutColumns).
+					AddRow(7, 2, date, "legs", startedAt, endedAt, nil, nil, date, date, true))
+			mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts`)).
+				WithArgs(date, "legs", tt.wantStarted, tt.wantEnded, 7, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+					AddRow(nil, nil, date, date, true))
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM WorkoutExercises we`)).
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows(nil))
+
+			ctx := context.WithValue(context.Background(), "user_id", 2)
+			workout, err := service.UpdateWorkoutByUserID(ctx, 7, &req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStarted, workout.StartedAt)
+			assert.Equal(t, tt.wantEnded, workout.EndedAt)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateWorkoutLookupTimeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewWorkoutService(repository.NewWorkoutRepository(sqlx.NewDb(db, "sqlmock")), nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at`)).
+		WithArgs(2, 7).
+		WillReturnError(context.DeadlineExceeded)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	_, err = service.UpdateWorkoutByUserID(ctx, 7, &models.WorkoutRequest{Notes: "legs"})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusGatewayTimeout, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWorkoutSucceedsWhenRecordsFail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	records := NewPersonalRecordService(nil, nil, repository.NewWorkoutExerciseRepository(sqlxDB))
+	service := NewWorkoutService(repository.NewWorkoutRepository(sqlxDB), nil, records)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, date, notes, started_at, ended_at`)).
+		WithArgs(2, 7).
+		WillReturnRows(sqlmock.NewRows(workoutColumns).
+			AddRow(7, 2, date, "legs", nil, nil, nil, nil, date, date, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE Workouts`)).
+		WillReturnRows(sqlmock.NewRows([]string{"total_sets", "total_volume", "created_at", "updated_at", "is_active"}).
+			AddRow(nil, nil, date, date, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM WorkoutExercises we`)).
+		WithArgs(7).
+		WillReturnError(context.DeadlineExceeded)
+
+	ctx := context.WithValue(context.Background(), "user_id", 2)
+	workout, err := service.UpdateWorkoutByUserID(ctx, 7, &models.WorkoutRequest{Date: date, Notes: "push"})
+	assert.NoError(t, err)
+	assert.Equal(t, "push", workout.Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
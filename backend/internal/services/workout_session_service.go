@@ -0,0 +1,357 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	workoutSessionTTL     = 12 * time.Hour
+	maxRestTimerSeconds   = 60 * 60
+	workoutSessionKeyFmt  = "workout_session:%d"
+	workoutSessionChanFmt = "workout_session:%d:events"
+)
+
+// WorkoutSessionService keeps live session state in Redis and fans out
+// session events over Redis pub/sub, so every device of the user sees the
+// same session regardless of the API instance it is connected to.
+type WorkoutSessionService struct {
+	workoutRepo       *repository.WorkoutRepository
+	workoutSetService *WorkoutSetService
+	redis             *redis.Client
+}
+
+func NewWorkoutSessionService(
+	workoutRepo *repository.WorkoutRepository,
+	workoutSetService *WorkoutSetService,
+	redis *redis.Client,
+) *WorkoutSessionService {
+	return &WorkoutSessionService{
+		workoutRepo:       workoutRepo,
+		workoutSetService: workoutSetService,
+		redis:             redis,
+	}
+}
+
+func (s *WorkoutSessionService) StartSession(ctx context.Context, workoutID int) (*models.WorkoutSession, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	workout := &models.Workout{
+		ID:     workoutID,
+		UserID: userID,
+	}
+
+	if err := s.workoutRepo.StartWorkout(ctx, workout); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.workoutStateError(ctx, userID, workoutID)
+		}
+		return nil, apperrors.Wrap(err, "Failed to start workout")
+	}
+
+	session, err := s.loadSession(ctx, workoutID)
+	if err == nil {
+		return session, nil
+	}
+
+	if !errors.Is(err, redis.Nil) {
+		return nil, apperrors.Wrap(err, "Failed to start workout")
+	}
+
+	session = &models.WorkoutSession{
+		WorkoutID: workoutID,
+		UserID:    userID,
+		StartedAt: *workout.StartedAt,
+	}
+
+	if err := s.saveSession(ctx, session); err != nil {
+		return nil, apperrors.Wrap(err, "Failed to start workout")
+	}
+
+	return session, nil
+}
+
+func (s *WorkoutSessionService) GetSession(ctx context.Context, workoutID int) (*models.WorkoutSession, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	session, err := s.loadSession(ctx, workoutID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			log.Println("Session not found")
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Session not found",
+			}
+		}
+		return nil, apperrors.Wrap(err, "Failed to get session")
+	}
+
+	if session.UserID != userID {
+		log.Println("Session belongs to another user")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Session not found",
+		}
+	}
+
+	return session, nil
+}
+
+func (s *WorkoutSessionService) FinishSession(ctx context.Context, workoutID int) (*models.Workout, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	workout := &models.Workout{
+		ID:     workoutID,
+		UserID: userID,
+	}
+
+	if err := s.workoutRepo.FinishWorkout(ctx, workout); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.workoutStateError(ctx, userID, workoutID)
+		}
+		return nil, apperrors.Wrap(err, "Failed to finish workout")
+	}
+
+	if err := s.redis.Del(ctx, sessionKey(workoutID)).Err(); err != nil {
+		log.Println("Failed to delete workout session:", err)
+	}
+
+	s.publish(ctx, &models.SessionEvent{
+		Type:      models.SessionEventSessionFinished,
+		WorkoutID: workoutID,
+		Workout:   workout,
+	})
+
+	return workout, nil
+}
+
+// HandleMessage applies a message received from one of the connected devices
+// and broadcasts the result to all of them.
+func (s *WorkoutSessionService) HandleMessage(ctx context.Context, workoutID int, msg *models.SessionMessage) error {
+	session, err := s.GetSession(ctx, workoutID)
+	if err != nil {
+		return err
+	}
+
+	switch msg.Type {
+	case models.SessionMessageCompleteSet, models.SessionMessageUpdateSet:
+		if msg.Set == nil {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Set is required",
+			}
+		}
+
+		event := &models.SessionEvent{
+			Type:              models.SessionEventSetCompleted,
+			WorkoutID:         workoutID,
+			WorkoutExerciseID: msg.WorkoutExerciseID,
+		}
+
+		var set *models.WorkoutSet
+		if msg.Type == models.SessionMessageCompleteSet {
+			set, err = s.workoutSetService.CreateSet(ctx, workoutID, msg.WorkoutExerciseID, msg.Set)
+		} else {
+			event.Type = models.SessionEventSetUpdated
+			set, err = s.workoutSetService.UpdateSet(ctx, workoutID, msg.WorkoutExerciseID, msg.SetID, msg.Set)
+		}
+		if err != nil {
+			return err
+		}
+
+		event.SetID = set.ID
+		event.Set = set
+		s.publish(ctx, event)
+
+	case models.SessionMessageDeleteSet:
+		if err := s.workoutSetService.DeleteSet(ctx, workoutID, msg.WorkoutExerciseID, msg.SetID); err != nil {
+			return err
+		}
+
+		s.publish(ctx, &models.SessionEvent{
+			Type:              models.SessionEventSetDeleted,
+			WorkoutID:         workoutID,
+			WorkoutExerciseID: msg.WorkoutExerciseID,
+			SetID:             msg.SetID,
+		})
+
+	case models.SessionMessageStartRestTimer:
+		if msg.RestSeconds < 1 || msg.RestSeconds > maxRestTimerSeconds {
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Rest seconds must be between 1 and 3600",
+			}
+		}
+
+		restEndsAt := time.Now().Add(time.Duration(msg.RestSeconds) * time.Second)
+		session.RestEndsAt = &restEndsAt
+		if err := s.saveSession(ctx, session); err != nil {
+			return apperrors.Wrap(err, "Failed to start rest timer")
+		}
+
+		s.publish(ctx, &models.SessionEvent{
+			Type:       models.SessionEventRestTimerStarted,
+			WorkoutID:  workoutID,
+			RestEndsAt: &restEndsAt,
+		})
+
+	case models.SessionMessageStopRestTimer:
+		session.RestEndsAt = nil
+		if err := s.saveSession(ctx, session); err != nil {
+			return apperrors.Wrap(err, "Failed to stop rest timer")
+		}
+
+		s.publish(ctx, &models.SessionEvent{
+			Type:      models.SessionEventRestTimerStopped,
+			WorkoutID: workoutID,
+		})
+
+	default:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Unknown message type",
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns the events of the session until ctx is done or the
+// returned close function is called.
+func (s *WorkoutSessionService) Subscribe(ctx context.Context, workoutID int) (<-chan models.SessionEvent, func() error, error) {
+	pubsub := s.redis.Subscribe(ctx, sessionChannel(workoutID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, apperrors.Wrap(err, "Failed to subscribe to session")
+	}
+
+	events := make(chan models.SessionEvent)
+	go func() {
+		defer close(events)
+
+		for msg := range pubsub.Channel() {
+			var event models.SessionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Println("Failed to decode session event:", err)
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, pubsub.Close, nil
+}
+
+func (s *WorkoutSessionService) workoutStateError(ctx context.Context, userID, workoutID int) error {
+	workout, err := s.workoutRepo.GetWorkoutByUserID(ctx, userID, workoutID)
+	switch {
+	case workout == nil || err != nil:
+		log.Println("Workout not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Workout not found",
+		}
+
+	case workout.EndedAt != nil:
+		log.Println("Workout already finished")
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Workout already finished",
+		}
+
+	default:
+		log.Println("Workout is not started")
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Workout is not started",
+		}
+	}
+}
+
+func (s *WorkoutSessionService) loadSession(ctx context.Context, workoutID int) (*models.WorkoutSession, error) {
+	val, err := s.redis.Get(ctx, sessionKey(workoutID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var session models.WorkoutSession
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		log.Println("Failed to decode workout session:", err)
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (s *WorkoutSessionService) saveSession(ctx context.Context, session *models.WorkoutSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		log.Println("Failed to encode workout session:", err)
+		return err
+	}
+
+	if err := s.redis.Set(ctx, sessionKey(session.WorkoutID), data, workoutSessionTTL).Err(); err != nil {
+		log.Println("Failed to save workout session:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *WorkoutSessionService) publish(ctx context.Context, event *models.SessionEvent) {
+	event.SentAt = time.Now()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Println("Failed to encode session event:", err)
+		return
+	}
+
+	if err := s.redis.Publish(ctx, sessionChannel(event.WorkoutID), data).Err(); err != nil {
+		log.Println("Failed to publish session event:", err)
+	}
+}
+
+func sessionKey(workoutID int) string {
+	return fmt.Sprintf(workoutSessionKeyFmt, workoutID)
+}
+
+func sessionChannel(workoutID int) string {
+	return fmt.Sprintf(workoutSessionChanFmt, workoutID)
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+var allowedSetTypes = map[string]bool{
+	models.SetTypeWarmup:  true,
+	models.SetTypeWorking: true,
+	models.SetTypeDrop:    true,
+	models.SetTypeFailure: true,
+}
+
+type WorkoutSetService struct {
+	workoutRepo         *repository.WorkoutRepository
+	workoutExerciseRepo *repository.WorkoutExerciseRepository
+	workoutSetRepo      *repository.WorkoutSetRepository
+	recordService       *PersonalRecordService
+}
+
+func NewWorkoutSetService(
+	workoutRepo *repository.WorkoutRepository,
+	workoutExerciseRepo *repository.WorkoutExerciseRepository,
+	workoutSetRepo *repository.WorkoutSetRepository,
+	recordService *PersonalRecordService,
+) *WorkoutSetService {
+	return &WorkoutSetService{
+		workoutRepo:         workoutRepo,
+		workoutExerciseRepo: workoutExerciseRepo,
+		workoutSetRepo:      workoutSetRepo,
+		recordService:       recordService,
+	}
+}
+
+func (s *WorkoutSetService) CreateSet(ctx context.Context, workoutID, workoutExerciseID int, req *models.WorkoutSetRequest) (*models.WorkoutSet, error) {
+	userID, workoutExercise, err := s.checkWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWorkoutSetRequest(workoutExerciseTrackingType(workoutExercise), req); err != nil {
+		return nil, err
+	}
+
+	set := newWorkoutSet(workoutExerciseID, req)
+
+	if err := s.workoutSetRepo.CreateSet(ctx, set); err != nil {
+		var pgErr *pq.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation:
+			log.Println("Unique violation:", pgErr)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Set with this number already exists",
+			}
+
+		case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation:
+			log.Println("Foreign key violation:", pgErr)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Exercise not found in workout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to add set",
+			}
+		}
+	}
+
+	set.NewRecords, err = s.recordService.UpdateRecords(ctx, userID, workoutExercise.ExerciseID, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
+	return set, nil
+}
+
+func (s *WorkoutSetService) GetSets(ctx context.Context, workoutID, workoutExerciseID int) (*[]models.WorkoutSet, error) {
+	if _, _, err := s.checkWorkoutExercise(ctx, workoutID, workoutExerciseID); err != nil {
+		return nil, err
+	}
+
+	sets, err := s.workoutSetRepo.GetSetsByWorkoutExerciseID(ctx, workoutExerciseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get sets",
+			}
+		}
+	}
+
+	if sets == nil || len(*sets) == 0 {
+		log.Println("Sets not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Sets not found",
+		}
+	}
+
+	return sets, nil
+}
+
+func (s *WorkoutSetService) GetSet(ctx context.Context, workoutID, workoutExerciseID, setID int) (*models.WorkoutSet, error) {
+	if _, _, err := s.checkWorkoutExercise(ctx, workoutID, workoutExerciseID); err != nil {
+		return nil, err
+	}
+
+	set, err := s.workoutSetRepo.GetSet(ctx, workoutExerciseID, setID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.Is(err, sql.ErrNoRows):
+			log.Println("Set not found:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Set not found",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get set",
+			}
+		}
+	}
+
+	return set, nil
+}
+
+func (s *WorkoutSetService) UpdateSet(ctx context.Context, workoutID, workoutExerciseID, setID int, req *models.WorkoutSetRequest) (*models.WorkoutSet, error) {
+	userID, workoutExercise, err := s.checkWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWorkoutSetRequest(workoutExerciseTrackingType(workoutExercise), req); err != nil {
+		return nil, err
+	}
+
+	set := newWorkoutSet(workoutExerciseID, req)
+	set.ID = setID
+
+	if err := s.workoutSetRepo.UpdateSet(ctx, set); err != nil {
+		var pgErr *pq.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.Is(err, sql.ErrNoRows):
+			log.Println("Set not found:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Set not found",
+			}
+
+		case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation:
+			log.Println("Unique violation:", pgErr)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusConflict,
+				Message: "Set with this number already exists",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to update set",
+			}
+		}
+	}
+
+	set.NewRecords, err = s.recordService.UpdateRecords(ctx, userID, workoutExercise.ExerciseID, workoutExerciseID)
+	if err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
+	return set, nil
+}
+
+func (s *WorkoutSetService) DeleteSet(ctx context.Context, workoutID, workoutExerciseID, setID int) error {
+	userID, workoutExercise, err := s.checkWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := s.workoutSetRepo.DeleteSet(ctx, workoutExerciseID, setID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to delete set",
+			}
+		}
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Set not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Set not found",
+		}
+	}
+
+	if _, err := s.recordService.UpdateRecords(ctx, userID, workoutExercise.ExerciseID, workoutExerciseID); err != nil {
+		log.Println("Failed to update personal records:", err)
+	}
+
+	return nil
+}
+
+func (s *WorkoutSetService) checkWorkoutExercise(ctx context.Context, workoutID, workoutExerciseID int) (int, *models.WorkoutExercise, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return 0, nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if workout, err := s.workoutRepo.GetWorkoutByUserID(ctx, userID, workoutID); workout == nil || err != nil {
+		log.Println("Workout not found")
+		return 0, nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Workout not found",
+		}
+	}
+
+	workoutExercise, err := s.workoutExerciseRepo.GetExerciseByWorkoutID(ctx, workoutID, workoutExerciseID)
+	if workoutExercise == nil || err != nil {
+		log.Println("Exercise not found in workout")
+		return 0, nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Exercise not found in workout",
+		}
+	}
+
+	return userID, workoutExercise, nil
+}
+
+func validateWorkoutSetRequest(trackingType string, req *models.WorkoutSetRequest) error {
+	if req.SetType == "" {
+		req.SetType = models.SetTypeWorking
+	}
+
+	switch {
+	case !allowedSetTypes[req.SetType]:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid set type",
+		}
+
+	case req.SetNumber < 0:
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid set number",
+		}
+
+	case req.RPE != nil && (*req.RPE < 1 || *req.RPE > 10):
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "RPE must be between 1 and 10",
+		}
+	}
+
+	return validateTrackingFields(trackingType, req)
+}
+
+func workoutExerciseTrackingType(workoutExercise *models.WorkoutExercise) string {
+	if workoutExercise.Exercise == nil || workoutExercise.Exercise.TrackingType == "" {
+		return models.TrackingTypeWeightReps
+	}
+
+	return workoutExercise.Exercise.TrackingType
+}
+
+func newWorkoutSet(workoutExerciseID int, req *models.WorkoutSetRequest) *models.WorkoutSet {
+	return &models.WorkoutSet{
+		WorkoutExerciseID: workoutExerciseID,
+		SetNumber:         req.SetNumber,
+		SetType:           req.SetType,
+		Reps:              req.Reps,
+		Weight:            req.Weight,
+		RPE:               req.RPE,
+		DurationSeconds:   req.DurationSeconds,
+		DistanceMeters:    req.DistanceMeters,
+		HeartRateAvg:      req.HeartRateAvg,
+	}
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"backend/internal/apperrors"
+	"backend/internal/models"
+	"backend/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type WorkoutTemplateService struct {
+	templateRepo *repository.WorkoutTemplateRepository
+	workoutRepo  *repository.WorkoutRepository
+}
+
+func NewWorkoutTemplateService(templateRepo *repository.WorkoutTemplateRepository, workoutRepo *repository.WorkoutRepository) *WorkoutTemplateService {
+	return &WorkoutTemplateService{
+		templateRepo: templateRepo,
+		workoutRepo:  workoutRepo,
+	}
+}
+
+func (s *WorkoutTemplateService) CreateTemplate(ctx context.Context, req *models.WorkoutTemplateRequest) (*models.WorkoutTemplate, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if err := validateWorkoutTemplateRequest(req); err != nil {
+		return nil, err
+	}
+
+	template := newWorkoutTemplate(userID, req)
+
+	if err := s.templateRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, templateWriteError(err, "Failed to create template")
+	}
+
+	return template, nil
+}
+
+func (s *WorkoutTemplateService) CreateTemplateFromWorkout(ctx context.Context, workoutID int, req *models.TemplateFromWorkoutRequest) (*models.WorkoutTemplate, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Template name is required",
+		}
+	}
+
+	if workout, err := s.workoutRepo.GetWorkoutByUserID(ctx, userID, workoutID); workout == nil || err != nil {
+		log.Println("Workout not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Workout not found",
+		}
+	}
+
+	template := &models.WorkoutTemplate{
+		UserID: userID,
+		Name:   strings.TrimSpace(req.Name),
+		Notes:  req.Notes,
+	}
+
+	if err := s.templateRepo.CreateTemplateFromWorkout(ctx, template, workoutID); err != nil {
+		return nil, templateWriteError(err, "Failed to create template")
+	}
+
+	return s.GetTemplate(ctx, template.ID)
+}
+
+func (s *WorkoutTemplateService) GetTemplates(ctx context.Context) (*[]models.WorkoutTemplate, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	templates, err := s.templateRepo.GetTemplatesByUserID(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get templates",
+			}
+		}
+	}
+
+	if templates == nil || len(*templates) == 0 {
+		log.Println("Templates not found")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Templates not found",
+		}
+	}
+
+	return templates, nil
+}
+
+func (s *WorkoutTemplateService) GetTemplate(ctx context.Context, templateID int) (*models.WorkoutTemplate, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	template, err := s.templateRepo.GetTemplateByUserID(ctx, userID, templateID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		case errors.Is(err, sql.ErrNoRows):
+			log.Println("Template not found:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Template not found",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to get template",
+			}
+		}
+	}
+
+	return template, nil
+}
+
+func (s *WorkoutTemplateService) UpdateTemplate(ctx context.Context, templateID int, req *models.WorkoutTemplateRequest) (*models.WorkoutTemplate, error) {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return nil, &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	if err := validateWorkoutTemplateRequest(req); err != nil {
+		return nil, err
+	}
+
+	template := newWorkoutTemplate(userID, req)
+	template.ID = templateID
+
+	if err := s.templateRepo.UpdateTemplate(ctx, template); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Println("Template not found:", err)
+			return nil, &apperrors.AppError{
+				Code:    http.StatusNotFound,
+				Message: "Template not found",
+			}
+		}
+		return nil, templateWriteError(err, "Failed to update template")
+	}
+
+	return template, nil
+}
+
+func (s *WorkoutTemplateService) DeleteTemplate(ctx context.Context, templateID int) error {
+	userID, ok := ctx.Value("user_id").(int)
+	if !ok {
+		log.Println("Unauthorized")
+		return &apperrors.AppError{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		}
+	}
+
+	rowsAffected, err := s.templateRepo.DeleteTemplate(ctx, userID, templateID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+			log.Println("Request cancelled:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Request cancelled",
+			}
+
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Println("Deadline exceeded:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusGatewayTimeout,
+				Message: "Request timeout",
+			}
+
+		default:
+			log.Println("Unhandled error:", err)
+			return &apperrors.AppError{
+				Code:    http.StatusInternalServerError,
+				Message: "Failed to delete template",
+			}
+		}
+	}
+
+	if rowsAffected == 0 {
+		log.Println("Template not found")
+		return &apperrors.AppError{
+			Code:    http.StatusNotFound,
+			Message: "Template not found",
+		}
+	}
+
+	return nil
+}
+
+func templateWriteError(err error, message string) error {
+	var pgErr *pq.Error
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrUniqueViolation:
+		log.Println("Unique violation:", pgErr)
+		return &apperrors.AppError{
+			Code:    http.StatusConflict,
+			Message: "Template with this name already exists",
+		}
+
+	case errors.As(err, &pgErr) && pgErr.Code == apperrors.PgErrForeignKeyViolation:
+		log.Println("Foreign key violation:", pgErr)
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Incorrect exercise id",
+		}
+
+	default:
+		return apperrors.Wrap(err, message)
+	}
+}
+
+func validateWorkoutTemplateRequest(req *models.WorkoutTemplateRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Template name is required",
+		}
+	}
+
+	if len(req.Exercises) == 0 {
+		return &apperrors.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "Template must contain at least one exercise",
+		}
+	}
+
+	for _, exercise := range req.Exercises {
+		switch {
+		case exercise.ExerciseID < 1:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid exercise id",
+			}
+
+		case exercise.TargetSets < 1 || (exercise.TargetReps != nil && *exercise.TargetReps < 1):
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Target sets and reps must be greater than zero",
+			}
+
+		case exercise.TargetWeight < 0:
+			return &apperrors.AppError{
+				Code:    http.StatusBadRequest,
+				Message: "Target weight must not be negative",
+			}
+		}
+	}
+
+	return nil
+}
+
+func newWorkoutTemplate(userID int, req *models.WorkoutTemplateRequest) *models.WorkoutTemplate {
+	template := &models.WorkoutTemplate{
+		UserID: userID,
+		Name:   strings.TrimSpace(req.Name),
+		Notes:  req.Notes,
+	}
+
+	for _, exercise := range req.Exercises {
+		template.Exercises = append(template.Exercises, models.WorkoutTemplateExercise{
+			ExerciseID:   exercise.ExerciseID,
+			TargetSets:   exercise.TargetSets,
+			TargetReps:   exercise.TargetReps,
+			TargetWeight: exercise.TargetWeight,
+			Notes:        exercise.Notes,
+		})
+	}
+
+	return template
+}
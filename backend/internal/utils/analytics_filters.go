@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"backend/internal/models"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var allowedBuckets = map[string]bool{
+	models.BucketDay:   true,
+	models.BucketWeek:  true,
+	models.BucketMonth: true,
+}
+
+func ParseProgressionFilter(r *http.Request) (*models.ProgressionFilter, error) {
+	q := r.URL.Query()
+	f := models.ProgressionFilter{
+		Formula: FormulaEpley,
+		Bucket:  models.BucketDay,
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		return nil, err
+	}
+	f.From = from
+	f.To = to
+
+	if v := q.Get("formula"); v != "" {
+		if !IsOneRepMaxFormula(v) {
+			return nil, errors.New("unknown formula, use epley, brzycki or lombardi")
+		}
+		f.Formula = v
+	}
+
+	if v := q.Get("bucket"); v != "" {
+		if !allowedBuckets[v] {
+			return nil, errors.New("unknown bucket, use day, week or month")
+		}
+		f.Bucket = v
+	}
+
+	return &f, nil
+}
+
+// ParseMuscleVolumeFilter defaults to the last four weeks including the current one.
+func ParseMuscleVolumeFilter(r *http.Request) (*models.DateRangeFilter, error) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	f := models.DateRangeFilter{
+		To: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	if to != nil {
+		f.To = *to
+	}
+
+	if from != nil {
+		f.From = *from
+	} else {
+		weekStart := f.To.AddDate(0, 0, -(int(f.To.Weekday())+6)%7)
+		f.From = weekStart.AddDate(0, 0, -21)
+	}
+
+	if f.From.After(f.To) {
+		return nil, errors.New("from date is after to date")
+	}
+
+	return &f, nil
+}
+
+func parseDateRange(r *http.Request) (*time.Time, *time.Time, error) {
+	q := r.URL.Query()
+	var from, to *time.Time
+
+	if v := q.Get("from"); v != "" {
+		date, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid from date, use YYYY-MM-DD")
+		}
+		from = &date
+	}
+
+	if v := q.Get("to"); v != "" {
+		date, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid to date, use YYYY-MM-DD")
+		}
+		to = &date
+	}
+
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, errors.New("from date is after to date")
+	}
+
+	return from, to, nil
+}
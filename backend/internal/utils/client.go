@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP prefers the address set by nginx, requests reach the API only
+// through the proxy.
+func ClientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
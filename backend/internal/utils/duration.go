@@ -0,0 +1,13 @@
+package utils
+
+import "time"
+
+// DurationSeconds returns nil until both ends of the interval are known.
+func DurationSeconds(startedAt, endedAt *time.Time) *int {
+	if startedAt == nil || endedAt == nil || endedAt.Before(*startedAt) {
+		return nil
+	}
+
+	duration := int(endedAt.Sub(*startedAt) / time.Second)
+	return &duration
+}
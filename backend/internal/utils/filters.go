@@ -2,8 +2,10 @@ package utils
 
 import (
 	"backend/internal/models"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 const (
@@ -12,6 +14,8 @@ const (
 	defaultPage   = 1
 	defaultSortBy = "name"
 	defaultOrder  = "asc"
+
+	defaultWorkoutOrder = "desc"
 )
 
 var allowedSortBy = map[string]bool{
@@ -70,3 +74,130 @@ func ParseExerciseFilter(r *http.Request) *models.ExerciseFilter {
 	return &f
 
 }
+
+// ParseWorkoutFilter accepts RFC 3339 timestamps or YYYY-MM-DD dates, a date
+// in "to" includes the whole day.
+func ParseWorkoutFilter(r *http.Request) (*models.WorkoutFilter, error) {
+	q := r.URL.Query()
+	var f models.WorkoutFilter
+
+	if v := q.Get("from"); v != "" {
+		from, err := parseTimeOrDate(v, false)
+		if err != nil {
+			return nil, errors.New("invalid from, use RFC 3339 time or YYYY-MM-DD")
+		}
+		f.From = &from
+	}
+
+	if v := q.Get("to"); v != "" {
+		to, err := parseTimeOrDate(v, true)
+		if err != nil {
+			return nil, errors.New("invalid to, use RFC 3339 time or YYYY-MM-DD")
+		}
+		f.To = &to
+	}
+
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return nil, errors.New("from is after to")
+	}
+
+	if v := q.Get("exercise_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			return nil, errors.New("invalid exercise_id")
+		}
+		f.ExerciseID = &id
+	}
+
+	if v := q.Get("search"); v != "" {
+		f.Search = &v
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 && l <= maxLimit {
+			f.Limit = l
+		}
+	}
+
+	if f.Limit == 0 {
+		f.Limit = defaultLimit
+	}
+
+	if v := q.Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			f.Page = p
+		}
+	}
+
+	if f.Page == 0 {
+		f.Page = defaultPage
+	}
+
+	f.Offset = (f.Page - 1) * f.Limit
+
+	if v := q.Get("sort_order"); v == "asc" {
+		f.SortOrder = v
+	} else {
+		f.SortOrder = defaultWorkoutOrder
+	}
+
+	return &f, nil
+}
+
+func parseTimeOrDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if endOfDay {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return date, nil
+}
+
+func ParseUserFilter(r *http.Request) (*models.UserFilter, error) {
+	q := r.URL.Query()
+	var f models.UserFilter
+
+	if v := q.Get("search"); v != "" {
+		f.Search = &v
+	}
+
+	if v := q.Get("is_active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid is_active, use true or false")
+		}
+		f.IsActive = &active
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 && l <= maxLimit {
+			f.Limit = l
+		}
+	}
+
+	if f.Limit == 0 {
+		f.Limit = defaultLimit
+	}
+
+	if v := q.Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			f.Page = p
+		}
+	}
+
+	if f.Page == 0 {
+		f.Page = defaultPage
+	}
+
+	f.Offset = (f.Page - 1) * f.Limit
+
+	return &f, nil
+}
@@ -0,0 +1,46 @@
+package utils
+
+import "math"
+
+const (
+	FormulaEpley    = "epley"
+	FormulaBrzycki  = "brzycki"
+	FormulaLombardi = "lombardi"
+)
+
+var allowedFormulas = map[string]bool{
+	FormulaEpley:    true,
+	FormulaBrzycki:  true,
+	FormulaLombardi: true,
+}
+
+func IsOneRepMaxFormula(formula string) bool {
+	return allowedFormulas[formula]
+}
+
+func EstimateOneRepMax(weight float64, reps int) float64 {
+	return EstimateOneRepMaxWith(FormulaEpley, weight, reps)
+}
+
+func EstimateOneRepMaxWith(formula string, weight float64, reps int) float64 {
+	if weight <= 0 || reps < 1 {
+		return 0
+	}
+
+	if reps == 1 {
+		return weight
+	}
+
+	var estimate float64
+	switch formula {
+	case FormulaBrzycki:
+		// Brzycki is undefined at 37 reps and above
+		estimate = weight * 36 / (37 - math.Min(float64(reps), 36))
+	case FormulaLombardi:
+		estimate = weight * math.Pow(float64(reps), 0.1)
+	default:
+		estimate = weight * (1 + float64(reps)/30)
+	}
+
+	return math.Round(estimate*100) / 100
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateOneRepMaxWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		weight  float64
+		reps    int
+		want    float64
+	}{
+		{"epley", FormulaEpley, 100, 5, 116.67},
+		{"brzycki", FormulaBrzycki, 100, 5, 112.5},
+		{"lombardi", FormulaLombardi, 100, 5, 117.46},
+		{"unknown formula falls back to epley", "unknown", 100, 5, 116.67},
+		{"single rep is the weight", FormulaBrzycki, 140, 1, 140},
+		{"brzycki is capped at 36 reps", FormulaBrzycki, 20, 40, 720},
+		{"no reps", FormulaEpley, 100, 0, 0},
+		{"no weight", FormulaEpley, 0, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EstimateOneRepMaxWith(tt.formula, tt.weight, tt.reps))
+		})
+	}
+}
+
+func TestEstimateOneRepMaxUsesEpley(t *testing.T) {
+	assert.Equal(t, EstimateOneRepMaxWith(FormulaEpley, 80, 8), EstimateOneRepMax(80, 8))
+}
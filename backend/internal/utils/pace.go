@@ -0,0 +1,13 @@
+package utils
+
+import "math"
+
+// PaceSecondsPerKm returns nil when duration or distance is not logged.
+func PaceSecondsPerKm(durationSeconds *int, distanceMeters *float64) *float64 {
+	if durationSeconds == nil || distanceMeters == nil || *distanceMeters <= 0 {
+		return nil
+	}
+
+	pace := math.Round(float64(*durationSeconds)/(*distanceMeters/1000)*100) / 100
+	return &pace
+}
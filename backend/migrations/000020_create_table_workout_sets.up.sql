@@ -0,0 +1,18 @@
+CREATE TABLE WorkoutSets (
+    id SERIAL PRIMARY KEY,
+    workout_exercise_id BIGINT NOT NULL REFERENCES WorkoutExercises(id) ON DELETE CASCADE,
+    set_number INTEGER NOT NULL CHECK (set_number > 0),
+    set_type VARCHAR(20) NOT NULL DEFAULT 'working' CHECK (set_type IN ('warmup', 'working', 'drop', 'failure')),
+    reps BIGINT NOT NULL CHECK (reps > 0),
+    weight numeric(5,1) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    rpe numeric(3,1) DEFAULT NULL CHECK (rpe >= 1 AND rpe <= 10),
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT unique_workout_set_number UNIQUE (workout_exercise_id, set_number)
+);
+
+INSERT INTO WorkoutSets (workout_exercise_id, set_number, set_type, reps, weight)
+SELECT we.id, gs.set_number, 'working', we.reps, COALESCE(we.weight, 0)
+FROM WorkoutExercises we
+CROSS JOIN LATERAL generate_series(1, we.sets) AS gs(set_number)
+WHERE we.sets IS NOT NULL
+AND we.reps IS NOT NULL;
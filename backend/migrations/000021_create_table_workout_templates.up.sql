@@ -0,0 +1,23 @@
+CREATE TABLE WorkoutTemplates (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id),
+    name VARCHAR(100) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    is_active BOOL DEFAULT TRUE
+);
+
+CREATE UNIQUE INDEX unique_workout_template_name ON WorkoutTemplates (user_id, name) WHERE is_active = true;
+
+CREATE TABLE WorkoutTemplateExercises (
+    id SERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES WorkoutTemplates(id) ON DELETE CASCADE,
+    exercise_id BIGINT NOT NULL REFERENCES Exercises(id),
+    position INTEGER NOT NULL CHECK (position > 0),
+    target_sets BIGINT NOT NULL CHECK (target_sets > 0),
+    target_reps BIGINT NOT NULL CHECK (target_reps > 0),
+    target_weight numeric(5,1) NOT NULL DEFAULT 0 CHECK (target_weight >= 0),
+    notes TEXT,
+    CONSTRAINT unique_template_exercise_position UNIQUE (template_id, position)
+);
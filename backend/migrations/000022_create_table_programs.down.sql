@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ScheduledWorkouts;
+DROP TABLE IF EXISTS ProgramAssignments;
+DROP TABLE IF EXISTS ProgramWorkouts;
+DROP TABLE IF EXISTS Programs;
+DROP TABLE IF EXISTS TrainerClients;
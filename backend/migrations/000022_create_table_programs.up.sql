@@ -0,0 +1,54 @@
+CREATE TABLE TrainerClients (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT NOT NULL REFERENCES Users(id),
+    client_id BIGINT NOT NULL REFERENCES Users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    accepted_at TIMESTAMP,
+    is_active BOOL DEFAULT TRUE,
+    CONSTRAINT trainer_client_differ CHECK (trainer_id <> client_id)
+);
+
+CREATE UNIQUE INDEX unique_trainer_client ON TrainerClients (trainer_id, client_id) WHERE is_active = true;
+
+CREATE TABLE Programs (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT NOT NULL REFERENCES Users(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    weeks INTEGER NOT NULL CHECK (weeks > 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    is_active BOOL DEFAULT TRUE
+);
+
+CREATE UNIQUE INDEX unique_program_name ON Programs (trainer_id, name) WHERE is_active = true;
+
+CREATE TABLE ProgramWorkouts (
+    id SERIAL PRIMARY KEY,
+    program_id BIGINT NOT NULL REFERENCES Programs(id) ON DELETE CASCADE,
+    template_id BIGINT NOT NULL REFERENCES WorkoutTemplates(id),
+    week INTEGER NOT NULL CHECK (week > 0),
+    day INTEGER NOT NULL CHECK (day BETWEEN 1 AND 7),
+    notes TEXT,
+    CONSTRAINT unique_program_workout_day UNIQUE (program_id, week, day)
+);
+
+CREATE TABLE ProgramAssignments (
+    id SERIAL PRIMARY KEY,
+    program_id BIGINT NOT NULL REFERENCES Programs(id),
+    trainer_id BIGINT NOT NULL REFERENCES Users(id),
+    client_id BIGINT NOT NULL REFERENCES Users(id),
+    start_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    is_active BOOL DEFAULT TRUE
+);
+
+CREATE TABLE ScheduledWorkouts (
+    id SERIAL PRIMARY KEY,
+    assignment_id BIGINT NOT NULL REFERENCES ProgramAssignments(id) ON DELETE CASCADE,
+    workout_id BIGINT NOT NULL REFERENCES Workouts(id),
+    week INTEGER NOT NULL,
+    day INTEGER NOT NULL,
+    scheduled_date DATE NOT NULL,
+    CONSTRAINT unique_scheduled_workout UNIQUE (workout_id)
+);
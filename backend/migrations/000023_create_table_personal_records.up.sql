@@ -0,0 +1,16 @@
+CREATE TABLE PersonalRecords (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id),
+    exercise_id BIGINT NOT NULL REFERENCES Exercises(id),
+    record_type VARCHAR(20) NOT NULL CHECK (record_type IN ('max_weight', 'reps_at_weight', 'estimated_1rm', 'max_volume')),
+    value numeric(10,2) NOT NULL,
+    weight numeric(5,1) NOT NULL DEFAULT 0,
+    reps BIGINT NOT NULL DEFAULT 0,
+    workout_id BIGINT NOT NULL REFERENCES Workouts(id),
+    workout_exercise_id BIGINT NOT NULL REFERENCES WorkoutExercises(id) ON DELETE CASCADE,
+    achieved_at DATE NOT NULL,
+    is_current BOOL NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_personal_records_user_exercise ON PersonalRecords (user_id, exercise_id);
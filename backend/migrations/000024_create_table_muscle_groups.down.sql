@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ExerciseMuscleGroups;
+DROP TABLE IF EXISTS MuscleGroups;
@@ -0,0 +1,96 @@
+CREATE TABLE MuscleGroups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    slug VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE ExerciseMuscleGroups (
+    exercise_id INTEGER NOT NULL REFERENCES Exercises(id) ON DELETE CASCADE,
+    muscle_group_id INTEGER NOT NULL REFERENCES MuscleGroups(id) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (exercise_id, muscle_group_id)
+);
+
+CREATE INDEX idx_exercise_muscle_groups_muscle_group ON ExerciseMuscleGroups (muscle_group_id);
+
+INSERT INTO MuscleGroups (name, slug) VALUES
+    ('Грудь', 'chest'),
+    ('Спина', 'back'),
+    ('Трапеции', 'traps'),
+    ('Плечи', 'shoulders'),
+    ('Бицепс', 'biceps'),
+    ('Трицепс', 'triceps'),
+    ('Предплечья', 'forearms'),
+    ('Пресс', 'abs'),
+    ('Квадрицепсы', 'quads'),
+    ('Бицепс бедра', 'hamstrings'),
+    ('Ягодицы', 'glutes'),
+    ('Икры', 'calves');
+
+INSERT INTO ExerciseMuscleGroups (exercise_id, muscle_group_id, is_primary)
+SELECT e.id, mg.id, m.is_primary
+FROM (VALUES
+    ('Жим лежа', 'chest', TRUE),
+    ('Жим лежа', 'triceps', FALSE),
+    ('Жим лежа', 'shoulders', FALSE),
+    ('Становая тяга', 'back', TRUE),
+    ('Становая тяга', 'hamstrings', TRUE),
+    ('Становая тяга', 'glutes', TRUE),
+    ('Становая тяга', 'traps', FALSE),
+    ('Становая тяга', 'forearms', FALSE),
+    ('Присед', 'quads', TRUE),
+    ('Присед', 'glutes', TRUE),
+    ('Присед', 'hamstrings', FALSE),
+    ('Жим стоя', 'shoulders', TRUE),
+    ('Жим стоя', 'triceps', FALSE),
+    ('Подтягивания', 'back', TRUE),
+    ('Подтягивания', 'biceps', FALSE),
+    ('Тяга штанги в наклоне', 'back', TRUE),
+    ('Тяга штанги в наклоне', 'biceps', FALSE),
+    ('Жим гантелей лежа', 'chest', TRUE),
+    ('Жим гантелей лежа', 'triceps', FALSE),
+    ('Жим гантелей лежа', 'shoulders', FALSE),
+    ('Румынская тяга', 'hamstrings', TRUE),
+    ('Румынская тяга', 'glutes', TRUE),
+    ('Румынская тяга', 'back', FALSE),
+    ('Фронтальные приседания', 'quads', TRUE),
+    ('Фронтальные приседания', 'glutes', FALSE),
+    ('Подъем штанги на бицепс', 'biceps', TRUE),
+    ('Подъем штанги на бицепс', 'forearms', FALSE),
+    ('Жим ногами', 'quads', TRUE),
+    ('Жим ногами', 'glutes', FALSE),
+    ('Тяга верхнего блока', 'back', TRUE),
+    ('Тяга верхнего блока', 'biceps', FALSE),
+    ('Шраги со штангой', 'traps', TRUE),
+    ('Разгибания на трицепс', 'triceps', TRUE),
+    ('Болгарские выпады', 'quads', TRUE),
+    ('Болгарские выпады', 'glutes', TRUE),
+    ('Армейский жим', 'shoulders', TRUE),
+    ('Армейский жим', 'triceps', FALSE),
+    ('Тяга Т-грифа', 'back', TRUE),
+    ('Тяга Т-грифа', 'biceps', FALSE),
+    ('Подъем гантелей через стороны', 'shoulders', TRUE),
+    ('Сгибания Зоттмана', 'biceps', TRUE),
+    ('Сгибания Зоттмана', 'forearms', FALSE),
+    ('Разгибания рук в кроссовере', 'triceps', TRUE),
+    ('Гакк-приседания', 'quads', TRUE),
+    ('Гакк-приседания', 'glutes', FALSE),
+    ('Ягодичный мостик со штангой', 'glutes', TRUE),
+    ('Ягодичный мостик со штангой', 'hamstrings', FALSE),
+    ('Пуловер с гантелью', 'chest', TRUE),
+    ('Пуловер с гантелью', 'back', FALSE),
+    ('Подъем на носки стоя', 'calves', TRUE),
+    ('Французский жим лежа', 'triceps', TRUE),
+    ('Тяга гири к подбородку', 'shoulders', TRUE),
+    ('Тяга гири к подбородку', 'traps', FALSE),
+    ('Приседания Зерчера', 'quads', TRUE),
+    ('Приседания Зерчера', 'glutes', FALSE),
+    ('Приседания Зерчера', 'abs', FALSE),
+    ('Жим Арнольда', 'shoulders', TRUE),
+    ('Жим Арнольда', 'triceps', FALSE),
+    ('Сисси-приседания', 'quads', TRUE),
+    ('Тяга нижнего блока', 'back', TRUE),
+    ('Тяга нижнего блока', 'biceps', FALSE)
+) AS m (exercise_name, muscle_slug, is_primary)
+INNER JOIN Exercises e ON e.name = m.exercise_name AND e.is_active = TRUE
+INNER JOIN MuscleGroups mg ON mg.slug = m.muscle_slug;
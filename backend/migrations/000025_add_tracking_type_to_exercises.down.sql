@@ -0,0 +1,14 @@
+DELETE FROM WorkoutSets WHERE reps IS NULL;
+
+ALTER TABLE WorkoutSets
+    DROP COLUMN heart_rate_avg,
+    DROP COLUMN distance_meters,
+    DROP COLUMN duration_seconds,
+    ALTER COLUMN reps SET NOT NULL;
+
+ALTER TABLE WorkoutExercises
+    DROP COLUMN heart_rate_avg,
+    DROP COLUMN distance_meters,
+    DROP COLUMN duration_seconds;
+
+ALTER TABLE Exercises DROP COLUMN tracking_type;
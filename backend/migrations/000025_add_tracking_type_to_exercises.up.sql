@@ -0,0 +1,32 @@
+ALTER TABLE Exercises
+    ADD COLUMN tracking_type VARCHAR(30) NOT NULL DEFAULT 'weight_reps'
+    CHECK (tracking_type IN ('weight_reps', 'reps_only', 'duration', 'distance_duration', 'bodyweight_plus'));
+
+ALTER TABLE WorkoutExercises
+    ADD COLUMN duration_seconds INTEGER DEFAULT NULL CHECK (duration_seconds > 0),
+    ADD COLUMN distance_meters numeric(9,2) DEFAULT NULL CHECK (distance_meters > 0),
+    ADD COLUMN heart_rate_avg INTEGER DEFAULT NULL CHECK (heart_rate_avg BETWEEN 30 AND 250);
+
+ALTER TABLE WorkoutSets
+    ALTER COLUMN reps DROP NOT NULL,
+    ADD COLUMN duration_seconds INTEGER DEFAULT NULL CHECK (duration_seconds > 0),
+    ADD COLUMN distance_meters numeric(9,2) DEFAULT NULL CHECK (distance_meters > 0),
+    ADD COLUMN heart_rate_avg INTEGER DEFAULT NULL CHECK (heart_rate_avg BETWEEN 30 AND 250);
+
+UPDATE Exercises SET tracking_type = 'distance_duration'
+WHERE name IN ('Бег', 'Велосипед', 'Гребля', 'Интервальный бег', 'Плавание', 'Гребной тренажер',
+    'Спринтерские интервалы', 'Лыжный тренажер', 'Фартлек', 'Бег в гору', 'Ходьба на беговой дорожке с уклоном',
+    'Ходьба с утяжелением', 'Фермерская прогулка', 'Прогулка фермера с гирями');
+
+UPDATE Exercises SET tracking_type = 'duration'
+WHERE category_id = (SELECT id FROM Categories WHERE slug = 'gibkost')
+OR name IN ('Скакалка', 'Эллипсоид', 'Степпер', 'Бег по лестнице', 'Гребля с сопротивлением', 'Кросс-тренинг',
+    'Бокс на груше', 'Скалолазание', 'Кикбоксинг', 'Прыжки на скакалке с утяжелением', 'Тренировка TABATA',
+    'Бег с парашютом', 'Прогулка медведя');
+
+UPDATE Exercises SET tracking_type = 'reps_only'
+WHERE name IN ('Берпи', 'Берпи с прыжком', 'Прыжки на тумбу', 'Прыжки в длину', 'Прыжки через барьеры',
+    'Отжимания с хлопком', 'Плиометрические отжимания', 'Прыжки на бокс с разворотом', 'Сисси-приседания');
+
+UPDATE Exercises SET tracking_type = 'bodyweight_plus'
+WHERE name IN ('Подтягивания', 'Подъем по канату', 'Подъемы по канату без ног');
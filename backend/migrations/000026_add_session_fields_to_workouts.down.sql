@@ -0,0 +1,6 @@
+ALTER TABLE Workouts
+    DROP CONSTRAINT IF EXISTS workouts_ended_after_started,
+    DROP COLUMN IF EXISTS total_volume,
+    DROP COLUMN IF EXISTS total_sets,
+    DROP COLUMN IF EXISTS ended_at,
+    DROP COLUMN IF EXISTS started_at;
@@ -0,0 +1,6 @@
+ALTER TABLE Workouts
+    ADD COLUMN started_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN ended_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN total_sets INTEGER DEFAULT NULL CHECK (total_sets >= 0),
+    ADD COLUMN total_volume numeric(12,2) DEFAULT NULL CHECK (total_volume >= 0),
+    ADD CONSTRAINT workouts_ended_after_started CHECK (ended_at IS NULL OR ended_at >= started_at);
@@ -0,0 +1,4 @@
+ALTER TABLE WorkoutExercises
+    DROP CONSTRAINT IF EXISTS workout_exercises_ended_after_started,
+    DROP COLUMN IF EXISTS ended_at,
+    DROP COLUMN IF EXISTS started_at;
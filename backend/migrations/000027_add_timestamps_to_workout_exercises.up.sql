@@ -0,0 +1,4 @@
+ALTER TABLE WorkoutExercises
+    ADD COLUMN started_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN ended_at TIMESTAMP DEFAULT NULL,
+    ADD CONSTRAINT workout_exercises_ended_after_started CHECK (ended_at IS NULL OR ended_at >= started_at);
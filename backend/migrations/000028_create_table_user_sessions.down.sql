@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS RefreshTokens;
+DROP TABLE IF EXISTS UserSessions;
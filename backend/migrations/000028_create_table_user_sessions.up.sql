@@ -0,0 +1,20 @@
+CREATE TABLE UserSessions (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id) ON DELETE CASCADE,
+    user_agent TEXT,
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_used_at TIMESTAMP DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_sessions_user_id ON UserSessions (user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE RefreshTokens (
+    id SERIAL PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES UserSessions(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE Users ADD COLUMN token_version INT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS UserTokens;
+
+ALTER TABLE Users DROP COLUMN IF EXISTS email_verified_at;
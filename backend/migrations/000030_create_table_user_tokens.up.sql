@@ -0,0 +1,15 @@
+ALTER TABLE Users ADD COLUMN email_verified_at TIMESTAMP;
+
+UPDATE Users SET email_verified_at = created_at;
+
+CREATE TABLE UserTokens (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id) ON DELETE CASCADE,
+    purpose VARCHAR(32) NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_tokens_user_purpose ON UserTokens (user_id, purpose);
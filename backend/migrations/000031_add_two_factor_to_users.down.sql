@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS UserRecoveryCodes;
+
+ALTER TABLE Users
+    DROP COLUMN IF EXISTS totp_enabled_at,
+    DROP COLUMN IF EXISTS totp_secret;
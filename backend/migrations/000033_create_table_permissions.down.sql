@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS RolePermissions;
+DROP TABLE IF EXISTS Permissions;
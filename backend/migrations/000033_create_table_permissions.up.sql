@@ -0,0 +1,29 @@
+CREATE TABLE Permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    description TEXT
+);
+
+CREATE TABLE RolePermissions (
+    role_id BIGINT NOT NULL REFERENCES Roles(id) ON DELETE CASCADE,
+    permission_id BIGINT NOT NULL REFERENCES Permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO Permissions (name, description) VALUES
+    ('exercises:write', 'Создание, изменение и удаление упражнений'),
+    ('categories:write', 'Создание, изменение и удаление категорий'),
+    ('clients:manage', 'Работа с клиентами и назначение программ тренировок'),
+    ('roles:manage', 'Создание ролей, изменение их прав и назначение ролей пользователям');
+
+INSERT INTO RolePermissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM Roles r
+JOIN Permissions p ON (r.name, p.name) IN (
+    ('admin', 'exercises:write'),
+    ('admin', 'categories:write'),
+    ('admin', 'clients:manage'),
+    ('admin', 'roles:manage'),
+    ('moderator', 'exercises:write'),
+    ('trainer', 'clients:manage')
+);
@@ -0,0 +1,5 @@
+DELETE FROM Permissions WHERE name = 'users:manage';
+
+DROP INDEX IF EXISTS idx_user_sessions_user_created;
+
+ALTER TABLE Users DROP COLUMN IF EXISTS password_reset_required;
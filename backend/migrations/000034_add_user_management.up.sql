@@ -0,0 +1,12 @@
+ALTER TABLE Users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_user_sessions_user_created ON UserSessions (user_id, created_at DESC);
+
+INSERT INTO Permissions (name, description) VALUES
+    ('users:manage', 'Поиск, блокировка и разблокировка пользователей, принудительный сброс пароля');
+
+INSERT INTO RolePermissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM Roles r, Permissions p
+WHERE r.name = 'admin'
+AND p.name = 'users:manage';
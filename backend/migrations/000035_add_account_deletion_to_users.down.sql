@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_purge_at;
+
+ALTER TABLE Users
+    DROP COLUMN IF EXISTS purged_at,
+    DROP COLUMN IF EXISTS purge_at;
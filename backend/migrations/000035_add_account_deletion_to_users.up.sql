@@ -0,0 +1,5 @@
+ALTER TABLE Users
+    ADD COLUMN purge_at TIMESTAMP,
+    ADD COLUMN purged_at TIMESTAMP;
+
+CREATE INDEX idx_users_purge_at ON Users (purge_at) WHERE purge_at IS NOT NULL AND purged_at IS NULL;
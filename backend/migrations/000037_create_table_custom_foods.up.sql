@@ -0,0 +1,24 @@
+CREATE TABLE CustomFoods (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id),
+    name VARCHAR(100) NOT NULL,
+    brand VARCHAR(100) NOT NULL DEFAULT '',
+    barcode VARCHAR(50) NOT NULL DEFAULT '',
+    serving_qty FLOAT NOT NULL DEFAULT 1 CHECK (serving_qty > 0),
+    serving_unit VARCHAR(20) NOT NULL DEFAULT '',
+    serving_weight_grams FLOAT CHECK (serving_weight_grams > 0),
+    calories_100g FLOAT,
+    protein_100g FLOAT,
+    carbs_100g FLOAT,
+    fat_100g FLOAT,
+    calories_serving FLOAT,
+    protein_serving FLOAT,
+    carbs_serving FLOAT,
+    fat_serving FLOAT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    is_active BOOL DEFAULT TRUE,
+    CONSTRAINT custom_food_has_nutrition CHECK (calories_100g IS NOT NULL OR calories_serving IS NOT NULL)
+);
+
+CREATE INDEX idx_custom_foods_user_id ON CustomFoods (user_id) WHERE is_active = true;
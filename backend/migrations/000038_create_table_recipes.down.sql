@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS RecipeIngredients;
+DROP TABLE IF EXISTS Recipes;
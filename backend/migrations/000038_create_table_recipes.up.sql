@@ -0,0 +1,27 @@
+CREATE TABLE Recipes (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES Users(id),
+    name VARCHAR(100) NOT NULL,
+    servings FLOAT NOT NULL CHECK (servings > 0),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    is_active BOOL DEFAULT TRUE
+);
+
+CREATE INDEX idx_recipes_user_id ON Recipes (user_id) WHERE is_active = true;
+
+CREATE TABLE RecipeIngredients (
+    id SERIAL PRIMARY KEY,
+    recipe_id BIGINT NOT NULL REFERENCES Recipes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    custom_food_id BIGINT REFERENCES CustomFoods(id),
+    provider_food_id VARCHAR(100) NOT NULL DEFAULT '',
+    name VARCHAR(100) NOT NULL,
+    weight_grams FLOAT NOT NULL CHECK (weight_grams > 0),
+    calories FLOAT NOT NULL,
+    protein FLOAT NOT NULL,
+    carbs FLOAT NOT NULL,
+    fat FLOAT NOT NULL,
+    CONSTRAINT unique_recipe_ingredient_position UNIQUE (recipe_id, position)
+);
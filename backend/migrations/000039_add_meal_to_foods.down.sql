@@ -0,0 +1,4 @@
+ALTER TABLE Foods
+    DROP COLUMN meal_type,
+    DROP COLUMN meal_name,
+    DROP COLUMN eaten_at;
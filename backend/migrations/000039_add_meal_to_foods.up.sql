@@ -0,0 +1,5 @@
+ALTER TABLE Foods
+    ADD COLUMN meal_type VARCHAR(20) NOT NULL DEFAULT 'unspecified'
+        CHECK (meal_type IN ('breakfast', 'lunch', 'dinner', 'snack', 'custom', 'unspecified')),
+    ADD COLUMN meal_name VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN eaten_at TIME;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_workouts_user_started_at ON Workouts (user_id, started_at);
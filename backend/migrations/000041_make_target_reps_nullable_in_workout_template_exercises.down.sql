@@ -0,0 +1,3 @@
+UPDATE WorkoutTemplateExercises SET target_reps = 1 WHERE target_reps IS NULL;
+
+ALTER TABLE WorkoutTemplateExercises ALTER COLUMN target_reps SET NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE WorkoutTemplateExercises ALTER COLUMN target_reps DROP NOT NULL;
@@ -0,0 +1,5 @@
+UPDATE DataExports SET status = 'failed', completed_at = NOW()
+WHERE status = 'pending'
+AND id NOT IN (SELECT MAX(id) FROM DataExports WHERE status = 'pending' GROUP BY user_id);
+
+CREATE UNIQUE INDEX unique_pending_data_export ON DataExports (user_id) WHERE status = 'pending';
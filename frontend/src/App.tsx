@@ -11,6 +11,8 @@ import Workouts from "./pages/Workouts";
 import { useAuth } from "./hooks/useAuth";
 import WorkoutDetails from "./pages/WorkoutDetails";
 import Nutrition from "./pages/Nutrition";
+import VerifyEmail from "./pages/VerifyEmail";
+import ResetPassword from "./pages/ResetPassword";
 
 export default function App() {
   const { user, loading } = useAuth()
@@ -40,6 +42,8 @@ export default function App() {
             path='/register'
             element={user ? <Navigate to='/' /> : <Register />}
           />
+          <Route path='/verify-email' element={<VerifyEmail />} />
+          <Route path='/reset-password' element={<ResetPassword />} />
           <Route
             path='/exercises'
             element={user ? <Exercises /> : <Navigate to='/login' />} 
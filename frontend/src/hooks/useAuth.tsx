@@ -5,6 +5,25 @@ import { API_URL } from "../config";
 
 
 
+function tooManyAttemptsMessage(response: Response) {
+    const seconds = Number(response.headers.get('Retry-After'))
+    if (!seconds) return 'Слишком много попыток входа, попробуйте позже'
+
+    const minutes = Math.ceil(seconds / 60)
+    return `Слишком много попыток входа, попробуйте через ${minutes} мин.`
+}
+
+function loginForbiddenMessage(message: string) {
+    switch (message) {
+        case 'Account is deactivated':
+            return 'Аккаунт заблокирован администратором'
+        case 'Password reset required':
+            return 'Необходимо сбросить пароль, ссылка отправлена на почту'
+        default:
+            return 'Email не подтверждён, проверьте почту'
+    }
+}
+
 export function useAuth() {
     const [user, setUser] = useState<User | null>(null)
     const [loading, setLoading] = useState(true)
@@ -18,10 +37,23 @@ export function useAuth() {
 
     const checkAuth = async () => {
         try {
-            const response = await fetch(`${API_URL}/users/me`, {
+            let response = await fetch(`${API_URL}/users/me`, {
                 credentials: 'include'
             })
 
+            if (response.status === 401 || response.status === 403) {
+                const refresh = await fetch(`${API_URL}/auth/refresh`, {
+                    method: 'POST',
+                    credentials: 'include',
+                })
+
+                if (refresh.ok) {
+                    response = await fetch(`${API_URL}/users/me`, {
+                        credentials: 'include'
+                    })
+                }
+            }
+
             if (response.ok) {
                 const userData = await response.json()
                 setUser(userData)
@@ -47,16 +79,55 @@ export function useAuth() {
                 body: JSON.stringify({ email, password })
             })
 
-            if (!response.ok) throw new Error('Ошибка авторизации')
+            if (response.status === 429) throw new Error(tooManyAttemptsMessage(response))
+            if (response.status === 403) {
+                const data = await response.json()
+                throw new Error(loginForbiddenMessage(data.message))
+            }
+            if (!response.ok) throw new Error('Неверный email или пароль')
+
+            const data = await response.json()
+            if (data.two_factor_required) return data.challenge as string
             
             await checkAuth()
             navigate('/')
+            return null
         } catch (error) {
             console.error('Login error:', error)
             throw error
         }
     }
 
+    const loginTwoFactor = async (challenge: string, code: string) => {
+        try {
+            const response = await fetch(`${API_URL}/login/2fa`, {
+                method: 'POST',
+                credentials: 'include',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ challenge, code })
+            })
+
+            if (response.status === 429) throw new Error(
+                response.headers.get('Retry-After')
+                    ? tooManyAttemptsMessage(response)
+                    : 'Слишком много попыток, войдите заново'
+            )
+            if (response.status === 401) {
+                const data = await response.json()
+                throw new Error(data.message === 'Invalid code' ? 'Неверный код' : 'Время на ввод кода истекло, войдите заново')
+            }
+            if (!response.ok) throw new Error('Ошибка авторизации')
+
+            await checkAuth()
+            navigate('/')
+        } catch (error) {
+            console.error('Two-factor login error:', error)
+            throw error
+        }
+    }
+
     const logout = async () => {
         try {
             await fetch(`${API_URL}/logout`, {
@@ -82,13 +153,11 @@ export function useAuth() {
             })
 
             if (!response.ok) throw new Error('Ошибка регистрации')
-
-            await login(email, password)
         } catch (err) {
             console.error('Registration error:', err)
             throw err
         }
     }
 
-    return { user, loading, login, logout, register }
+    return { user, loading, login, loginTwoFactor, logout, register }
 }
\ No newline at end of file
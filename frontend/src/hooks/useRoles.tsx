@@ -2,7 +2,7 @@ import { useState, useEffect } from "react";
 import { Role } from "../models/roles";
 import { API_URL } from "../config";
 
-export function useRoles(userID: number) {
+export function useRoles() {
   const [roles, setRoles] = useState<Role[]>([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
@@ -12,7 +12,7 @@ export function useRoles(userID: number) {
       setLoading(true);
       setError(null);
 
-      const response = await fetch(`${API_URL}/users/${userID}/roles`, {
+      const response = await fetch(`${API_URL}/users/me/roles`, {
         method: "GET",
         credentials: "include",
       });
@@ -20,7 +20,7 @@ export function useRoles(userID: number) {
       if (!response.ok) throw new Error("Ошибка загрузки ролей");
 
       const data = await response.json();
-      setRoles(data);
+      setRoles(data ?? []);
     } catch (err) {
       setError(err instanceof Error ? err.message : "Неизвестная ошибка");
     } finally {
@@ -30,7 +30,7 @@ export function useRoles(userID: number) {
 
   useEffect(() => {
     fetchRoles();
-  }, [userID]);
+  }, []);
 
   return { roles, loading, error };
 }
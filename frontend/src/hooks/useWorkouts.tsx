@@ -1,39 +1,61 @@
 import { useState, useEffect } from "react";
 import { useAuth } from "./useAuth";
-import { Workout } from "../models/workouts";
+import { Workout, WorkoutListResponse } from "../models/workouts";
 import { API_URL } from "../config";
 
+const PAGE_SIZE = 50;
+
 export function useWorkouts() {
     const { user } = useAuth();
     const [workouts, setWorkouts] = useState<Workout[]>([]);
     const [loading, setLoading] = useState(true);
     const [error, setError] = useState<string | null>(null);
+    const [page, setPage] = useState(1);
+    const [total, setTotal] = useState(0);
+    const [loadingMore, setLoadingMore] = useState(false);
+
+    const fetchPage = async (pageNumber: number): Promise<WorkoutListResponse> => {
+        const response = await fetch(`${API_URL}/workouts?page=${pageNumber}&limit=${PAGE_SIZE}`, {
+            method: "GET",
+            credentials: "include",
+        });
+
+        if (!response.ok) {
+            throw new Error("Ошибка при загрузке тренировок")
+        }
+
+        return response.json();
+    }
 
     const fetchWorkouts = async () => {
         try {
             setLoading(true);
             setError(null);
 
-            const response = await fetch(`${API_URL}/workouts`, {
-                method: "GET",
-                credentials: "include",
-            });
-
-            if (response.status === 404) {
-                setWorkouts([]);
-                return
-            }
+            const data = await fetchPage(1);
+            setWorkouts(data.workouts);
+            setTotal(data.total);
+            setPage(1);
+        } catch (err) {
+            setError(err instanceof Error ? err.message : "Неизвестная ошибка");
+        } finally {
+            setLoading(false);
+        }
+    }
 
-            if (!response.ok) {
-                throw new Error("Ошибка при загрузке тренировок")
-            }
+    const loadMore = async () => {
+        try {
+            setLoadingMore(true);
+            setError(null);
 
-            const data = await response.json();
-            setWorkouts(data);
+            const data = await fetchPage(page + 1);
+            setWorkouts((prev) => [...prev, ...data.workouts]);
+            setTotal(data.total);
+            setPage(page + 1);
         } catch (err) {
             setError(err instanceof Error ? err.message : "Неизвестная ошибка");
         } finally {
-            setLoading(false);
+            setLoadingMore(false);
         }
     }
 
@@ -85,9 +107,13 @@ export function useWorkouts() {
 
     return {
         workouts,
+        total,
+        hasMore: workouts.length < total,
         loading,
+        loadingMore,
         error,
         fetchWorkouts,
+        loadMore,
         updateWorkout,
         deleteWorkout,
     }
@@ -2,4 +2,5 @@ export interface Role {
     id: number,
     name: string,
     description: string,
+    permissions?: string[],
 }
\ No newline at end of file
@@ -5,4 +5,11 @@ export interface Workout {
     notes: string,
     created_at: string,
     updated_at: string,
-}
\ No newline at end of file
+}
+
+export interface WorkoutListResponse {
+    workouts: Workout[],
+    total: number,
+    page: number,
+    limit: number,
+}
@@ -2,9 +2,9 @@ import { ExerciseFilters } from "../components/ExerciseFilters";
 import { useExercises } from "../hooks/useExercises";
 import { Exercise } from "../models/exercise";
 import { useCategories } from "../hooks/useCategories";
-import { useRoles } from "../hooks/useRoles";
+import { usePermissions } from "../hooks/usePermissions";
 import { useAuth } from "../hooks/useAuth";
-import { hasRole } from "../utils/roleHelpers";
+import { hasPermission } from "../utils/roleHelpers";
 import { API_URL } from "../config";
 import { useState } from "react";
 import { AddExerciseModal } from "../components/AddExerciseModal";
@@ -37,14 +37,14 @@ export default function Exercises() {
   } = useCategories();
 
   const { user, loading: loadingUser } = useAuth();
-  const { roles } = useRoles(user?.id || 0);
+  const { permissions } = usePermissions();
 
   const [editingExercise, setEditingExercise] = useState<Exercise | null>(null);
   const [addingExercise, setAddingExercise] = useState(false);
   const [operationError, setOperaionError] = useState<string | null>(null);
 
-  const isAdmin = user ? hasRole(roles, "admin") : false;
-  const isModerator = user ? hasRole(roles, "moderator") : false;
+  const canEditExercises = user ? hasPermission(permissions, "exercises:write") : false;
+  const canEditCategories = user ? hasPermission(permissions, "categories:write") : false;
 
   const [addingCategory, setAddingCategory] = useState(false);
   const [editingCategory, setEditingCategory] = useState<Category | null>(null);
@@ -138,7 +138,7 @@ export default function Exercises() {
     <div className="container mx-auto p-4">
       <h1 className="text-2xl font-bold mb-6">Упражнения</h1>
 
-      {(isAdmin || isModerator) && (
+      {canEditExercises && (
         <button
           onClick={() => setAddingExercise(true)}
           className="px-4 py-2 bg-blue-500 text-white rounded hover:bg-blue-600 mb-4"
@@ -168,8 +168,7 @@ export default function Exercises() {
               <ExerciseCard
                 key={exercise.id}
                 exercise={exercise}
-                isAdmin={isAdmin}
-                isModerator={isModerator}
+                canEdit={canEditExercises}
                 onEdit={() => setEditingExercise(exercise)}
                 onDelete={() => handleDeleteExercises(exercise.id)}
               />
@@ -234,7 +233,7 @@ export default function Exercises() {
         />
       )}
 
-      {isAdmin && (
+      {canEditCategories && (
         <div className="mt-8 p-4 bg-gray-50 rounded">
           <h2 className="text-lg font-semibold mb-4">Категории</h2>
           <button
@@ -292,14 +291,12 @@ export default function Exercises() {
 
 function ExerciseCard({
   exercise,
-  isAdmin,
-  isModerator,
+  canEdit,
   onEdit,
   onDelete,
 }: {
   exercise: Exercise;
-  isAdmin: boolean;
-  isModerator: boolean;
+  canEdit: boolean;
   onEdit: () => void;
   onDelete: () => void;
 }) {
@@ -308,7 +305,7 @@ function ExerciseCard({
       <h2 className="font-bold text-lg">{exercise.name}</h2>
       <p className="text-gray-600 mt-2">{exercise.description}</p>
 
-      {(isAdmin || isModerator) && (
+      {canEdit && (
         <div>
           <button
             onClick={onEdit}
@@ -6,7 +6,9 @@ export default function Login() {
     const [email, setEmail] = useState('')
     const [password, setPassword] = useState('')
     const [error, setError] = useState('')
-    const { login } = useAuth()
+    const [challenge, setChallenge] = useState<string | null>(null)
+    const [code, setCode] = useState('')
+    const { login, loginTwoFactor } = useAuth()
     const navigate = useNavigate()
 
     const handleSubmit = async (e: React.FormEvent) => {
@@ -14,15 +16,77 @@ export default function Login() {
 
 
         try {
-            await login(email, password)
+            const loginChallenge = await login(email, password)
+            if (loginChallenge) {
+                setError('')
+                setChallenge(loginChallenge)
+                return
+            }
             await new Promise(resolve => setTimeout(resolve, 100))
             navigate('/')
         } catch(err) {
-            setError('Неверный email или пароль')
+            setError(err instanceof Error ? err.message : 'Неверный email или пароль')
             console.error('Ошибка входа:', err)
         }
     }
 
+    const handleCodeSubmit = async (e: React.FormEvent) => {
+        e.preventDefault();
+
+        if (!challenge) return
+
+        try {
+            await loginTwoFactor(challenge, code)
+            await new Promise(resolve => setTimeout(resolve, 100))
+            navigate('/')
+        } catch(err) {
+            const message = err instanceof Error ? err.message : 'Неверный код'
+            setError(message)
+            setCode('')
+            if (message !== 'Неверный код') {
+                setChallenge(null)
+            }
+            console.error('Ошибка входа:', err)
+        }
+    }
+
+    if (challenge) {
+        return (
+            <div className='max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md'>
+                <h1 className='text-2xl font-bold mb-6 text-center'>
+                    Двухфакторная аутентификация
+                </h1>
+                {error && <div className='mb-4 p-2 bg-red-100 text-red-700 rounded'>{error}</div>}
+
+                <form onSubmit={handleCodeSubmit} className='space-y-4'>
+                    <div>
+                        <label htmlFor='code' className='block mb-1 font-medium'>
+                            Код из приложения или код восстановления
+                        </label>
+                        <input
+                            id='code'
+                            type='text'
+                            inputMode='text'
+                            autoComplete='one-time-code'
+                            value={code}
+                            onChange={(e) => setCode(e.target.value)}
+                            className='w-full px-3 py-2 border rounded-md'
+                            autoFocus
+                            required
+                        />
+                    </div>
+
+                    <button
+                        type='submit'
+                        className='w-full bg-blue-600 text-white py-2 px-4 rounded-md hover:bg-blue-700'
+                    >
+                        Подтвердить
+                    </button>
+                </form>
+            </div>
+        )
+    }
+
     return (
         <div className='max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md'>
             <h1 className='text-2xl font-bold mb-6 text-center'>
@@ -67,6 +131,14 @@ export default function Login() {
                 </button>
             </form>
             <div className='mt-4 text-center'>
+                <Link
+                    to='/reset-password'
+                    className='text-blue-600 hover:underline'
+                >
+                    Забыли пароль?
+                </Link>
+            </div>
+            <div className='mt-2 text-center'>
                 Нет аккаунта?{' '}
                 <Link
                     to='/register'
@@ -4,7 +4,7 @@ import { useRoles } from "../hooks/useRoles";
 
 export default function Profile() {
   const { user } = useAuth();
-  const { roles } = useRoles(user?.id || 0);
+  const { roles } = useRoles();
   return (
     <div className="max-w-4xl mx-auto px-4 py-8">
       <div className="mb-8">
@@ -1,4 +1,4 @@
-import { Link, useNavigate } from "react-router-dom";
+import { Link } from "react-router-dom";
 import { useState } from "react";
 import { useAuth } from "../hooks/useAuth";
 
@@ -8,8 +8,8 @@ export default function Register() {
     const [username, setUsername] = useState('')
     const [password, setPassword] = useState('')
     const [error, setError] = useState('')
+    const [registered, setRegistered] = useState(false)
     const { register } = useAuth()
-    const navigate = useNavigate()
 
     const handleSubmit = async (e: React.FormEvent) => {
         e.preventDefault()
@@ -17,13 +17,32 @@ export default function Register() {
 
         try {
             await register(email, password, username);
-            navigate('/')
+            setRegistered(true)
         } catch (err) {
             setError(err instanceof Error ? err.message : 'Ошибка регистрации')
         }
     }
 
 
+    if (registered) {
+        return (
+            <div className="max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md text-center">
+                <h1 className="text-2xl font-bold mb-4">
+                    Проверьте почту
+                </h1>
+                <p className="mb-4">
+                    Мы отправили письмо на {email}. Перейдите по ссылке из письма, чтобы подтвердить email.
+                </p>
+                <Link
+                    to='/login'
+                    className="text-blue-600 hover:underline"
+                >
+                    Перейти ко входу
+                </Link>
+            </div>
+        )
+    }
+
     return (
         <div className="max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md">
             <h1 className="text-2xl font-bold mb-6 text-center">
@@ -0,0 +1,109 @@
+import { useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { API_URL } from "../config";
+
+export default function ResetPassword() {
+    const [searchParams] = useSearchParams()
+    const token = searchParams.get('token')
+    const [email, setEmail] = useState('')
+    const [password, setPassword] = useState('')
+    const [error, setError] = useState('')
+    const [done, setDone] = useState(false)
+
+    const handleSubmit = async (e: React.FormEvent) => {
+        e.preventDefault()
+        setError('')
+
+        try {
+            const response = token
+                ? await fetch(`${API_URL}/auth/password-reset/confirm`, {
+                    method: 'POST',
+                    credentials: 'include',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ token, password })
+                })
+                : await fetch(`${API_URL}/auth/password-reset/request`, {
+                    method: 'POST',
+                    credentials: 'include',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ email })
+                })
+
+            if (!response.ok) {
+                throw new Error(token ? 'Ссылка устарела или уже была использована' : 'Ошибка при отправке письма')
+            }
+
+            setDone(true)
+        } catch (err) {
+            setError(err instanceof Error ? err.message : 'Неизвестная ошибка')
+        }
+    }
+
+    if (done) {
+        return (
+            <div className="max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md text-center">
+                <p className="mb-4">
+                    {token
+                        ? 'Пароль изменён, войдите с новым паролем.'
+                        : 'Если аккаунт с таким email существует, мы отправили на него ссылку для сброса пароля.'}
+                </p>
+                <Link to='/login' className="text-blue-600 hover:underline">
+                    Перейти ко входу
+                </Link>
+            </div>
+        )
+    }
+
+    return (
+        <div className="max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md">
+            <h1 className="text-2xl font-bold mb-6 text-center">
+                Сброс пароля
+            </h1>
+
+            {error && (
+                <div className="mb-4 p-2 bg-red-100 text-red-700 rounded text-sm">
+                    {error}
+                </div>
+            )}
+
+            <form onSubmit={handleSubmit} className='space-y-4'>
+                {token ? (
+                    <div>
+                        <label htmlFor='password' className='block mb-1 font-medium'>
+                            Новый пароль
+                        </label>
+                        <input
+                            id='password'
+                            type='password'
+                            value={password}
+                            onChange={(e) => setPassword(e.target.value)}
+                            className='w-full px-3 py-2 border rounded-md'
+                            required
+                        />
+                    </div>
+                ) : (
+                    <div>
+                        <label htmlFor='email' className='block mb-1 font-medium'>
+                            Email
+                        </label>
+                        <input
+                            id='email'
+                            type='email'
+                            value={email}
+                            onChange={(e) => setEmail(e.target.value)}
+                            className='w-full px-3 py-2 border rounded-md'
+                            required
+                        />
+                    </div>
+                )}
+
+                <button
+                    type='submit'
+                    className='w-full bg-blue-600 text-white py-2 px-4 rounded-md hover:bg-blue-700'
+                >
+                    {token ? 'Сохранить пароль' : 'Отправить ссылку'}
+                </button>
+            </form>
+        </div>
+    )
+}
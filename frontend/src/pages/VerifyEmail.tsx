@@ -0,0 +1,49 @@
+import { useEffect, useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { API_URL } from "../config";
+
+export default function VerifyEmail() {
+    const [searchParams] = useSearchParams()
+    const [status, setStatus] = useState<'loading' | 'success' | 'error'>('loading')
+
+    useEffect(() => {
+        const token = searchParams.get('token')
+        if (!token) {
+            setStatus('error')
+            return
+        }
+
+        fetch(`${API_URL}/auth/verify-email/confirm`, {
+            method: 'POST',
+            credentials: 'include',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({ token })
+        })
+            .then((response) => setStatus(response.ok ? 'success' : 'error'))
+            .catch(() => setStatus('error'))
+    }, [searchParams])
+
+    return (
+        <div className="max-w-md mx-auto mt-10 p-6 bg-white rounded-lg shadow-md text-center">
+            {status === 'loading' && <p>Подтверждаем email...</p>}
+
+            {status === 'success' && (
+                <>
+                    <h1 className="text-2xl font-bold mb-4">Email подтверждён</h1>
+                    <Link to='/login' className="text-blue-600 hover:underline">
+                        Войти
+                    </Link>
+                </>
+            )}
+
+            {status === 'error' && (
+                <>
+                    <h1 className="text-2xl font-bold mb-4">Ссылка недействительна</h1>
+                    <p>Ссылка устарела или уже была использована.</p>
+                </>
+            )}
+        </div>
+    )
+}
@@ -6,7 +6,7 @@ import { EditWorkoutForm } from "../components/EditWorkuotForm";
 import { Link } from "react-router-dom";
 
 export default function Workouts() {
-    const { workouts, loading, error, fetchWorkouts, updateWorkout, deleteWorkout } = useWorkouts();
+    const { workouts, hasMore, loading, loadingMore, error, fetchWorkouts, loadMore, updateWorkout, deleteWorkout } = useWorkouts();
 
     const [editingWorkout, setEditingWorkout] = useState<Workout | null>(null);
 
@@ -36,6 +36,16 @@ export default function Workouts() {
                 </div>
             )}
 
+            {hasMore && (
+                <button
+                    onClick={() => loadMore()}
+                    disabled={loadingMore}
+                    className="mt-4 bg-gray-200 py-2 px-4 rounded hover:bg-gray-300 disabled:opacity-50"
+                >
+                    {loadingMore ? "Загрузка..." : "Показать ещё"}
+                </button>
+            )}
+
 
             {editingWorkout && (
                 <EditWorkoutForm
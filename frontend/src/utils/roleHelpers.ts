@@ -3,3 +3,7 @@ import { Role } from "../models/roles";
 export const hasRole = (roles: Role[], roleName: string): boolean => {
   return roles.some(role => role.name === roleName);
 };
+
+export const hasPermission = (permissions: string[], permission: string): boolean => {
+  return permissions.includes(permission);
+};